@@ -117,6 +117,10 @@ const (
 	FieldAuthLoginJWT                  = "auth_login_jwt"
 	FieldAuthLoginAzure                = "auth_login_azure"
 	FieldAuthLoginTokenFile            = "auth_login_token_file"
+	FieldAuthLoginCF                   = "auth_login_cf"
+	FieldAuthLoginAliCloud             = "auth_login_alicloud"
+	FieldAuthLoginAppRole              = "auth_login_approle"
+	FieldAuthLoginKubernetes           = "auth_login_kubernetes"
 	FieldIAMHttpRequestMethod          = "iam_http_request_method"
 	FieldIAMRequestURL                 = "iam_request_url"
 	FieldIAMRequestBody                = "iam_request_body"
@@ -375,6 +379,184 @@ const (
 	FieldTags                          = "tags"
 	FieldCustomTags                    = "custom_tags"
 	FieldSecretNameTemplate            = "secret_name_template"
+	FieldCSRFile                       = "csr_file"
+	FieldSigningCommand                = "signing_command"
+	FieldSignedCertificateFile         = "signed_certificate_file"
+	FieldIssuerChain                   = "issuer_chain"
+	FieldCommand                       = "command"
+	FieldTimeout                       = "timeout"
+	FieldPEM                           = "pem"
+	FieldCertificates                  = "certificates"
+	FieldSubject                       = "subject"
+	FieldDNSNames                      = "dns_names"
+	FieldURIs                          = "uris"
+	FieldEmailAddresses                = "email_addresses"
+	FieldNotBefore                     = "not_before"
+	FieldNotAfter                      = "not_after"
+	FieldIsCA                          = "is_ca"
+	FieldSHA1Fingerprint               = "sha1_fingerprint"
+	FieldSHA256Fingerprint             = "sha256_fingerprint"
+	FieldPublicKeyAlgorithm            = "public_key_algorithm"
+	FieldSignatureAlgorithm            = "signature_algorithm"
+	FieldSubjectKeyID                  = "subject_key_id"
+	FieldAuthorityKeyID                = "authority_key_id"
+	FieldChainVerified                 = "chain_verified"
+	FieldChainVerificationError        = "chain_verification_error"
+	FieldIncludeDelta                  = "include_delta"
+	FieldIncludeUnified                = "include_unified"
+	FieldOCSP                          = "ocsp"
+	FieldOCSPResponses                 = "ocsp_responses"
+	FieldCRLs                          = "crls"
+	FieldThisUpdate                    = "this_update"
+	FieldNextUpdate                    = "next_update"
+	FieldCRLNumber                     = "crl_number"
+	FieldEntryCount                    = "entry_count"
+	FieldStale                         = "stale"
+	FieldSignatureValid                = "signature_valid"
+	FieldSerialRevoked                 = "serial_revoked"
+	FieldStatus                        = "status"
+	FieldRevoked                       = "revoked"
+	FieldRevokedAt                     = "revoked_at"
+	FieldPKIRolePolicy                 = "pki_role_policy"
+	FieldDenyAllowAnyName              = "deny_allow_any_name"
+	FieldDenyAllowGlobDomains          = "deny_allow_glob_domains"
+	FieldRequireEnforceHostnames       = "require_enforce_hostnames"
+	FieldRequiredPolicyIdentifiers     = "required_policy_identifiers"
+	FieldRoot                          = "root"
+	FieldIntermediate                  = "intermediate"
+	FieldParent                        = "parent"
+	FieldURLBase                       = "url_base"
+	FieldCRLExpiry                     = "crl_expiry"
+	FieldCRLAutoRebuild                = "crl_auto_rebuild"
+	FieldIssuers                       = "issuers"
+	FieldExpiry                        = "expiry"
+	FieldAutoRebuild                   = "auto_rebuild"
+	FieldFromEntityIDs                 = "from_entity_ids"
+	FieldToEntityID                    = "to_entity_id"
+	FieldForce                         = "force"
+	FieldConflictingAliasIDsToKeep     = "conflicting_alias_ids_to_keep"
+	FieldMergedEntityIDs               = "merged_entity_ids"
+	FieldAliasIDs                      = "alias_ids"
+	FieldDisabled                      = "disabled"
+	FieldAliasMountAccessor            = "alias_mount_accessor"
+	FieldMaxParallelReads              = "max_parallel_reads"
+	FieldIDs                           = "ids"
+	FieldEntities                      = "entities"
+	FieldGroups                        = "groups"
+	FieldMember                        = "member"
+	FieldAliasName                     = "alias_name"
+	FieldCreateMissingEntities         = "create_missing_entities"
+	FieldCreatedEntityIDs              = "created_entity_ids"
+	FieldNoAliases                     = "no_aliases"
+	FieldInactiveDays                  = "inactive_days"
+	FieldNamePattern                   = "name_pattern"
+	FieldExcludeEntityIDs              = "exclude_entity_ids"
+	FieldExcludeNames                  = "exclude_names"
+	FieldDryRun                        = "dry_run"
+	FieldBatchSize                     = "batch_size"
+	FieldBatchInterval                 = "batch_interval"
+	FieldMatchedEntityIDs              = "matched_entity_ids"
+	FieldDeletedEntityIDs              = "deleted_entity_ids"
+	FieldClaims                        = "claims"
+	FieldClaimsJSON                    = "claims_json"
+	FieldAudience                      = "audience"
+	FieldIssuedAt                      = "issued_at"
+	FieldExpiresAt                     = "expires_at"
+	FieldActive                        = "active"
+	FieldError                         = "error"
+	FieldClientName                    = "client_name"
+	FieldEntityToken                   = "entity_token"
+	FieldScopes                        = "scopes"
+	FieldRedirectURI                   = "redirect_uri"
+	FieldExpectedAlgorithm             = "expected_algorithm"
+	FieldExpectedClaims                = "expected_claims"
+	FieldPassed                        = "passed"
+	FieldChecks                        = "checks"
+	FieldMessage                       = "message"
+	FieldSource                        = "source"
+	FieldValue                         = "value"
+	FieldExpected                      = "expected"
+	FieldIDTokenClaimsJSON             = "id_token_claims_json"
+	FieldPolicyHCL                     = "policy_hcl"
+	FieldEntityID                      = "entity_id"
+	FieldRequest                       = "request"
+	FieldOperation                     = "operation"
+	FieldAllowed                       = "allowed"
+	FieldCapabilities                  = "capabilities"
+	FieldMatchedPath                   = "matched_path"
+	FieldVaultCapabilities             = "vault_capabilities"
+	FieldVaultAllowed                  = "vault_allowed"
+	FieldUnresolvedPaths               = "unresolved_paths"
+	FieldSentinelBinary                = "sentinel_binary"
+	FieldTest                          = "test"
+	FieldGlobals                       = "globals"
+	FieldExpectedResult                = "expected_result"
+	FieldValidateIdentityTemplates     = "validate_identity_templates"
+	FieldGeneratedPassword             = "generated_password"
+	FieldPasswordLastRotated           = "password_last_rotated"
+	FieldDisableBinding                = "disable_binding"
+	FieldEnableIdentityAliasMetadata   = "enable_identity_alias_metadata"
+	FieldOCSPCacheSize                 = "ocsp_cache_size"
+	FieldRoleCacheSize                 = "role_cache_size"
+	FieldCRL                           = "crl"
+	FieldKeytab                        = "keytab"
+	FieldAddGroupAliases               = "add_group_aliases"
+	FieldLDAP                          = "ldap"
+	FieldGroupDN                       = "groupdn"
+	FieldGroupFilter                   = "groupfilter"
+	FieldGroupAttr                     = "groupattr"
+	FieldDiscoverDN                    = "discoverdn"
+	FieldDenyNullBind                  = "deny_null_bind"
+	FieldCaseSensitiveNames            = "case_sensitive_names"
+	FieldUsernameAsAlias               = "username_as_alias"
+	FieldHost                          = "host"
+	FieldPort                          = "port"
+	FieldSecret                        = "secret"
+	FieldUnregisteredUserPolicies      = "unregistered_user_policies"
+	FieldDialTimeout                   = "dial_timeout"
+	FieldReadTimeout                   = "read_timeout"
+	FieldNASPort                       = "nas_port"
+	FieldNASIdentifier                 = "nas_identifier"
+	FieldHomeTenancyID                 = "home_tenancy_id"
+	FieldOCIDList                      = "ocid_list"
+	FieldIdentityCACertificates        = "identity_ca_certificates"
+	FieldCFAPIAddr                     = "cf_api_addr"
+	FieldCFUsername                    = "cf_username"
+	FieldCFPassword                    = "cf_password"
+	FieldCFClientID                    = "cf_client_id"
+	FieldCFClientSecret                = "cf_client_secret"
+	FieldCFAPITrustedCertificates      = "cf_api_trusted_certificates"
+	FieldCFAPIMutualTLSCertificate     = "cf_api_mutual_tls_certificate"
+	FieldCFAPIMutualTLSKey             = "cf_api_mutual_tls_key"
+	FieldLoginMaxSecondsNotBefore      = "login_max_seconds_not_before"
+	FieldLoginMaxSecondsNotAfter       = "login_max_seconds_not_after"
+	FieldCFTimeout                     = "cf_timeout"
+	FieldBoundApplicationIDs           = "bound_application_ids"
+	FieldBoundSpaceIDs                 = "bound_space_ids"
+	FieldBoundOrganizationIDs          = "bound_organization_ids"
+	FieldBoundInstanceIDs              = "bound_instance_ids"
+	FieldDisableIPMatching             = "disable_ip_matching"
+	FieldCFInstanceCert                = "cf_instance_cert"
+	FieldCFInstanceKey                 = "cf_instance_key"
+	FieldSigningTime                   = "signing_time"
+	FieldSignature                     = "signature"
+	FieldSecurityToken                 = "security_token"
+	FieldIdentityRequestURL            = "identity_request_url"
+	FieldIdentityRequestHeaders        = "identity_request_headers"
+	FieldSampleJWT                     = "sample_jwt"
+	FieldRoleResults                   = "role_results"
+	FieldAcceptedRoles                 = "accepted_roles"
+	FieldAccepted                      = "accepted"
+	FieldSecretID                      = "secret_id"
+	FieldTokenType                     = "token_type"
+	FieldIdentityPolicies              = "identity_policies"
+	FieldAliasMetadata                 = "alias_metadata"
+	FieldLoginPath                     = "login_path"
+	FieldKubeconfig                    = "kubeconfig"
+	FieldContext                       = "context"
+	FieldNamespaceValidation           = "namespace_validation"
+	FieldTokenReviewerJWT              = "token_reviewer_jwt"
+	FieldBoundServiceAccountNamespaces = "bound_service_account_namespaces"
 
 	/*
 		common environment variables
@@ -409,6 +591,21 @@ const (
 	EnvVarRadiusPassword = "RADIUS_PASSWORD"
 	// EnvVarTokenFilename for the TokenFile auth login.
 	EnvVarTokenFilename = "TERRAFORM_VAULT_TOKEN_FILENAME"
+	// EnvVarCFInstanceCert path of the CF instance certificate for the CF auth login.
+	EnvVarCFInstanceCert = "CF_INSTANCE_CERT"
+	// EnvVarCFInstanceKey path of the CF instance key for the CF auth login.
+	EnvVarCFInstanceKey = "CF_INSTANCE_KEY"
+	// EnvVarAliCloudAccessKey for the AliCloud auth login.
+	EnvVarAliCloudAccessKey = "ALICLOUD_ACCESS_KEY"
+	// EnvVarAliCloudSecretKey for the AliCloud auth login.
+	EnvVarAliCloudSecretKey = "ALICLOUD_SECRET_KEY"
+	// EnvVarAliCloudSecurityToken for the AliCloud auth login.
+	EnvVarAliCloudSecurityToken = "ALICLOUD_SECURITY_TOKEN"
+	// EnvVarAliCloudRegion for the AliCloud auth login.
+	EnvVarAliCloudRegion = "ALICLOUD_REGION"
+	// EnvVarSentinelBinary path of the sentinel binary.
+	EnvVarSentinelBinary = "TERRAFORM_VAULT_SENTINEL_BINARY"
+
 	/*
 		common mount types
 	*/
@@ -436,6 +633,9 @@ const (
 	MountTypeTerraform    = "terraform"
 	MountTypeNone         = "none"
 	MountTypeSAML         = "saml"
+	MountTypeCF           = "cf"
+	MountTypeAliCloud     = "alicloud"
+	MountTypeAppRole      = "approle"
 
 	/*
 		Vault version constants
@@ -452,16 +652,20 @@ const (
 	/*
 		Vault auth methods
 	*/
-	AuthMethodAWS      = "aws"
-	AuthMethodUserpass = "userpass"
-	AuthMethodCert     = "cert"
-	AuthMethodGCP      = "gcp"
-	AuthMethodKerberos = "kerberos"
-	AuthMethodRadius   = "radius"
-	AuthMethodOCI      = "oci"
-	AuthMethodOIDC     = "oidc"
-	AuthMethodJWT      = "jwt"
-	AuthMethodAzure    = "azure"
+	AuthMethodAWS        = "aws"
+	AuthMethodUserpass   = "userpass"
+	AuthMethodCert       = "cert"
+	AuthMethodGCP        = "gcp"
+	AuthMethodKerberos   = "kerberos"
+	AuthMethodRadius     = "radius"
+	AuthMethodOCI        = "oci"
+	AuthMethodOIDC       = "oidc"
+	AuthMethodJWT        = "jwt"
+	AuthMethodAzure      = "azure"
+	AuthMethodCF         = "cf"
+	AuthMethodAliCloud   = "alicloud"
+	AuthMethodAppRole    = "approle"
+	AuthMethodKubernetes = "kubernetes"
 
 	/*
 		misc. path related constants
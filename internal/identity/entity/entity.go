@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -165,6 +166,73 @@ func LookupEntityAlias(client *api.Client, params *FindAliasParams) (*Alias, err
 
 	return nil, nil
 }
+
+// ReadAll lists the IDs under listPath and reads each one from listPath/<id>,
+// with at most maxParallel reads in flight. The results are returned in
+// listing order, IDs that are deleted between the list and the read are
+// skipped.
+func ReadAll(ctx context.Context, client *api.Client, listPath string, maxParallel int) ([]map[string]interface{}, error) {
+	resp, err := client.Logical().ListWithContext(ctx, listPath)
+	if resp == nil || err != nil {
+		return nil, err
+	}
+
+	keys, ok := resp.Data["keys"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	ids := make([]string, len(keys))
+	for i, k := range keys {
+		id, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected key %v listed from %q", k, listPath)
+		}
+		ids[i] = id
+	}
+
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+
+	results := make([]map[string]interface{}, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			path := fmt.Sprintf("%s/%s", listPath, id)
+			resp, err := client.Logical().ReadWithContext(ctx, path)
+			if err != nil {
+				errs[i] = fmt.Errorf("error reading %q: %w", path, err)
+				return
+			}
+			if resp != nil {
+				results[i] = resp.Data
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var result []map[string]interface{}
+	for _, data := range results {
+		if data != nil {
+			result = append(result, data)
+		}
+	}
+
+	return result, nil
+}
+
 func WithMinRetryWait(d time.Duration) func(client *api.Client) {
 	return func(client *api.Client) {
 		client.SetMinRetryWait(d)
@@ -4,6 +4,7 @@
 package entity
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -521,3 +522,98 @@ func TestLookupEntityAlias(t *testing.T) {
 		})
 	}
 }
+
+func TestReadAll(t *testing.T) {
+	t.Parallel()
+
+	entities := []*Entity{
+		{
+			ID:   "C6D3410E-86AF-4A10-9282-4B1E9773932A",
+			Name: "bob",
+		},
+		{
+			ID:   "C6D3410E-86AF-4A10-9282-4B1E9773932B",
+			Name: "alice",
+		},
+		{
+			ID:   "C6D3410E-86AF-4A10-9282-4B1E9773932C",
+			Name: "carol",
+		},
+	}
+	tests := []struct {
+		name        string
+		maxParallel int
+		findHandler *testFindAliasHandler
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "empty",
+			maxParallel: 2,
+			findHandler: &testFindAliasHandler{},
+			want:        nil,
+		},
+		{
+			name:        "all",
+			maxParallel: 2,
+			findHandler: &testFindAliasHandler{
+				entities: entities,
+			},
+			want: []string{"bob", "alice", "carol"},
+		},
+		{
+			name:        "serial",
+			maxParallel: 0,
+			findHandler: &testFindAliasHandler{
+				entities: entities,
+			},
+			want: []string{"bob", "alice", "carol"},
+		},
+		{
+			name:        "error-on-list",
+			maxParallel: 2,
+			findHandler: &testFindAliasHandler{
+				wantErrOnList: true,
+			},
+			wantErr: true,
+		},
+		{
+			name:        "error-on-read",
+			maxParallel: 2,
+			findHandler: &testFindAliasHandler{
+				entities:      entities,
+				wantErrOnRead: true,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config, ln := testutil.TestHTTPServer(t, tt.findHandler.handler())
+			defer ln.Close()
+
+			config.Address = fmt.Sprintf("http://%s", ln.Addr())
+			config.MaxRetries = 0
+			c, err := api.NewClient(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadAll(context.Background(), c, RootEntityIDPath, tt.maxParallel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var names []string
+			for _, data := range got {
+				names = append(names, data["name"].(string))
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("ReadAll() got = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	pemTypeCertificate        = "CERTIFICATE"
+	pemTypeCertificateRequest = "CERTIFICATE REQUEST"
+	pemTypeNewCertRequest     = "NEW CERTIFICATE REQUEST"
+)
+
+// ParseCertificates parses all PEM encoded certificates found in data, in
+// the order they appear. Non-certificate PEM blocks are ignored. An error is
+// returned if no certificates are found.
+func ParseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var b *pem.Block
+		b, rest = pem.Decode(rest)
+		if b == nil {
+			break
+		}
+
+		if b.Type != pemTypeCertificate {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(b.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d: %w", len(certs), err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificates found")
+	}
+
+	return certs, nil
+}
+
+// ParseCertificateRequest parses the first PEM encoded certificate signing
+// request found in data.
+func ParseCertificateRequest(data string) (*x509.CertificateRequest, error) {
+	rest := []byte(data)
+	for {
+		var b *pem.Block
+		b, rest = pem.Decode(rest)
+		if b == nil {
+			break
+		}
+
+		switch b.Type {
+		case pemTypeCertificateRequest, pemTypeNewCertRequest:
+			csr, err := x509.ParseCertificateRequest(b.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate request: %w", err)
+			}
+			return csr, nil
+		}
+	}
+
+	return nil, errors.New("no PEM encoded certificate request found")
+}
+
+// EncodeCertificate returns the PEM encoding of cert.
+func EncodeCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  pemTypeCertificate,
+		Bytes: cert.Raw,
+	}))
+}
+
+// SerialNumber formats the certificate's serial number the same way Vault
+// does, as colon separated hex octets.
+func SerialNumber(cert *x509.Certificate) string {
+	return formatOctets(cert.SerialNumber.Bytes())
+}
+
+// PublicKeysEqual reports whether the certificate and the certificate request
+// carry the same public key.
+func PublicKeysEqual(cert *x509.Certificate, csr *x509.CertificateRequest) (bool, error) {
+	certKey, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal certificate public key: %w", err)
+	}
+
+	csrKey, err := x509.MarshalPKIXPublicKey(csr.PublicKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal certificate request public key: %w", err)
+	}
+
+	return bytes.Equal(certKey, csrKey), nil
+}
+
+// VerifyChain verifies that leaf chains up to one of the self-signed
+// certificates in chain, using any remaining certificates in chain as
+// intermediates. Key usage is not enforced, since CA certificates are
+// commonly verified with this function.
+func VerifyChain(leaf *x509.Certificate, chain []*x509.Certificate) ([][]*x509.Certificate, error) {
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, c := range chain {
+		if bytes.Equal(c.RawIssuer, c.RawSubject) && c.CheckSignatureFrom(c) == nil {
+			roots.AddCert(c)
+		} else {
+			intermediates.AddCert(c)
+		}
+	}
+
+	return leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+}
+
+// ParseSerialNumber parses a certificate serial number formatted as hex
+// octets, optionally separated by colons or dashes as Vault does.
+func ParseSerialNumber(serial string) (*big.Int, error) {
+	s := strings.NewReplacer(":", "", "-", "").Replace(strings.TrimSpace(serial))
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok || s == "" {
+		return nil, fmt.Errorf("invalid serial number %q", serial)
+	}
+	return n, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+var (
+	oidExtensionCertificatePolicies = asn1.ObjectIdentifier{2, 5, 29, 32}
+	oidPolicyQualifierCPS           = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 1}
+	oidPolicyQualifierUserNotice    = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 2}
+)
+
+// keyUsageNames follows the naming used by the key_usage field of PKI roles.
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "DigitalSignature"},
+	{x509.KeyUsageContentCommitment, "ContentCommitment"},
+	{x509.KeyUsageKeyEncipherment, "KeyEncipherment"},
+	{x509.KeyUsageDataEncipherment, "DataEncipherment"},
+	{x509.KeyUsageKeyAgreement, "KeyAgreement"},
+	{x509.KeyUsageCertSign, "CertSign"},
+	{x509.KeyUsageCRLSign, "CRLSign"},
+	{x509.KeyUsageEncipherOnly, "EncipherOnly"},
+	{x509.KeyUsageDecipherOnly, "DecipherOnly"},
+}
+
+// extKeyUsages follows the naming used by the ext_key_usage field of PKI roles.
+var extKeyUsages = map[x509.ExtKeyUsage]struct {
+	name string
+	oid  string
+}{
+	x509.ExtKeyUsageAny:                            {"Any", "2.5.29.37.0"},
+	x509.ExtKeyUsageServerAuth:                     {"ServerAuth", "1.3.6.1.5.5.7.3.1"},
+	x509.ExtKeyUsageClientAuth:                     {"ClientAuth", "1.3.6.1.5.5.7.3.2"},
+	x509.ExtKeyUsageCodeSigning:                    {"CodeSigning", "1.3.6.1.5.5.7.3.3"},
+	x509.ExtKeyUsageEmailProtection:                {"EmailProtection", "1.3.6.1.5.5.7.3.4"},
+	x509.ExtKeyUsageIPSECEndSystem:                 {"IPSECEndSystem", "1.3.6.1.5.5.7.3.5"},
+	x509.ExtKeyUsageIPSECTunnel:                    {"IPSECTunnel", "1.3.6.1.5.5.7.3.6"},
+	x509.ExtKeyUsageIPSECUser:                      {"IPSECUser", "1.3.6.1.5.5.7.3.7"},
+	x509.ExtKeyUsageTimeStamping:                   {"TimeStamping", "1.3.6.1.5.5.7.3.8"},
+	x509.ExtKeyUsageOCSPSigning:                    {"OCSPSigning", "1.3.6.1.5.5.7.3.9"},
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     {"MicrosoftServerGatedCrypto", "1.3.6.1.4.1.311.10.3.3"},
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      {"NetscapeServerGatedCrypto", "2.16.840.1.113730.4.1"},
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: {"MicrosoftCommercialCodeSigning", "1.3.6.1.4.1.311.2.1.22"},
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     {"MicrosoftKernelCodeSigning", "1.3.6.1.4.1.311.61.1.1"},
+}
+
+type policyInformation struct {
+	Policy     asn1.ObjectIdentifier
+	Qualifiers []policyQualifierInfo `asn1:"optional"`
+}
+
+type policyQualifierInfo struct {
+	QualifierID asn1.ObjectIdentifier
+	Qualifier   asn1.RawValue
+}
+
+// KeyUsageNames returns the names of the key usages set on cert.
+func KeyUsageNames(cert *x509.Certificate) []string {
+	var names []string
+	for _, ku := range keyUsageNames {
+		if cert.KeyUsage&ku.usage != 0 {
+			names = append(names, ku.name)
+		}
+	}
+	return names
+}
+
+// ExtKeyUsageNames returns the names of the well known extended key usages
+// set on cert. Unknown extended key usages are only reported by
+// ExtKeyUsageOIDs.
+func ExtKeyUsageNames(cert *x509.Certificate) []string {
+	var names []string
+	for _, eku := range cert.ExtKeyUsage {
+		if v, ok := extKeyUsages[eku]; ok {
+			names = append(names, v.name)
+		}
+	}
+	return names
+}
+
+// ExtKeyUsageOIDs returns the OIDs of all extended key usages set on cert,
+// including the ones unknown to the x509 package.
+func ExtKeyUsageOIDs(cert *x509.Certificate) []string {
+	var oids []string
+	for _, eku := range cert.ExtKeyUsage {
+		if v, ok := extKeyUsages[eku]; ok {
+			oids = append(oids, v.oid)
+		}
+	}
+	for _, oid := range cert.UnknownExtKeyUsage {
+		oids = append(oids, oid.String())
+	}
+	return oids
+}
+
+// Fingerprints returns the hex encoded SHA-1 and SHA-256 fingerprints of
+// cert, formatted as colon separated octets.
+func Fingerprints(cert *x509.Certificate) (string, string) {
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+	return formatOctets(sha1Sum[:]), formatOctets(sha256Sum[:])
+}
+
+// PolicyIdentifiers returns the certificate policies of cert in the same
+// serialized form Vault uses for the policy_identifiers field of PKI roles,
+// i.e. one JSON object per policy with its OID and optional CPS and notice
+// qualifiers. The result can be fed to MakePkiPolicyIdentifiersListOrSet.
+func PolicyIdentifiers(cert *x509.Certificate) ([]interface{}, error) {
+	var policies []interface{}
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidExtensionCertificatePolicies) {
+			continue
+		}
+
+		var infos []policyInformation
+		if rest, err := asn1.Unmarshal(ext.Value, &infos); err != nil {
+			return nil, fmt.Errorf("failed to parse certificate policies: %w", err)
+		} else if len(rest) != 0 {
+			return nil, fmt.Errorf("trailing data after certificate policies")
+		}
+
+		for _, info := range infos {
+			policy := map[string]string{
+				consts.FieldOID: info.Policy.String(),
+			}
+			for _, q := range info.Qualifiers {
+				switch {
+				case q.QualifierID.Equal(oidPolicyQualifierCPS):
+					policy[consts.FieldCPS] = string(q.Qualifier.Bytes)
+				case q.QualifierID.Equal(oidPolicyQualifierUserNotice):
+					// UserNotice is a sequence of an optional NoticeReference
+					// sequence and an optional DisplayText string.
+					var notice []asn1.RawValue
+					if _, err := asn1.Unmarshal(q.Qualifier.FullBytes, &notice); err != nil {
+						return nil, fmt.Errorf("failed to parse user notice of policy %s: %w", info.Policy, err)
+					}
+					for _, v := range notice {
+						if !v.IsCompound {
+							policy[consts.FieldNotice] = string(v.Bytes)
+						}
+					}
+				}
+			}
+
+			b, err := json.Marshal(policy)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, string(b))
+		}
+	}
+
+	return policies, nil
+}
+
+func formatOctets(b []byte) string {
+	s := hex.EncodeToString(b)
+	octets := make([]string, 0, len(b))
+	for i := 0; i < len(s); i += 2 {
+		octets = append(octets, s[i:i+2])
+	}
+	return strings.Join(octets, ":")
+}
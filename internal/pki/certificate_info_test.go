@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPolicyIdentifiers(t *testing.T) {
+	notice, err := asn1.Marshal([]asn1.RawValue{
+		{Tag: asn1.TagUTF8String, Bytes: []byte("Some notice")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := asn1.Marshal([]policyInformation{
+		{
+			Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+			Qualifiers: []policyQualifierInfo{
+				{
+					QualifierID: oidPolicyQualifierCPS,
+					Qualifier:   asn1.RawValue{Tag: asn1.TagIA5String, Bytes: []byte("https://example.com/cps")},
+				},
+				{
+					QualifierID: oidPolicyQualifierUserNotice,
+					Qualifier:   asn1.RawValue{FullBytes: notice},
+				},
+			},
+		},
+		{
+			Policy: asn1.ObjectIdentifier{1, 2, 3, 5},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := testCertificate(t, &x509.Certificate{
+		KeyUsage:           x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{{1, 3, 6, 1, 4, 1, 311, 4}},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidExtensionCertificatePolicies, Value: policies},
+		},
+	})
+
+	actual, err := PolicyIdentifiers(cert)
+	if err != nil {
+		t.Fatalf("PolicyIdentifiers() unexpected error: %s", err)
+	}
+
+	expected := []interface{}{
+		`{"cps":"https://example.com/cps","notice":"Some notice","oid":"1.2.3.4"}`,
+		`{"oid":"1.2.3.5"}`,
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("PolicyIdentifiers() expected %v, got %v", expected, actual)
+	}
+
+	// round trip through the parsing used for PKI roles
+	_, set, err := MakePkiPolicyIdentifiersListOrSet(actual)
+	if err != nil {
+		t.Fatalf("MakePkiPolicyIdentifiersListOrSet() unexpected error: %s", err)
+	}
+	if set.Len() != 2 {
+		t.Fatalf("expected 2 policy identifiers, got %d", set.Len())
+	}
+
+	for name, tt := range map[string]struct {
+		actual   []string
+		expected []string
+	}{
+		"key usage": {
+			actual:   KeyUsageNames(cert),
+			expected: []string{"DigitalSignature", "KeyEncipherment"},
+		},
+		"ext key usage": {
+			actual:   ExtKeyUsageNames(cert),
+			expected: []string{"ServerAuth", "ClientAuth"},
+		},
+		"ext key usage oids": {
+			actual:   ExtKeyUsageOIDs(cert),
+			expected: []string{"1.3.6.1.5.5.7.3.1", "1.3.6.1.5.5.7.3.2", "1.3.6.1.4.1.311.4"},
+		},
+	} {
+		if !reflect.DeepEqual(tt.expected, tt.actual) {
+			t.Errorf("%s: expected %v, got %v", name, tt.expected, tt.actual)
+		}
+	}
+}
+
+func testCertificate(t *testing.T, template *x509.Certificate) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.SerialNumber = big.NewInt(1)
+	template.Subject = pkix.Name{CommonName: "test"}
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	b, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
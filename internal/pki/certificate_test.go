@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestParseCertificates(t *testing.T) {
+	caCert, caKey, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, _, err := testutil.GenerateCSR("intermediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediate, err := testutil.SignIntermediateCSR(caCert, caKey, csr, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		data     string
+		wantCNs  []string
+		wantErr  bool
+		verifyOK bool
+	}{
+		{
+			name:    "single",
+			data:    string(caCert),
+			wantCNs: []string{"Testing CA"},
+		},
+		{
+			name:     "chain",
+			data:     string(intermediate) + string(csr) + string(caCert),
+			wantCNs:  []string{"intermediate", "Testing CA"},
+			verifyOK: true,
+		},
+		{
+			name:    "no-certificates",
+			data:    string(csr),
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			data:    "not a certificate",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := ParseCertificates(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCertificates() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCertificates() unexpected error: %s", err)
+			}
+
+			var cns []string
+			for _, c := range certs {
+				cns = append(cns, c.Subject.CommonName)
+			}
+			if strings.Join(cns, ",") != strings.Join(tt.wantCNs, ",") {
+				t.Fatalf("ParseCertificates() expected %v, got %v", tt.wantCNs, cns)
+			}
+
+			if tt.verifyOK {
+				if _, err := VerifyChain(certs[0], certs[1:]); err != nil {
+					t.Fatalf("VerifyChain() unexpected error: %s", err)
+				}
+			}
+		})
+	}
+}
+
+func TestPublicKeysEqual(t *testing.T) {
+	caCert, caKey, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM, _, err := testutil.GenerateCSR("intermediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, err := testutil.SignIntermediateCSR(caCert, caKey, csrPEM, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := ParseCertificates(string(certPEM) + string(caCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, err := ParseCertificateRequest(string(csrPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := PublicKeysEqual(certs[0], csr); err != nil || !ok {
+		t.Fatalf("PublicKeysEqual() expected true, got %t, err=%v", ok, err)
+	}
+	if ok, err := PublicKeysEqual(certs[1], csr); err != nil || ok {
+		t.Fatalf("PublicKeysEqual() expected false, got %t, err=%v", ok, err)
+	}
+
+	if !strings.Contains(SerialNumber(certs[0]), ":") {
+		t.Fatalf("SerialNumber() expected colon separated octets, got %q", SerialNumber(certs[0]))
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+// RolePolicy holds the provider level rules that every
+// vault_pki_secret_backend_role must comply with.
+type RolePolicy struct {
+	DenyAllowAnyName          bool
+	DenyAllowGlobDomains      bool
+	RequireEnforceHostnames   bool
+	MaxTTL                    time.Duration
+	RequiredPolicyIdentifiers []string
+}
+
+// RoleGetter is satisfied by *schema.ResourceDiff.
+type RoleGetter interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+	NewValueKnown(key string) bool
+}
+
+// NewRolePolicy builds a RolePolicy from the provider's pki_role_policy block.
+func NewRolePolicy(m map[string]interface{}) (*RolePolicy, error) {
+	p := &RolePolicy{}
+
+	if v, ok := m[consts.FieldDenyAllowAnyName]; ok {
+		p.DenyAllowAnyName = v.(bool)
+	}
+	if v, ok := m[consts.FieldDenyAllowGlobDomains]; ok {
+		p.DenyAllowGlobDomains = v.(bool)
+	}
+	if v, ok := m[consts.FieldRequireEnforceHostnames]; ok {
+		p.RequireEnforceHostnames = v.(bool)
+	}
+	if v, ok := m[consts.FieldMaxTTL]; ok && v.(string) != "" {
+		ttl, err := parseutil.ParseDurationSecond(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", consts.FieldMaxTTL, v, err)
+		}
+		p.MaxTTL = ttl
+	}
+	if v, ok := m[consts.FieldRequiredPolicyIdentifiers]; ok {
+		for _, oid := range v.([]interface{}) {
+			if oid != nil && oid.(string) != "" {
+				p.RequiredPolicyIdentifiers = append(p.RequiredPolicyIdentifiers, oid.(string))
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Validate returns an error for every rule the role violates.
+// Values that are not known yet, e.g. during the plan of a role
+// that depends on other resources, are not validated.
+func (p *RolePolicy) Validate(d RoleGetter) []error {
+	if p == nil {
+		return nil
+	}
+
+	var errs []error
+	if p.DenyAllowAnyName && knownBool(d, consts.FieldAllowAnyName, false) {
+		errs = append(errs, fmt.Errorf("%s is forbidden", consts.FieldAllowAnyName))
+	}
+	if p.DenyAllowGlobDomains && knownBool(d, consts.FieldAllowGlobDomains, false) {
+		errs = append(errs, fmt.Errorf("%s is forbidden", consts.FieldAllowGlobDomains))
+	}
+	if p.RequireEnforceHostnames && !knownBool(d, consts.FieldEnforceHostnames, true) {
+		errs = append(errs, fmt.Errorf("%s must be enabled", consts.FieldEnforceHostnames))
+	}
+
+	if p.MaxTTL > 0 {
+		if err := p.validateMaxTTL(d); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(p.RequiredPolicyIdentifiers) > 0 &&
+		d.NewValueKnown(consts.FieldPolicyIdentifiers) && d.NewValueKnown(consts.FieldPolicyIdentifier) {
+		if missing := p.missingPolicyIdentifiers(d); len(missing) > 0 {
+			errs = append(errs, fmt.Errorf("policy identifiers %v are required", missing))
+		}
+	}
+
+	return errs
+}
+
+func (p *RolePolicy) validateMaxTTL(d RoleGetter) error {
+	// max_ttl is computed, so its new value is unknown whenever
+	// it is not set in the configuration.
+	raw := d.GetRawConfig()
+	unset := !raw.IsNull() && raw.Type().IsObjectType() && raw.GetAttr(consts.FieldMaxTTL).IsNull()
+	if !unset && !d.NewValueKnown(consts.FieldMaxTTL) {
+		return nil
+	}
+
+	v := d.Get(consts.FieldMaxTTL).(string)
+	if unset || v == "" || v == "0" {
+		// Vault falls back to the mount's max lease TTL, which we cannot
+		// bound here, so an explicit value is required.
+		return fmt.Errorf("%s must be set to at most %s", consts.FieldMaxTTL, p.MaxTTL)
+	}
+
+	ttl, err := parseutil.ParseDurationSecond(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", consts.FieldMaxTTL, v, err)
+	}
+	if ttl > p.MaxTTL {
+		return fmt.Errorf("%s %q exceeds the maximum of %s", consts.FieldMaxTTL, v, p.MaxTTL)
+	}
+
+	return nil
+}
+
+func (p *RolePolicy) missingPolicyIdentifiers(d RoleGetter) []string {
+	oids := map[string]bool{}
+	if v, ok := d.Get(consts.FieldPolicyIdentifiers).([]interface{}); ok {
+		for _, oid := range v {
+			if oid != nil {
+				oids[oid.(string)] = true
+			}
+		}
+	}
+	if v, ok := d.Get(consts.FieldPolicyIdentifier).(*schema.Set); ok {
+		for _, b := range v.List() {
+			if oid, ok := b.(map[string]interface{})[consts.FieldOID]; ok {
+				oids[oid.(string)] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, oid := range p.RequiredPolicyIdentifiers {
+		if !oids[oid] {
+			missing = append(missing, oid)
+		}
+	}
+	return missing
+}
+
+// knownBool returns the value of a boolean field, or def if it is not known yet.
+func knownBool(d RoleGetter, k string, def bool) bool {
+	if !d.NewValueKnown(k) {
+		return def
+	}
+
+	return d.Get(k).(bool)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pki
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+type testRoleGetter struct {
+	values  map[string]interface{}
+	unknown map[string]bool
+}
+
+func (g *testRoleGetter) Get(k string) interface{} {
+	if v, ok := g.values[k]; ok {
+		return v
+	}
+
+	switch k {
+	case consts.FieldMaxTTL:
+		return ""
+	case consts.FieldPolicyIdentifiers:
+		return []interface{}{}
+	case consts.FieldPolicyIdentifier:
+		return schema.NewSet(schema.HashResource(&schema.Resource{}), nil)
+	default:
+		return false
+	}
+}
+
+func (g *testRoleGetter) GetRawConfig() cty.Value {
+	maxTTL := cty.NullVal(cty.String)
+	if v, ok := g.values[consts.FieldMaxTTL]; ok {
+		if g.unknown[consts.FieldMaxTTL] {
+			maxTTL = cty.UnknownVal(cty.String)
+		} else {
+			maxTTL = cty.StringVal(v.(string))
+		}
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{
+		consts.FieldMaxTTL: maxTTL,
+	})
+}
+
+func (g *testRoleGetter) NewValueKnown(k string) bool {
+	return !g.unknown[k]
+}
+
+func TestRolePolicy_Validate(t *testing.T) {
+	policy, err := NewRolePolicy(map[string]interface{}{
+		consts.FieldDenyAllowAnyName:          true,
+		consts.FieldDenyAllowGlobDomains:      true,
+		consts.FieldRequireEnforceHostnames:   true,
+		consts.FieldMaxTTL:                    "720h",
+		consts.FieldRequiredPolicyIdentifiers: []interface{}{"1.2.3.4", "1.2.3.5"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.MaxTTL != 720*time.Hour {
+		t.Fatalf("expected max TTL of 720h, got %s", policy.MaxTTL)
+	}
+
+	policyBlocks := schema.NewSet(func(i interface{}) int {
+		return len(i.(map[string]interface{})[consts.FieldOID].(string))
+	}, []interface{}{
+		map[string]interface{}{consts.FieldOID: "1.2.3.5"},
+	})
+
+	compliant := map[string]interface{}{
+		consts.FieldEnforceHostnames:  true,
+		consts.FieldMaxTTL:            "86400",
+		consts.FieldPolicyIdentifiers: []interface{}{"1.2.3.4", "1.2.3.5"},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]interface{}
+		unknown  map[string]bool
+		expected []string
+	}{
+		{
+			name:   "compliant",
+			values: compliant,
+		},
+		{
+			name: "compliant-blocks",
+			values: map[string]interface{}{
+				consts.FieldEnforceHostnames:  true,
+				consts.FieldMaxTTL:            "720h",
+				consts.FieldPolicyIdentifiers: []interface{}{"1.2.3.4"},
+				consts.FieldPolicyIdentifier:  policyBlocks,
+			},
+		},
+		{
+			name: "violations",
+			values: map[string]interface{}{
+				consts.FieldAllowAnyName:      true,
+				consts.FieldAllowGlobDomains:  true,
+				consts.FieldMaxTTL:            "721h",
+				consts.FieldPolicyIdentifiers: []interface{}{"1.2.3.5"},
+			},
+			expected: []string{
+				"allow_any_name is forbidden",
+				"allow_glob_domains is forbidden",
+				"enforce_hostnames must be enabled",
+				`max_ttl "721h" exceeds the maximum of 720h0m0s`,
+				"policy identifiers [1.2.3.4] are required",
+			},
+		},
+		{
+			name: "max-ttl-unset",
+			values: map[string]interface{}{
+				consts.FieldEnforceHostnames:  true,
+				consts.FieldPolicyIdentifiers: []interface{}{"1.2.3.4", "1.2.3.5"},
+			},
+			unknown: map[string]bool{
+				// computed fields are unknown when not configured
+				consts.FieldMaxTTL: true,
+			},
+			expected: []string{
+				"max_ttl must be set to at most 720h0m0s",
+			},
+		},
+		{
+			// values interpolated from resources that are not created yet
+			name: "unknown",
+			values: map[string]interface{}{
+				consts.FieldMaxTTL: "",
+			},
+			unknown: map[string]bool{
+				consts.FieldAllowAnyName:      true,
+				consts.FieldEnforceHostnames:  true,
+				consts.FieldMaxTTL:            true,
+				consts.FieldPolicyIdentifiers: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual []string
+			for _, err := range policy.Validate(&testRoleGetter{values: tt.values, unknown: tt.unknown}) {
+				actual = append(actual, err.Error())
+			}
+
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Fatalf("Validate() expected %#v, got %#v", tt.expected, actual)
+			}
+		})
+	}
+
+	var nilPolicy *RolePolicy
+	if errs := nilPolicy.Validate(&testRoleGetter{values: compliant}); len(errs) != 0 {
+		t.Fatalf("Validate() expected no errors for a nil policy, got %v", errs)
+	}
+
+	if _, err := NewRolePolicy(map[string]interface{}{consts.FieldMaxTTL: "forever"}); err == nil {
+		t.Fatalf("NewRolePolicy() expected an error for an invalid max_ttl")
+	}
+}
@@ -106,6 +106,11 @@ var (
 	authLoginInitCheckError = errors.New("auth login not initialized")
 
 	globalAuthLoginRegistry = &authLoginRegistry{}
+
+	// dataSourceAuthLoginRegistry holds the AuthLogin(s) that are only
+	// supported by the vault_auth_login_test data source, and not as provider
+	// logins.
+	dataSourceAuthLoginRegistry = &authLoginRegistry{}
 )
 
 type AuthLogin interface {
@@ -321,13 +326,19 @@ func (l *AuthLoginCommon) validate() error {
 }
 
 func GetAuthLogin(r *schema.ResourceData) (AuthLogin, error) {
-	for _, authField := range globalAuthLoginRegistry.Fields() {
+	return GetAuthLoginFromFields(r, globalAuthLoginRegistry.Fields()...)
+}
+
+// GetAuthLoginFromFields returns the AuthLogin of the first of the auth login
+// fields set in r.
+func GetAuthLoginFromFields(r *schema.ResourceData, fields ...string) (AuthLogin, error) {
+	for _, authField := range fields {
 		_, ok := r.GetOk(authField)
 		if !ok {
 			continue
 		}
 
-		entry, err := globalAuthLoginRegistry.Get(authField)
+		entry, err := getAuthLoginEntry(authField)
 		if err != nil {
 			return nil, err
 		}
@@ -338,6 +349,16 @@ func GetAuthLogin(r *schema.ResourceData) (AuthLogin, error) {
 	return nil, nil
 }
 
+// getAuthLoginEntry returns the authLoginEntry of field from the global
+// registry, or from the registry of the data source only AuthLogin(s).
+func getAuthLoginEntry(field string) (*authLoginEntry, error) {
+	if _, ok := dataSourceAuthLoginRegistry.m.Load(field); ok {
+		return dataSourceAuthLoginRegistry.Get(field)
+	}
+
+	return globalAuthLoginRegistry.Get(field)
+}
+
 func mustAddLoginSchema(r *schema.Resource, authField string, defaultMount string) *schema.Resource {
 	m := map[string]*schema.Schema{
 		consts.FieldNamespace: {
@@ -396,6 +417,20 @@ func getLoginSchema(authField, description string, resourceFunc getSchemaResourc
 	}
 }
 
+// GetAuthLoginSchema returns the schema.Schema of the auth login field, for
+// use in a schema that does not include all the auth login fields.
+func GetAuthLoginSchema(field string) (*schema.Schema, error) {
+	entry, err := getAuthLoginEntry(field)
+	if err != nil {
+		return nil, err
+	}
+
+	s := entry.LoginSchema()
+	s.ConflictsWith = nil
+
+	return s, nil
+}
+
 // MustAddAuthLoginSchema adds all supported auth login type schema.Schema to
 // a schema map.
 func MustAddAuthLoginSchema(s map[string]*schema.Schema) {
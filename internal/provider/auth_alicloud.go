@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials/providers"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+const aliCloudDefaultRegion = "us-east-1"
+
+func init() {
+	field := consts.FieldAuthLoginAliCloud
+	if err := globalAuthLoginRegistry.Register(field,
+		func(r *schema.ResourceData) (AuthLogin, error) {
+			a := &AuthLoginAliCloud{}
+			return a.Init(r, field)
+		}, GetAliCloudLoginSchema); err != nil {
+		panic(err)
+	}
+}
+
+// GetAliCloudLoginSchema for the AliCloud authentication engine.
+func GetAliCloudLoginSchema(authField string) *schema.Schema {
+	return getLoginSchema(
+		authField,
+		"Login to vault using the AliCloud method",
+		GetAliCloudLoginSchemaResource,
+	)
+}
+
+// GetAliCloudLoginSchemaResource for the AliCloud authentication engine.
+func GetAliCloudLoginSchemaResource(authField string) *schema.Resource {
+	return mustAddLoginSchema(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			consts.FieldRole: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the login role.",
+			},
+			consts.FieldAccessKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The AliCloud access key ID.",
+				DefaultFunc: schema.EnvDefaultFunc(consts.EnvVarAliCloudAccessKey, nil),
+			},
+			consts.FieldSecretKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The AliCloud access key secret.",
+				DefaultFunc:  schema.EnvDefaultFunc(consts.EnvVarAliCloudSecretKey, nil),
+				RequiredWith: []string{fmt.Sprintf("%s.0.%s", authField, consts.FieldAccessKey)},
+			},
+			consts.FieldSecurityToken: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The AliCloud STS security token.",
+				DefaultFunc: schema.EnvDefaultFunc(consts.EnvVarAliCloudSecurityToken, nil),
+			},
+			consts.FieldRegion: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The AliCloud region of the STS endpoint.",
+				DefaultFunc: schema.EnvDefaultFunc(consts.EnvVarAliCloudRegion, aliCloudDefaultRegion),
+			},
+		},
+	}, authField, consts.MountTypeAliCloud)
+}
+
+var _ AuthLogin = (*AuthLoginAliCloud)(nil)
+
+// AuthLoginAliCloud for handling the Vault AliCloud authentication engine.
+// Requires configuration provided by SchemaLoginAliCloud.
+type AuthLoginAliCloud struct {
+	AuthLoginCommon
+}
+
+func (l *AuthLoginAliCloud) Init(d *schema.ResourceData, authField string) (AuthLogin, error) {
+	if err := l.AuthLoginCommon.Init(d, authField,
+		func(data *schema.ResourceData) error {
+			return l.checkRequiredFields(d, consts.FieldRole)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// MountPath for the AliCloud authentication engine.
+func (l *AuthLoginAliCloud) MountPath() string {
+	if l.mount == "" {
+		return l.Method()
+	}
+	return l.mount
+}
+
+// LoginPath for the AliCloud authentication engine.
+func (l *AuthLoginAliCloud) LoginPath() string {
+	return fmt.Sprintf("auth/%s/login", l.MountPath())
+}
+
+// Method name for the AliCloud authentication engine.
+func (l *AuthLoginAliCloud) Method() string {
+	return consts.AuthMethodAliCloud
+}
+
+// Login using the AliCloud authentication engine.
+func (l *AuthLoginAliCloud) Login(client *api.Client) (*api.Secret, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+
+	params, err := l.copyParams(
+		consts.FieldRole,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := l.getCredential()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AliCloud credentials required for Vault login, err=%w", err)
+	}
+
+	region := aliCloudDefaultRegion
+	if v, ok := l.params[consts.FieldRegion].(string); ok && v != "" {
+		region = v
+	}
+
+	loginData, err := generateAliCloudLoginData(creds, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate AliCloud login data, err=%w", err)
+	}
+	for k, v := range loginData {
+		params[k] = v
+	}
+
+	return l.login(client, l.LoginPath(), params)
+}
+
+// getCredential returns the credentials from the configuration, falling back
+// to the environment and the instance metadata.
+func (l *AuthLoginAliCloud) getCredential() (auth.Credential, error) {
+	config := &providers.Configuration{}
+	if v, ok := l.params[consts.FieldAccessKey].(string); ok {
+		config.AccessKeyID = v
+	}
+	if v, ok := l.params[consts.FieldSecretKey].(string); ok {
+		config.AccessKeySecret = v
+	}
+	if v, ok := l.params[consts.FieldSecurityToken].(string); ok {
+		config.AccessKeyStsToken = v
+	}
+
+	return providers.NewChainProvider([]providers.Provider{
+		providers.NewConfigurationCredentialProvider(config),
+		providers.NewEnvCredentialProvider(),
+		providers.NewInstanceMetadataProvider(),
+	}).Retrieve()
+}
+
+// aliCloudRequestCapturer captures the signed request from the proxy of the
+// transport, and fails it so that it is never sent.
+type aliCloudRequestCapturer struct {
+	request *http.Request
+}
+
+func (c *aliCloudRequestCapturer) proxy(req *http.Request) (*url.URL, error) {
+	c.request = req
+	return nil, errors.New("request captured")
+}
+
+// generateAliCloudLoginData returns the login data of a signed STS
+// GetCallerIdentity request, which Vault sends to AliCloud to verify the
+// identity of the caller.
+func generateAliCloudLoginData(creds auth.Credential, region string) (map[string]interface{}, error) {
+	capturer := &aliCloudRequestCapturer{}
+
+	config := sdk.NewConfig()
+	// the STS request must always be sent over https
+	config.Scheme = "https"
+	config.AutoRetry = false
+	config.HttpTransport = &http.Transport{
+		Proxy: capturer.proxy,
+	}
+
+	client, err := sts.NewClientWithOptions(region, config, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	// the request always fails, it is captured before it is sent
+	_, _ = client.GetCallerIdentity(sts.CreateGetCallerIdentityRequest())
+	if capturer.request == nil {
+		return nil, errors.New("unable to capture the signed GetCallerIdentity request")
+	}
+
+	headers, err := json.Marshal(capturer.request.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		consts.FieldIdentityRequestURL:     base64.StdEncoding.EncodeToString([]byte(capturer.request.URL.String())),
+		consts.FieldIdentityRequestHeaders: base64.StdEncoding.EncodeToString(headers),
+	}, nil
+}
@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+func TestAuthLoginAliCloud_Init(t *testing.T) {
+	tests := []authLoginInitTest{
+		{
+			name:      "basic",
+			authField: consts.FieldAuthLoginAliCloud,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginAliCloud: []interface{}{
+					map[string]interface{}{
+						consts.FieldNamespace:     "ns1",
+						consts.FieldRole:          "alice",
+						consts.FieldAccessKey:     "key-id",
+						consts.FieldSecretKey:     "key-secret",
+						consts.FieldSecurityToken: "token",
+						consts.FieldRegion:        "cn-hangzhou",
+					},
+				},
+			},
+			envVars: map[string]string{
+				consts.EnvVarAliCloudRegion: "",
+			},
+			expectParams: map[string]interface{}{
+				consts.FieldNamespace:        "ns1",
+				consts.FieldUseRootNamespace: false,
+				consts.FieldMount:            consts.MountTypeAliCloud,
+				consts.FieldRole:             "alice",
+				consts.FieldAccessKey:        "key-id",
+				consts.FieldSecretKey:        "key-secret",
+				consts.FieldSecurityToken:    "token",
+				consts.FieldRegion:           "cn-hangzhou",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "env",
+			authField: consts.FieldAuthLoginAliCloud,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginAliCloud: []interface{}{
+					map[string]interface{}{
+						consts.FieldRole: "alice",
+					},
+				},
+			},
+			envVars: map[string]string{
+				consts.EnvVarAliCloudAccessKey:     "env-key-id",
+				consts.EnvVarAliCloudSecretKey:     "env-key-secret",
+				consts.EnvVarAliCloudSecurityToken: "",
+				consts.EnvVarAliCloudRegion:        "",
+			},
+			expectParams: map[string]interface{}{
+				consts.FieldNamespace:        "",
+				consts.FieldUseRootNamespace: false,
+				consts.FieldMount:            consts.MountTypeAliCloud,
+				consts.FieldRole:             "alice",
+				consts.FieldAccessKey:        "env-key-id",
+				consts.FieldSecretKey:        "env-key-secret",
+				consts.FieldSecurityToken:    "",
+				consts.FieldRegion:           aliCloudDefaultRegion,
+			},
+			wantErr: false,
+		},
+		{
+			name:         "error-missing-resource",
+			authField:    consts.FieldAuthLoginAliCloud,
+			expectParams: nil,
+			wantErr:      true,
+			expectErr:    fmt.Errorf("resource data missing field %q", consts.FieldAuthLoginAliCloud),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := map[string]*schema.Schema{
+				tt.authField: GetAliCloudLoginSchema(tt.authField),
+			}
+			assertAuthLoginInit(t, tt, s, &AuthLoginAliCloud{})
+		})
+	}
+}
+
+func TestAuthLoginAliCloud_LoginPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		mount string
+		want  string
+	}{
+		{
+			name: "default",
+			want: "auth/alicloud/login",
+		},
+		{
+			name:  "other",
+			mount: "other",
+			want:  "auth/other/login",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &AuthLoginAliCloud{
+				AuthLoginCommon: AuthLoginCommon{
+					mount: tt.mount,
+				},
+			}
+			if got := l.LoginPath(); got != tt.want {
+				t.Errorf("LoginPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthLoginAliCloud_Login(t *testing.T) {
+	handlerFunc := func(t *testLoginHandler, w http.ResponseWriter, req *http.Request) {
+		params := t.params[len(t.params)-1]
+		for _, k := range []string{consts.FieldIdentityRequestURL, consts.FieldIdentityRequestHeaders} {
+			if v, _ := params[k].(string); v == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		m, err := json.Marshal(
+			&api.Secret{
+				Auth: &api.SecretAuth{
+					Metadata: map[string]string{
+						"role": params[consts.FieldRole].(string),
+					},
+				},
+			},
+		)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(m)
+	}
+
+	tests := []authLoginTest{
+		{
+			name: "basic",
+			authLogin: &AuthLoginAliCloud{
+				AuthLoginCommon{
+					authField: consts.FieldAuthLoginAliCloud,
+					params: map[string]interface{}{
+						consts.FieldRole:      "alice",
+						consts.FieldAccessKey: "key-id",
+						consts.FieldSecretKey: "key-secret",
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			expectReqCount:     1,
+			skipCheckReqParams: true,
+			expectReqPaths:     []string{"/v1/auth/alicloud/login"},
+			want: &api.Secret{
+				Auth: &api.SecretAuth{
+					Metadata: map[string]string{
+						"role": "alice",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error-uninitialized",
+			authLogin: &AuthLoginAliCloud{
+				AuthLoginCommon{
+					initialized: false,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			want:      nil,
+			wantErr:   true,
+			expectErr: authLoginInitCheckError,
+		},
+		{
+			name: "error-vault-token-set",
+			authLogin: &AuthLoginAliCloud{
+				AuthLoginCommon{
+					authField: consts.FieldAuthLoginAliCloud,
+					params: map[string]interface{}{
+						consts.FieldRole:      "alice",
+						consts.FieldAccessKey: "key-id",
+						consts.FieldSecretKey: "key-secret",
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			token:     "foo",
+			wantErr:   true,
+			expectErr: errors.New("vault login client has a token set"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testAuthLogin(t, tt)
+		})
+	}
+}
+
+func TestGenerateAliCloudLoginData(t *testing.T) {
+	creds := credentials.NewStsTokenCredential("key-id", "key-secret", "sts-token")
+	data, err := generateAliCloudLoginData(creds, "cn-hangzhou")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(data[consts.FieldIdentityRequestURL].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Scheme != "https" {
+		t.Errorf("expected an https request, got %q", u.Scheme)
+	}
+	query := u.Query()
+	for k, expected := range map[string]string{
+		"Action":        "GetCallerIdentity",
+		"AccessKeyId":   "key-id",
+		"SecurityToken": "sts-token",
+	} {
+		if actual := query.Get(k); actual != expected {
+			t.Errorf("expected query parameter %s=%q, got %q", k, expected, actual)
+		}
+	}
+	if query.Get("Signature") == "" {
+		t.Error("expected a signed request")
+	}
+
+	b, err = base64.StdEncoding.DecodeString(data[consts.FieldIdentityRequestHeaders].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var headers http.Header
+	if err := json.Unmarshal(b, &headers); err != nil {
+		t.Fatalf("expected JSON encoded headers: %s", err)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+func TestAuthLoginAppRole_Init(t *testing.T) {
+	tests := []authLoginInitTest{
+		{
+			name:      "basic",
+			authField: consts.FieldAuthLoginAppRole,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginAppRole: []interface{}{
+					map[string]interface{}{
+						consts.FieldNamespace: "ns1",
+						consts.FieldRoleID:    "role-id-1",
+						consts.FieldSecretID:  "secret-id-1",
+					},
+				},
+			},
+			expectParams: map[string]interface{}{
+				consts.FieldNamespace:        "ns1",
+				consts.FieldUseRootNamespace: false,
+				consts.FieldMount:            consts.MountTypeAppRole,
+				consts.FieldRoleID:           "role-id-1",
+				consts.FieldSecretID:         "secret-id-1",
+			},
+			wantErr: false,
+		},
+		{
+			name:         "error-missing-resource",
+			authField:    consts.FieldAuthLoginAppRole,
+			expectParams: nil,
+			wantErr:      true,
+			expectErr:    fmt.Errorf("resource data missing field %q", consts.FieldAuthLoginAppRole),
+		},
+		{
+			name:      "error-missing-required",
+			authField: consts.FieldAuthLoginAppRole,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginAppRole: []interface{}{
+					map[string]interface{}{
+						consts.FieldSecretID: "secret-id-1",
+					},
+				},
+			},
+			expectParams: nil,
+			wantErr:      true,
+			expectErr: fmt.Errorf("required fields are unset: %v", []string{
+				consts.FieldRoleID,
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := map[string]*schema.Schema{
+				tt.authField: GetAppRoleLoginSchema(tt.authField),
+			}
+			assertAuthLoginInit(t, tt, s, &AuthLoginAppRole{})
+		})
+	}
+}
+
+func TestAuthLoginAppRole_LoginPath(t *testing.T) {
+	type fields struct {
+		AuthLoginCommon AuthLoginCommon
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "default",
+			fields: fields{
+				AuthLoginCommon: AuthLoginCommon{
+					params: map[string]interface{}{
+						consts.FieldRoleID:   "role-id-1",
+						consts.FieldSecretID: "secret-id-1",
+					},
+				},
+			},
+			want: "auth/approle/login",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &AuthLoginAppRole{
+				AuthLoginCommon: tt.fields.AuthLoginCommon,
+			}
+			if got := l.LoginPath(); got != tt.want {
+				t.Errorf("LoginPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthLoginAppRole_Login(t *testing.T) {
+	handlerFunc := func(t *testLoginHandler, w http.ResponseWriter, req *http.Request) {
+		m, err := json.Marshal(
+			&api.Secret{
+				Data: map[string]interface{}{
+					"auth_login": "approle",
+				},
+			},
+		)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(m); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	tests := []authLoginTest{
+		{
+			name: "basic",
+			authLogin: &AuthLoginAppRole{
+				AuthLoginCommon: AuthLoginCommon{
+					authField: consts.FieldAuthLoginAppRole,
+					params: map[string]interface{}{
+						consts.FieldRoleID:   "role-id-1",
+						consts.FieldSecretID: "secret-id-1",
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			expectReqCount: 1,
+			expectReqPaths: []string{"/v1/auth/approle/login"},
+			expectReqParams: []map[string]interface{}{
+				{
+					consts.FieldRoleID:   "role-id-1",
+					consts.FieldSecretID: "secret-id-1",
+				},
+			},
+			want: &api.Secret{
+				Data: map[string]interface{}{
+					"auth_login": "approle",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no-secret-id",
+			authLogin: &AuthLoginAppRole{
+				AuthLoginCommon: AuthLoginCommon{
+					authField: consts.FieldAuthLoginAppRole,
+					params: map[string]interface{}{
+						consts.FieldRoleID:   "role-id-1",
+						consts.FieldSecretID: "",
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			expectReqCount: 1,
+			expectReqPaths: []string{"/v1/auth/approle/login"},
+			expectReqParams: []map[string]interface{}{
+				{
+					consts.FieldRoleID: "role-id-1",
+				},
+			},
+			want: &api.Secret{
+				Data: map[string]interface{}{
+					"auth_login": "approle",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error-vault-token-set",
+			authLogin: &AuthLoginAppRole{
+				AuthLoginCommon: AuthLoginCommon{
+					authField: consts.FieldAuthLoginAppRole,
+					params: map[string]interface{}{
+						consts.FieldRoleID:   "role-id-1",
+						consts.FieldSecretID: "secret-id-1",
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			token:     "foo",
+			wantErr:   true,
+			expectErr: errors.New("vault login client has a token set"),
+		},
+		{
+			name: "error-uninitialized",
+			authLogin: &AuthLoginAppRole{
+				AuthLoginCommon: AuthLoginCommon{
+					initialized: false,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			expectReqCount: 0,
+			want:           nil,
+			wantErr:        true,
+			expectErr:      authLoginInitCheckError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testAuthLogin(t, tt)
+		})
+	}
+}
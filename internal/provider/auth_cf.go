@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+// cfSigningTimeFormat is the format of the signing time expected by the CF
+// auth engine.
+const cfSigningTimeFormat = "2006-01-02T15:04:05Z"
+
+func init() {
+	field := consts.FieldAuthLoginCF
+	if err := globalAuthLoginRegistry.Register(field,
+		func(r *schema.ResourceData) (AuthLogin, error) {
+			a := &AuthLoginCF{}
+			return a.Init(r, field)
+		}, GetCFLoginSchema); err != nil {
+		panic(err)
+	}
+}
+
+// GetCFLoginSchema for the CF authentication engine.
+func GetCFLoginSchema(authField string) *schema.Schema {
+	return getLoginSchema(
+		authField,
+		"Login to vault using the CF method",
+		GetCFLoginSchemaResource,
+	)
+}
+
+// GetCFLoginSchemaResource for the CF authentication engine.
+func GetCFLoginSchemaResource(authField string) *schema.Resource {
+	return mustAddLoginSchema(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			consts.FieldRole: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the login role.",
+			},
+			consts.FieldCFInstanceCert: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the CF instance identity certificate.",
+				DefaultFunc: schema.EnvDefaultFunc(consts.EnvVarCFInstanceCert, nil),
+			},
+			consts.FieldCFInstanceKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the key of the CF instance identity certificate.",
+				DefaultFunc: schema.EnvDefaultFunc(consts.EnvVarCFInstanceKey, nil),
+			},
+		},
+	}, authField, consts.MountTypeCF)
+}
+
+var _ AuthLogin = (*AuthLoginCF)(nil)
+
+// AuthLoginCF for handling the Vault CF authentication engine.
+// Requires configuration provided by SchemaLoginCF.
+type AuthLoginCF struct {
+	AuthLoginCommon
+}
+
+func (l *AuthLoginCF) Init(d *schema.ResourceData, authField string) (AuthLogin, error) {
+	if err := l.AuthLoginCommon.Init(d, authField,
+		func(data *schema.ResourceData) error {
+			return l.checkRequiredFields(d, consts.FieldRole, consts.FieldCFInstanceCert, consts.FieldCFInstanceKey)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// MountPath for the CF authentication engine.
+func (l *AuthLoginCF) MountPath() string {
+	if l.mount == "" {
+		return l.Method()
+	}
+	return l.mount
+}
+
+// LoginPath for the CF authentication engine.
+func (l *AuthLoginCF) LoginPath() string {
+	return fmt.Sprintf("auth/%s/login", l.MountPath())
+}
+
+// Method name for the CF authentication engine.
+func (l *AuthLoginCF) Method() string {
+	return consts.AuthMethodCF
+}
+
+// Login using the CF authentication engine.
+func (l *AuthLoginCF) Login(client *api.Client) (*api.Secret, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+
+	params, err := l.copyParams(
+		consts.FieldRole,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := os.ReadFile(l.params[consts.FieldCFInstanceCert].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CF instance certificate, err=%w", err)
+	}
+
+	key, err := os.ReadFile(l.params[consts.FieldCFInstanceKey].(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CF instance key, err=%w", err)
+	}
+
+	// the certificate and key are rotated by CF, so the login data is
+	// signed at login time.
+	signingTime := time.Now().UTC()
+	signature, err := signCFLogin(key, string(cert), params[consts.FieldRole].(string), signingTime)
+	if err != nil {
+		return nil, err
+	}
+
+	params[consts.FieldCFInstanceCert] = string(cert)
+	params[consts.FieldSigningTime] = signingTime.Format(cfSigningTimeFormat)
+	params[consts.FieldSignature] = signature
+
+	return l.login(client, l.LoginPath(), params)
+}
+
+// cfLoginDigest returns the digest of the login data signed with the CF
+// instance key.
+func cfLoginDigest(cert, role string, signingTime time.Time) []byte {
+	sum := sha256.Sum256([]byte(signingTime.UTC().Format(cfSigningTimeFormat) + cert + role))
+	return sum[:]
+}
+
+// signCFLogin signs the login data with the PEM encoded CF instance key,
+// the way the CF auth engine expects it.
+func signCFLogin(keyPEM []byte, cert, role string, signingTime time.Time) (string, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return "", errors.New("failed to decode the CF instance key, no PEM block found")
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse the CF instance key, err=%w", err)
+		}
+		key = k
+	default:
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse the CF instance key, err=%w", err)
+		}
+		rsaKey, ok := k.(*rsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("unsupported CF instance key type %T, expected an RSA key", k)
+		}
+		key = rsaKey
+	}
+
+	signature, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, cfLoginDigest(cert, role, signingTime), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the CF login data, err=%w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(signature), nil
+}
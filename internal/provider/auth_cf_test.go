@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+const testCFInstanceCert = `-----BEGIN CERTIFICATE-----
+MIIBfake
+-----END CERTIFICATE-----
+`
+
+func TestAuthLoginCF_Init(t *testing.T) {
+	tests := []authLoginInitTest{
+		{
+			name:      "basic",
+			authField: consts.FieldAuthLoginCF,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginCF: []interface{}{
+					map[string]interface{}{
+						consts.FieldNamespace:      "ns1",
+						consts.FieldRole:           "alice",
+						consts.FieldCFInstanceCert: "/etc/cf-instance-credentials/instance.crt",
+						consts.FieldCFInstanceKey:  "/etc/cf-instance-credentials/instance.key",
+					},
+				},
+			},
+			expectParams: map[string]interface{}{
+				consts.FieldNamespace:        "ns1",
+				consts.FieldUseRootNamespace: false,
+				consts.FieldMount:            consts.MountTypeCF,
+				consts.FieldRole:             "alice",
+				consts.FieldCFInstanceCert:   "/etc/cf-instance-credentials/instance.crt",
+				consts.FieldCFInstanceKey:    "/etc/cf-instance-credentials/instance.key",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "env",
+			authField: consts.FieldAuthLoginCF,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginCF: []interface{}{
+					map[string]interface{}{
+						consts.FieldRole: "alice",
+					},
+				},
+			},
+			envVars: map[string]string{
+				consts.EnvVarCFInstanceCert: "/env/instance.crt",
+				consts.EnvVarCFInstanceKey:  "/env/instance.key",
+			},
+			expectParams: map[string]interface{}{
+				consts.FieldNamespace:        "",
+				consts.FieldUseRootNamespace: false,
+				consts.FieldMount:            consts.MountTypeCF,
+				consts.FieldRole:             "alice",
+				consts.FieldCFInstanceCert:   "/env/instance.crt",
+				consts.FieldCFInstanceKey:    "/env/instance.key",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "error-missing-required",
+			authField: consts.FieldAuthLoginCF,
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginCF: []interface{}{
+					map[string]interface{}{
+						consts.FieldRole: "alice",
+					},
+				},
+			},
+			envVars: map[string]string{
+				consts.EnvVarCFInstanceCert: "",
+				consts.EnvVarCFInstanceKey:  "",
+			},
+			expectParams: nil,
+			wantErr:      true,
+			expectErr: fmt.Errorf("required fields are unset: %v", []string{
+				consts.FieldCFInstanceCert,
+				consts.FieldCFInstanceKey,
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := map[string]*schema.Schema{
+				tt.authField: GetCFLoginSchema(tt.authField),
+			}
+			assertAuthLoginInit(t, tt, s, &AuthLoginCF{})
+		})
+	}
+}
+
+func TestAuthLoginCF_LoginPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		mount string
+		want  string
+	}{
+		{
+			name: "default",
+			want: "auth/cf/login",
+		},
+		{
+			name:  "other",
+			mount: "other",
+			want:  "auth/other/login",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &AuthLoginCF{
+				AuthLoginCommon: AuthLoginCommon{
+					mount: tt.mount,
+				},
+			}
+			if got := l.LoginPath(); got != tt.want {
+				t.Errorf("LoginPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthLoginCF_Login(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "instance.crt")
+	keyFile := filepath.Join(dir, "instance.key")
+	if err := os.WriteFile(certFile, []byte(testCFInstanceCert), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the handler verifies the signature the way the CF auth engine does
+	handlerFunc := func(t *testLoginHandler, w http.ResponseWriter, req *http.Request) {
+		params := t.params[len(t.params)-1]
+		if err := testVerifyCFLogin(&key.PublicKey, params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		m, err := json.Marshal(
+			&api.Secret{
+				Auth: &api.SecretAuth{
+					Metadata: map[string]string{
+						"role": params[consts.FieldRole].(string),
+					},
+				},
+			},
+		)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(m)
+	}
+
+	tests := []authLoginTest{
+		{
+			name: "basic",
+			authLogin: &AuthLoginCF{
+				AuthLoginCommon{
+					authField: consts.FieldAuthLoginCF,
+					params: map[string]interface{}{
+						consts.FieldRole:           "alice",
+						consts.FieldCFInstanceCert: certFile,
+						consts.FieldCFInstanceKey:  keyFile,
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			expectReqCount:     1,
+			skipCheckReqParams: true,
+			expectReqPaths:     []string{"/v1/auth/cf/login"},
+			want: &api.Secret{
+				Auth: &api.SecretAuth{
+					Metadata: map[string]string{
+						"role": "alice",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error-missing-key",
+			authLogin: &AuthLoginCF{
+				AuthLoginCommon{
+					authField: consts.FieldAuthLoginCF,
+					params: map[string]interface{}{
+						consts.FieldRole:           "alice",
+						consts.FieldCFInstanceCert: certFile,
+						consts.FieldCFInstanceKey:  filepath.Join(dir, "missing.key"),
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			wantErr: true,
+		},
+		{
+			name: "error-uninitialized",
+			authLogin: &AuthLoginCF{
+				AuthLoginCommon{
+					initialized: false,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			want:      nil,
+			wantErr:   true,
+			expectErr: authLoginInitCheckError,
+		},
+		{
+			name: "error-vault-token-set",
+			authLogin: &AuthLoginCF{
+				AuthLoginCommon{
+					authField: consts.FieldAuthLoginCF,
+					params: map[string]interface{}{
+						consts.FieldRole:           "alice",
+						consts.FieldCFInstanceCert: certFile,
+						consts.FieldCFInstanceKey:  keyFile,
+					},
+					initialized: true,
+				},
+			},
+			handler: &testLoginHandler{
+				handlerFunc: handlerFunc,
+			},
+			token:     "foo",
+			wantErr:   true,
+			expectErr: errors.New("vault login client has a token set"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testAuthLogin(t, tt)
+		})
+	}
+}
+
+func TestSignCFLogin(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name      string
+		keyPEM    []byte
+		expectErr string
+	}{
+		{
+			name:   "pkcs1",
+			keyPEM: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+		{
+			name:   "pkcs8",
+			keyPEM: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		},
+		{
+			name:      "not-pem",
+			keyPEM:    []byte("not a key"),
+			expectErr: "no PEM block found",
+		},
+		{
+			name:      "ec-key",
+			keyPEM:    pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8}),
+			expectErr: "expected an RSA key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := signCFLogin(tt.keyPEM, testCFInstanceCert, "alice", signingTime)
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := testVerifyCFLogin(&key.PublicKey, map[string]interface{}{
+				consts.FieldRole:           "alice",
+				consts.FieldCFInstanceCert: testCFInstanceCert,
+				consts.FieldSigningTime:    "2024-01-02T03:04:05Z",
+				consts.FieldSignature:      signature,
+			}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func testVerifyCFLogin(pub *rsa.PublicKey, params map[string]interface{}) error {
+	signingTime, err := time.Parse(cfSigningTimeFormat, params[consts.FieldSigningTime].(string))
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.URLEncoding.DecodeString(params[consts.FieldSignature].(string))
+	if err != nil {
+		return err
+	}
+
+	digest := cfLoginDigest(params[consts.FieldCFInstanceCert].(string), params[consts.FieldRole].(string), signingTime)
+	return rsa.VerifyPSS(pub, crypto.SHA256, digest, signature, nil)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+// The kubernetes login is only supported by the vault_auth_login_test data source.
+func init() {
+	field := consts.FieldAuthLoginKubernetes
+	if err := dataSourceAuthLoginRegistry.Register(field,
+		func(r *schema.ResourceData) (AuthLogin, error) {
+			a := &AuthLoginKubernetes{}
+			return a.Init(r, field)
+		}, GetKubernetesLoginSchema); err != nil {
+		panic(err)
+	}
+}
+
+// GetKubernetesLoginSchema for the kubernetes authentication engine.
+func GetKubernetesLoginSchema(authField string) *schema.Schema {
+	return getLoginSchema(
+		authField,
+		"Login to vault using the kubernetes method",
+		GetKubernetesLoginSchemaResource,
+	)
+}
+
+// GetKubernetesLoginSchemaResource for the kubernetes authentication engine.
+func GetKubernetesLoginSchemaResource(authField string) *schema.Resource {
+	return mustAddLoginSchema(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			consts.FieldRole: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the login role.",
+			},
+			consts.FieldJWT: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "A Kubernetes service account token.",
+			},
+		},
+	}, authField, consts.MountTypeKubernetes)
+}
+
+var _ AuthLogin = (*AuthLoginKubernetes)(nil)
+
+// AuthLoginKubernetes for handling the Vault kubernetes authentication engine.
+// Requires configuration provided by SchemaLoginKubernetes.
+type AuthLoginKubernetes struct {
+	AuthLoginCommon
+}
+
+// MountPath for the kubernetes authentication engine.
+func (l *AuthLoginKubernetes) MountPath() string {
+	if l.mount == "" {
+		return l.Method()
+	}
+	return l.mount
+}
+
+// LoginPath for the kubernetes authentication engine.
+func (l *AuthLoginKubernetes) LoginPath() string {
+	return fmt.Sprintf("auth/%s/login", l.MountPath())
+}
+
+func (l *AuthLoginKubernetes) Init(d *schema.ResourceData, authField string) (AuthLogin, error) {
+	if err := l.AuthLoginCommon.Init(d, authField,
+		func(data *schema.ResourceData) error {
+			return l.checkRequiredFields(d, consts.FieldRole, consts.FieldJWT)
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Method name for the kubernetes authentication engine.
+func (l *AuthLoginKubernetes) Method() string {
+	return consts.AuthMethodKubernetes
+}
+
+// Login using the kubernetes authentication engine.
+func (l *AuthLoginKubernetes) Login(client *api.Client) (*api.Secret, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+
+	params, err := l.copyParamsExcluding(
+		consts.FieldUseRootNamespace,
+		consts.FieldNamespace,
+		consts.FieldMount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.login(client, l.LoginPath(), params)
+}
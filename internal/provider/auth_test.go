@@ -20,7 +20,7 @@ import (
 
 // expectedRegisteredAuthLogin value should be modified when adding
 // registering/de-registering AuthLogin resources.
-const expectedRegisteredAuthLogin = 12
+const expectedRegisteredAuthLogin = 14
 
 type authLoginTest struct {
 	name               string
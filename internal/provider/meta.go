@@ -25,6 +25,7 @@ import (
 
 	"github.com/hashicorp/terraform-provider-vault/helper"
 	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
 )
 
 const (
@@ -159,6 +160,36 @@ func (p *ProviderMeta) GetVaultVersion() *version.Version {
 	return p.vaultVersion
 }
 
+// GetPKIRolePolicy returns the rules configured in the provider's
+// pki_role_policy block, or nil if the block is not set.
+func (p *ProviderMeta) GetPKIRolePolicy() (*pki.RolePolicy, error) {
+	if p.resourceData == nil {
+		return nil, nil
+	}
+
+	v, ok := p.resourceData.GetOk(consts.FieldPKIRolePolicy)
+	if !ok {
+		return nil, nil
+	}
+
+	l := v.([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	return pki.NewRolePolicy(l[0].(map[string]interface{}))
+}
+
+// GetSentinelBinary returns the path of the sentinel binary configured in
+// the provider, or an empty string if it is not set.
+func (p *ProviderMeta) GetSentinelBinary() string {
+	if p.resourceData == nil {
+		return ""
+	}
+
+	return p.resourceData.Get(consts.FieldSentinelBinary).(string)
+}
+
 func (p *ProviderMeta) validate() error {
 	if p.client == nil {
 		return fmt.Errorf("root api.Client not set, init with NewProviderMeta()")
@@ -531,6 +562,34 @@ func IsEnterpriseSupported(meta interface{}) bool {
 	return p.IsEnterpriseSupported()
 }
 
+// GetPKIRolePolicy returns the PKI role policy configured
+// on the providerMeta obtained from the provided interface.
+func GetPKIRolePolicy(meta interface{}) (*pki.RolePolicy, error) {
+	var p *ProviderMeta
+	switch v := meta.(type) {
+	case *ProviderMeta:
+		p = v
+	default:
+		return nil, fmt.Errorf("meta argument must be a %T, not %T", p, meta)
+	}
+
+	return p.GetPKIRolePolicy()
+}
+
+// GetSentinelBinary returns the path of the sentinel binary configured in
+// the provider.
+func GetSentinelBinary(meta interface{}) (string, error) {
+	var p *ProviderMeta
+	switch v := meta.(type) {
+	case *ProviderMeta:
+		p = v
+	default:
+		return "", fmt.Errorf("meta argument must be a %T, not %T", p, meta)
+	}
+
+	return p.GetSentinelBinary(), nil
+}
+
 func getVaultVersion(client *api.Client) (*version.Version, error) {
 	clone, err := client.Clone()
 	if err != nil {
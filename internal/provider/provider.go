@@ -216,6 +216,51 @@ func NewProvider(
 					"which is normally determined dynamically from the target Vault server",
 				ValidateDiagFunc: ValidateDiagSemVer,
 			},
+			consts.FieldPKIRolePolicy: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Rules enforced at plan time on all vault_pki_secret_backend_role resources.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldDenyAllowAnyName: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Forbid roles that set allow_any_name.",
+						},
+						consts.FieldDenyAllowGlobDomains: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Forbid roles that set allow_glob_domains.",
+						},
+						consts.FieldRequireEnforceHostnames: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Require roles to set enforce_hostnames.",
+						},
+						consts.FieldMaxTTL: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The maximum max_ttl roles may set, roles must set max_ttl explicitly.",
+							ValidateFunc: ValidateDurationSecond,
+						},
+						consts.FieldRequiredPolicyIdentifiers: {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Policy identifier OIDs that every role must include.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			consts.FieldSentinelBinary: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(consts.EnvVarSentinelBinary, ""),
+				Description: "Path of the sentinel binary used to validate and test Sentinel policies at plan time.",
+			},
 		},
 		ConfigureFunc:  NewProviderMeta,
 		DataSourcesMap: dataSourcesMap,
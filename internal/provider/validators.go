@@ -12,6 +12,7 @@ import (
 
 	"github.com/gosimple/slug"
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -52,6 +53,20 @@ func ValidateDuration(i interface{}, k string) (s []string, es []error) {
 	return
 }
 
+// ValidateDurationSecond accepts a duration string or an integer number of seconds.
+func ValidateDurationSecond(i interface{}, k string) (s []string, es []error) {
+	v, ok := i.(string)
+	if !ok {
+		es = append(es, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := parseutil.ParseDurationSecond(v); err != nil {
+		es = append(es, fmt.Errorf("expected '%s' to be a valid duration string or number of seconds", k))
+	}
+	return
+}
+
 func ValidateNoTrailingSlash(i interface{}, k string) ([]string, []error) {
 	var errs []error
 	if err := validatePath(regexpPathTrailing, i, k); err != nil {
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sentinel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const policyName = "policy"
+
+// mainRuleRegex matches the assignment of the main rule at the start of a
+// line.
+var mainRuleRegex = regexp.MustCompile(`(?m)^\s*main\s*=`)
+
+// Validate does a basic check of the Sentinel source for use when no sentinel
+// binary is available: the source must not be empty and must assign a main
+// rule at the start of a line. It does not parse the source, syntax errors
+// are only reported by Vault or by sentinel fmt.
+func Validate(source string) error {
+	if strings.TrimSpace(source) == "" {
+		return errors.New("the policy is empty")
+	}
+
+	if !mainRuleRegex.MatchString(source) {
+		return errors.New("the policy does not define a main rule")
+	}
+
+	return nil
+}
+
+// TestCase is a test of the policy, run with mocked global values.
+type TestCase struct {
+	Name string
+	// Globals maps the name of a global, such as request or identity, to its
+	// JSON encoded value.
+	Globals map[string]string
+	// Expected is the expected result of the main rule.
+	Expected bool
+}
+
+// Runner runs the sentinel binary.
+type Runner struct {
+	Binary string
+}
+
+// Fmt parses the policy with sentinel fmt, without writing any changes.
+func (r *Runner) Fmt(ctx context.Context, source string) error {
+	dir, err := os.MkdirTemp("", "terraform-provider-vault-sentinel")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, policyName+".sentinel")
+	if err := os.WriteFile(file, []byte(source), 0o600); err != nil {
+		return err
+	}
+
+	out, err := r.run(ctx, dir, "fmt", "-write=false", file)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("invalid Sentinel policy: %s", strings.ReplaceAll(out, file, policyName+".sentinel"))
+	}
+
+	return err
+}
+
+// Test runs the test cases with sentinel test. It returns an error that
+// includes the output of the binary if any test case fails.
+func (r *Runner) Test(ctx context.Context, source string, cases []*TestCase) error {
+	if len(cases) == 0 {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "terraform-provider-vault-sentinel")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, policyName+".sentinel"), []byte(source), 0o600); err != nil {
+		return err
+	}
+
+	testDir := filepath.Join(dir, "test", policyName)
+	if err := os.MkdirAll(testDir, 0o700); err != nil {
+		return err
+	}
+
+	for _, tc := range cases {
+		b, err := testCaseConfig(tc)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(testDir, tc.Name+".json"), b, 0o600); err != nil {
+			return err
+		}
+	}
+
+	out, err := r.run(ctx, dir, "test")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("tests of the Sentinel policy failed:\n%s", out)
+	}
+
+	return err
+}
+
+// testCaseConfig returns the JSON test configuration of the test case.
+func testCaseConfig(tc *TestCase) ([]byte, error) {
+	globals := map[string]interface{}{}
+	for name, value := range tc.Globals {
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("test %q: invalid JSON for global %q: %w", tc.Name, name, err)
+		}
+		globals[name] = map[string]interface{}{
+			"value": v,
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"global": globals,
+		"test": map[string]interface{}{
+			"rules": map[string]interface{}{
+				"main": tc.Expected,
+			},
+		},
+	}, "", "  ")
+}
+
+func (r *Runner) run(ctx context.Context, dir string, args ...string) (string, error) {
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.Binary, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return strings.TrimSpace(out.String()), fmt.Errorf("error running %s: %w", r.Binary, err)
+	}
+
+	return out.String(), nil
+}
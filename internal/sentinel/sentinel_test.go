@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sentinel
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		expectErr string
+	}{
+		{
+			name: "valid",
+			source: `
+import "strings"
+
+main = rule {
+  strings.has_prefix(request.path, "secret/") and
+  request.operation in ["read", "list"]
+}
+`,
+		},
+		{
+			name:      "empty",
+			source:    " \n",
+			expectErr: "the policy is empty",
+		},
+		{
+			name:   "indented-main",
+			source: "allow = rule { true }\n  main = allow\n",
+		},
+		{
+			name:      "no-main",
+			source:    "allow = rule { true }\n",
+			expectErr: "does not define a main rule",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.source)
+			if tt.expectErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestTestCaseConfig(t *testing.T) {
+	b, err := testCaseConfig(&TestCase{
+		Name:     "read",
+		Globals:  map[string]string{"request": `{"operation": "read"}`},
+		Expected: false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual map[string]interface{}
+	if err := json.Unmarshal(b, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"global": map[string]interface{}{
+			"request": map[string]interface{}{
+				"value": map[string]interface{}{"operation": "read"},
+			},
+		},
+		"test": map[string]interface{}{
+			"rules": map[string]interface{}{"main": false},
+		},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if _, err := testCaseConfig(&TestCase{Name: "bad", Globals: map[string]string{"request": "{"}}); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+// fakeSentinel writes a script that behaves like the sentinel binary: fmt
+// fails on sources containing "invalid", test fails when a test file expects
+// false.
+func fakeSentinel(t *testing.T) string {
+	t.Helper()
+
+	binary := filepath.Join(t.TempDir(), "sentinel")
+	script := `#!/bin/sh
+case "$1" in
+fmt)
+  if grep -q invalid "$3"; then
+    echo "$3:1:1: expected operand"
+    exit 1
+  fi
+  ;;
+test)
+  for f in test/policy/*.json; do
+    if grep -q '"main": false' "$f"; then
+      echo "FAIL - policy.sentinel"
+      echo "  FAIL - $f"
+      exit 1
+    fi
+  done
+  echo "PASS - policy.sentinel"
+  ;;
+esac
+`
+	if err := os.WriteFile(binary, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	return binary
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+	r := &Runner{Binary: fakeSentinel(t)}
+
+	if err := r.Fmt(ctx, "main = rule { true }"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	err := r.Fmt(ctx, "main = rule { invalid }")
+	if err == nil || !strings.Contains(err.Error(), "invalid Sentinel policy: policy.sentinel:1:1: expected operand") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+
+	cases := []*TestCase{
+		{Name: "allowed", Expected: true, Globals: map[string]string{"request": `{"operation": "read"}`}},
+	}
+	if err := r.Test(ctx, "main = rule { true }", cases); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	cases = append(cases, &TestCase{Name: "denied", Expected: false})
+	err = r.Test(ctx, "main = rule { true }", cases)
+	if err == nil || !strings.Contains(err.Error(), "FAIL - test/policy/denied.json") {
+		t.Errorf("expected a test failure, got %v", err)
+	}
+
+	if err := r.Test(ctx, "main = rule { true }", nil); err != nil {
+		t.Errorf("expected no error without test cases, got %s", err)
+	}
+
+	r = &Runner{Binary: filepath.Join(t.TempDir(), "missing")}
+	err = r.Fmt(ctx, "main = rule { true }")
+	if err == nil || strings.Contains(err.Error(), "invalid Sentinel policy") {
+		t.Errorf("expected an error running the binary, got %v", err)
+	}
+}
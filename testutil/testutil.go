@@ -899,6 +899,96 @@ func GenerateCA() ([]byte, []byte, error) {
 	return buf.Bytes(), key, nil
 }
 
+// GenerateCSR returns a PEM encoded certificate request for commonName
+// along with its PEM encoded private key.
+func GenerateCSR(commonName string) ([]byte, []byte, error) {
+	signer, key, err := PrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: commonName},
+	}
+
+	bs, err := x509.CreateCertificateRequest(rand.Reader, &template, signer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	err = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: bs})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buf.Bytes(), key, nil
+}
+
+// SignIntermediateCSR signs the PEM encoded csr with the CA certificate and
+// key returned from GenerateCA, producing an intermediate CA certificate with
+// the given max path length. A negative maxPathLen leaves the path length
+// unconstrained.
+func SignIntermediateCSR(caCert, caKey, csr []byte, maxPathLen int) ([]byte, error) {
+	b, _ := pem.Decode(caCert)
+	if b == nil {
+		return nil, fmt.Errorf("invalid CA certificate")
+	}
+	parent, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ = pem.Decode(caKey)
+	if b == nil {
+		return nil, fmt.Errorf("invalid CA key")
+	}
+	signer, err := x509.ParseECPrivateKey(b.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ = pem.Decode(csr)
+	if b == nil {
+		return nil, fmt.Errorf("invalid certificate request")
+	}
+	req, err := x509.ParseCertificateRequest(b.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sn, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          sn,
+		Subject:               req.Subject,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		IsCA:                  true,
+		MaxPathLen:            maxPathLen,
+		MaxPathLenZero:        maxPathLen == 0,
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		NotBefore:             time.Now().Add(-1 * time.Minute),
+	}
+
+	bs, err := x509.CreateCertificate(
+		rand.Reader, &template, parent, req.PublicKey, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: bs})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 // PrivateKey returns a new ECDSA-based private key. Both a crypto.Signer
 // and the key are returned.
 func PrivateKey() (crypto.Signer, []byte, error) {
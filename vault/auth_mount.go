@@ -8,6 +8,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/vault/api"
@@ -114,6 +115,100 @@ func authMountTuneGet(client *api.Client, path string) (map[string]interface{},
 	return flattenAuthMethodTune(tune), nil
 }
 
+// authMountTuneUpdate tunes the auth mount at path if the tune block changed.
+func authMountTuneUpdate(d *schema.ResourceData, client *api.Client, path string) error {
+	if !d.HasChange("tune") {
+		return nil
+	}
+
+	log.Printf("[INFO] Auth %q tune configuration changed", path)
+	if raw, ok := d.GetOk("tune"); ok {
+		log.Printf("[DEBUG] Writing auth tune to %q", path)
+		if err := authMountTune(client, path, raw); err != nil {
+			return fmt.Errorf("error writing tune information to Vault: %w", err)
+		}
+		log.Printf("[INFO] Wrote auth tune to %q", path)
+	}
+
+	return nil
+}
+
+// authMountTuneRead sets the tune block from the tune config of the auth mount
+// at path, normalized against the tune block in state, see
+// normalizeAuthMountTune.
+func authMountTuneRead(d *schema.ResourceData, client *api.Client, path string) error {
+	log.Printf("[DEBUG] Reading auth tune from %q", path+"/tune")
+	rawTune, err := authMountTuneGet(client, path)
+	if err != nil {
+		return fmt.Errorf("error reading tune information from Vault: %w", err)
+	}
+
+	if raw, ok := d.GetOk("tune"); ok {
+		normalizeAuthMountTune(rawTune, raw.(*schema.Set).List())
+	}
+
+	if err := d.Set("tune", []map[string]interface{}{rawTune}); err != nil {
+		log.Printf("[ERROR] Error when setting tune config from path %q to state: %s", path+"/tune", err)
+		return err
+	}
+
+	return nil
+}
+
+var (
+	// authMountTuneScalarFields are only sent to Vault when they are set.
+	authMountTuneScalarFields = []string{
+		"default_lease_ttl",
+		"max_lease_ttl",
+		"listing_visibility",
+		"token_type",
+	}
+
+	authMountTuneDurationFields = []string{
+		"default_lease_ttl",
+		"max_lease_ttl",
+	}
+)
+
+// normalizeAuthMountTune normalizes the tune config read from Vault against
+// the configured tune block, so that drift is only reported for the fields
+// that Terraform manages:
+//   - scalar fields that are unset in the configured block are cleared, since
+//     they are never sent to Vault.
+//   - durations that are equal to the configured ones keep their configured
+//     form, e.g. "60s" and "1m".
+func normalizeAuthMountTune(rawTune map[string]interface{}, configured []interface{}) {
+	if len(configured) == 0 || configured[0] == nil {
+		return
+	}
+
+	c := configured[0].(map[string]interface{})
+	for _, k := range authMountTuneScalarFields {
+		if v, ok := c[k].(string); !ok || v == "" {
+			rawTune[k] = ""
+		}
+	}
+
+	for _, k := range authMountTuneDurationFields {
+		v, _ := c[k].(string)
+		if v == "" {
+			continue
+		}
+
+		configuredTTL, err := parseutil.ParseDurationSecond(v)
+		if err != nil {
+			continue
+		}
+		actualTTL, err := parseutil.ParseDurationSecond(rawTune[k])
+		if err != nil {
+			continue
+		}
+		if configuredTTL == actualTTL {
+			rawTune[k] = v
+		}
+	}
+}
+
 func authMountDisable(client *api.Client, path string) error {
 	log.Printf("[DEBUG] Disabling auth mount config from '%q'", path)
 	err := client.Sys().DisableAuth(path)
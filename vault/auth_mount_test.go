@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+func TestNormalizeAuthMountTune(t *testing.T) {
+	vaultTune := func() map[string]interface{} {
+		return map[string]interface{}{
+			"default_lease_ttl":           "1m",
+			"max_lease_ttl":               "768h",
+			"listing_visibility":          "unauth",
+			"token_type":                  "default-service",
+			"audit_non_hmac_request_keys": []interface{}{"key1"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		configured []interface{}
+		want       map[string]interface{}
+	}{
+		{
+			name: "not-configured",
+			want: vaultTune(),
+		},
+		{
+			name: "unset-scalars",
+			configured: []interface{}{
+				map[string]interface{}{
+					"default_lease_ttl":           "1m",
+					"max_lease_ttl":               "",
+					"listing_visibility":          "",
+					"token_type":                  "",
+					"audit_non_hmac_request_keys": []interface{}{},
+				},
+			},
+			want: map[string]interface{}{
+				"default_lease_ttl":           "1m",
+				"max_lease_ttl":               "",
+				"listing_visibility":          "",
+				"token_type":                  "",
+				"audit_non_hmac_request_keys": []interface{}{"key1"},
+			},
+		},
+		{
+			name: "equal-durations",
+			configured: []interface{}{
+				map[string]interface{}{
+					"default_lease_ttl":  "60s",
+					"max_lease_ttl":      "2764800",
+					"listing_visibility": "unauth",
+					"token_type":         "default-service",
+				},
+			},
+			want: map[string]interface{}{
+				"default_lease_ttl":           "60s",
+				"max_lease_ttl":               "2764800",
+				"listing_visibility":          "unauth",
+				"token_type":                  "default-service",
+				"audit_non_hmac_request_keys": []interface{}{"key1"},
+			},
+		},
+		{
+			name: "drifted-durations",
+			configured: []interface{}{
+				map[string]interface{}{
+					"default_lease_ttl":  "30s",
+					"max_lease_ttl":      "1h",
+					"listing_visibility": "hidden",
+					"token_type":         "batch",
+				},
+			},
+			want: vaultTune(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := vaultTune()
+			normalizeAuthMountTune(got, tt.configured)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeAuthMountTune() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthBackendResources_tune(t *testing.T) {
+	var wantTuneFields []string
+	for k := range authMountTuneSchema().Elem.(*schema.Resource).Schema {
+		wantTuneFields = append(wantTuneFields, k)
+	}
+	sort.Strings(wantTuneFields)
+
+	resources := map[string]*schema.Resource{
+		"vault_auth_backend":        AuthBackendResource(),
+		"vault_gcp_auth_backend":    gcpAuthBackendResource(),
+		"vault_github_auth_backend": githubAuthBackendResource(),
+		"vault_jwt_auth_backend":    jwtAuthBackendResource(),
+		"vault_ldap_auth_backend":   ldapAuthBackendResource(),
+		"vault_okta_auth_backend":   oktaAuthBackendResource(),
+		"vault_saml_auth_backend":   samlAuthBackendResource(),
+	}
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			s, ok := r.Schema["tune"]
+			if !ok {
+				t.Fatalf("expected a tune block")
+			}
+			if s.Type != schema.TypeSet || !s.Optional || !s.Computed {
+				t.Errorf("expected an optional and computed tune set")
+			}
+
+			var tuneFields []string
+			for k := range s.Elem.(*schema.Resource).Schema {
+				tuneFields = append(tuneFields, k)
+			}
+			sort.Strings(tuneFields)
+			if !reflect.DeepEqual(tuneFields, wantTuneFields) {
+				t.Errorf("expected tune fields %v, got %v", wantTuneFields, tuneFields)
+			}
+
+			// every resource must upgrade the state of its previous schema version
+			if r.SchemaVersion < 1 {
+				t.Fatalf("expected a schema version of at least 1, got %d", r.SchemaVersion)
+			}
+			if len(r.StateUpgraders) == 0 {
+				t.Fatalf("expected state upgraders")
+			}
+			if last := r.StateUpgraders[len(r.StateUpgraders)-1].Version; last != r.SchemaVersion-1 {
+				t.Errorf("expected the last state upgrader to be for version %d, got %d",
+					r.SchemaVersion-1, last)
+			}
+
+			if err := r.InternalValidate(nil, true); err != nil {
+				t.Errorf("invalid resource: %s", err)
+			}
+		})
+	}
+}
+
+// testAccCheckAuthMountTune checks the tune block of the auth backend
+// resourceName.
+func testAccCheckAuthMountTune(resourceName, ttl, visibility, tokenType string, keys int) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(resourceName, "tune.#", "1"),
+		resource.TestCheckResourceAttr(resourceName, "tune.0.default_lease_ttl", ttl),
+		resource.TestCheckResourceAttr(resourceName, "tune.0.max_lease_ttl", ""),
+		resource.TestCheckResourceAttr(resourceName, "tune.0.listing_visibility", visibility),
+		resource.TestCheckResourceAttr(resourceName, "tune.0.token_type", tokenType),
+		resource.TestCheckResourceAttr(resourceName, "tune.0.audit_non_hmac_request_keys.#", strconv.Itoa(keys)),
+	)
+}
+
+// testAccAuthMountTuneDriftStep returns a test step that changes the
+// listing_visibility of the auth mount at path outside of Terraform, and
+// expects a non-empty plan for config.
+func testAccAuthMountTuneDriftStep(t *testing.T, path, config string) resource.TestStep {
+	return resource.TestStep{
+		PreConfig: func() {
+			client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+			if err := client.Sys().TuneMount("auth/"+path, api.MountConfigInput{
+				ListingVisibility: "hidden",
+			}); err != nil {
+				t.Fatalf("error tuning auth mount %q: %s", path, err)
+			}
+		},
+		Config:             config,
+		PlanOnly:           true,
+		ExpectNonEmptyPlan: true,
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	awsARNPartitionRegex = regexp.MustCompile(`^aws(-[a-z]+)*$`)
+	awsAccountIDRegex    = regexp.MustCompile(`^[0-9]{12}$`)
+	awsPartialIDRegex    = regexp.MustCompile(`^[0-9]{0,12}$`)
+)
+
+// validateAWSAuthIAMARN checks that arn is an IAM ARN of one of the resource
+// types, the way the AWS auth engine matches them at login. A wildcard is only
+// supported at the end of the ARN, where it is matched as a prefix.
+func validateAWSAuthIAMARN(arn string, resourceTypes ...string) error {
+	if i := strings.Index(arn, "*"); i >= 0 && i != len(arn)-1 {
+		return errors.New("a wildcard is only supported at the end of the ARN")
+	}
+
+	wildcard := strings.HasSuffix(arn, "*")
+	parts := strings.SplitN(strings.TrimSuffix(arn, "*"), ":", 6)
+	if !wildcard && len(parts) != 6 {
+		return fmt.Errorf("expected 6 colon-separated parts, got %d", len(parts))
+	}
+
+	for i, part := range parts {
+		// with a wildcard, the last part is only a prefix
+		partial := wildcard && i == len(parts)-1
+		switch i {
+		case 0:
+			if part != "arn" && !(partial && strings.HasPrefix("arn", part)) {
+				return errors.New(`the ARN does not begin with "arn:"`)
+			}
+		case 1:
+			if !partial && !awsARNPartitionRegex.MatchString(part) {
+				return fmt.Errorf("invalid partition %q", part)
+			}
+		case 2:
+			if part == "sts" {
+				return errors.New("STS ARNs are not supported, assumed roles are matched by their IAM role ARN " +
+					`"arn:<partition>:iam::<account_id>:role/<role_name>"`)
+			}
+			if part != "iam" && !(partial && strings.HasPrefix("iam", part)) {
+				return fmt.Errorf(`unsupported service %q, expected "iam"`, part)
+			}
+		case 3:
+			if part != "" {
+				return fmt.Errorf("IAM ARNs have no region, got %q", part)
+			}
+		case 4:
+			if !awsAccountIDRegex.MatchString(part) && !(partial && awsPartialIDRegex.MatchString(part)) {
+				return fmt.Errorf("invalid account ID %q, expected 12 digits", part)
+			}
+		case 5:
+			if err := validateAWSAuthIAMResource(part, partial, resourceTypes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateAWSAuthIAMResource(resource string, partial bool, resourceTypes []string) error {
+	resourceType, name, found := strings.Cut(resource, "/")
+	if !found {
+		if partial {
+			// the resource type itself may be a prefix
+			for _, t := range resourceTypes {
+				if strings.HasPrefix(t, resourceType) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("invalid resource %q, expected one of %s", resource,
+			strings.Join(awsAuthResourceFormats(resourceTypes), ", "))
+	}
+
+	valid := false
+	for _, t := range resourceTypes {
+		if t == resourceType {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unsupported resource type %q, expected one of %s", resourceType,
+			strings.Join(awsAuthResourceFormats(resourceTypes), ", "))
+	}
+
+	if !partial && (name == "" || strings.HasSuffix(name, "/")) {
+		return fmt.Errorf("invalid resource %q, the %s name is empty", resource, resourceType)
+	}
+
+	return nil
+}
+
+func awsAuthResourceFormats(resourceTypes []string) []string {
+	var formats []string
+	for _, t := range resourceTypes {
+		formats = append(formats, fmt.Sprintf("%q", t+"/<name>"))
+	}
+	return formats
+}
+
+// awsAuthWildcardARNWarnings warns about the wildcard ARNs of the field, which
+// the AWS auth engine cannot resolve to unique IDs.
+func awsAuthWildcardARNWarnings(field string, arns []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, arn := range arns {
+		if !strings.HasSuffix(arn, "*") {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("wildcard ARN %q in %q is not resolved to a unique ID", arn, field),
+			Detail: "resolve_aws_unique_ids only applies to the ARNs without a wildcard. " +
+				"Principals matching a wildcard ARN are compared by their full ARN at login, " +
+				"which requires Vault to be allowed iam:GetUser and iam:GetRole, " +
+				"and a principal that is deleted and recreated with the same name is still permitted to log in.",
+		})
+	}
+	return diags
+}
+
+// validateAWSAuthWildcardARN warns about the wildcard ARNs of the field when
+// the configuration is validated, before the role is written.
+func validateAWSAuthWildcardARN(field string) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		arn, ok := i.(string)
+		if !ok {
+			return nil
+		}
+
+		diags := awsAuthWildcardARNWarnings(field, []string{arn})
+		for j := range diags {
+			diags[j].AttributePath = path
+		}
+		return diags
+	}
+}
+
+// awsAuthResolvedPrincipalIDs maps the ARNs without a wildcard to the unique
+// IDs the AWS auth engine resolved them to. The engine resolves them in order,
+// skipping the wildcard ARNs.
+func awsAuthResolvedPrincipalIDs(arns, ids []string) map[string]string {
+	result := map[string]string{}
+
+	var resolvable []string
+	for _, arn := range arns {
+		if !strings.HasSuffix(arn, "*") {
+			resolvable = append(resolvable, arn)
+		}
+	}
+	if len(resolvable) != len(ids) {
+		return result
+	}
+
+	for i, arn := range resolvable {
+		result[arn] = ids[i]
+	}
+	return result
+}
@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateAWSAuthIAMARN(t *testing.T) {
+	tests := []struct {
+		name          string
+		arn           string
+		resourceTypes []string
+		wantErr       string
+	}{
+		{
+			name:          "role",
+			arn:           "arn:aws:iam::123456789012:role/MyRole",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "role-path",
+			arn:           "arn:aws-us-gov:iam::123456789012:role/path/to/MyRole",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "user",
+			arn:           "arn:aws-cn:iam::123456789012:user/alice",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "instance-profile",
+			arn:           "arn:aws:iam::123456789012:instance-profile/Webserver",
+			resourceTypes: []string{"instance-profile"},
+		},
+		{
+			name:          "wildcard-name",
+			arn:           "arn:aws:iam::123456789012:role/MyRole/*",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "wildcard-resource",
+			arn:           "arn:aws:iam::123456789012:*",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "wildcard-resource-type",
+			arn:           "arn:aws:iam::123456789012:ro*",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "wildcard-account",
+			arn:           "arn:aws:iam::1234*",
+			resourceTypes: []string{"user", "role"},
+		},
+		{
+			name:          "wildcard-not-at-end",
+			arn:           "arn:aws:iam::*:role/MyRole",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       "only supported at the end",
+		},
+		{
+			name:          "missing-parts",
+			arn:           "arn:aws:iam::123456789012",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       "expected 6 colon-separated parts, got 5",
+		},
+		{
+			name:          "not-an-arn",
+			arn:           "aws:iam::123456789012:role/MyRole:x",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       `does not begin with "arn:"`,
+		},
+		{
+			name:          "invalid-partition",
+			arn:           "arn:amazon:iam::123456789012:role/MyRole",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       `invalid partition "amazon"`,
+		},
+		{
+			name:          "sts",
+			arn:           "arn:aws:sts::123456789012:assumed-role/MyRole/session",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       "STS ARNs are not supported",
+		},
+		{
+			name:          "unsupported-service",
+			arn:           "arn:aws:ec2::123456789012:role/MyRole",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       `unsupported service "ec2"`,
+		},
+		{
+			name:          "region",
+			arn:           "arn:aws:iam:us-east-1:123456789012:role/MyRole",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       `IAM ARNs have no region, got "us-east-1"`,
+		},
+		{
+			name:          "short-account",
+			arn:           "arn:aws:iam::12345678901:role/MyRole",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       `invalid account ID "12345678901"`,
+		},
+		{
+			name:          "unsupported-resource-type",
+			arn:           "arn:aws:iam::123456789012:instance-profile/Webserver",
+			resourceTypes: []string{"user", "role"},
+			wantErr:       `unsupported resource type "instance-profile"`,
+		},
+		{
+			name:          "missing-resource-type",
+			arn:           "arn:aws:iam::123456789012:MyRole",
+			resourceTypes: []string{"role"},
+			wantErr:       `invalid resource "MyRole", expected one of "role/<name>"`,
+		},
+		{
+			name:          "empty-name",
+			arn:           "arn:aws:iam::123456789012:role/",
+			resourceTypes: []string{"role"},
+			wantErr:       "the role name is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAWSAuthIAMARN(tt.arn, tt.resourceTypes...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %q", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAWSAuthResolvedPrincipalIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		arns []string
+		ids  []string
+		want map[string]string
+	}{
+		{
+			name: "resolved",
+			arns: []string{
+				"arn:aws:iam::123456789012:role/MyRole",
+				"arn:aws:iam::123456789012:role/team/*",
+				"arn:aws:iam::123456789012:user/alice",
+			},
+			ids: []string{"AROAEXAMPLE", "AIDAEXAMPLE"},
+			want: map[string]string{
+				"arn:aws:iam::123456789012:role/MyRole": "AROAEXAMPLE",
+				"arn:aws:iam::123456789012:user/alice":  "AIDAEXAMPLE",
+			},
+		},
+		{
+			name: "not-resolved",
+			arns: []string{"arn:aws:iam::123456789012:role/MyRole"},
+			want: map[string]string{},
+		},
+		{
+			name: "wildcards-only",
+			arns: []string{"arn:aws:iam::123456789012:role/*"},
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := awsAuthResolvedPrincipalIDs(tt.arns, tt.ids); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("awsAuthResolvedPrincipalIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAWSAuthWildcardARNWarnings(t *testing.T) {
+	diags := awsAuthWildcardARNWarnings("bound_iam_principal_arns", []string{
+		"arn:aws:iam::123456789012:role/MyRole",
+		"arn:aws:iam::123456789012:role/team/*",
+	})
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 warning, got %#v", diags)
+	}
+	if !strings.Contains(diags[0].Summary, "arn:aws:iam::123456789012:role/team/*") {
+		t.Errorf("unexpected warning %q", diags[0].Summary)
+	}
+}
+
+func TestAWSAuthBackendRoleValidate_wildcardARN(t *testing.T) {
+	tests := []struct {
+		name         string
+		arns         []interface{}
+		wantWarnings int
+	}{
+		{
+			name:         "no-wildcard",
+			arns:         []interface{}{"arn:aws:iam::123456789012:role/MyRole"},
+			wantWarnings: 0,
+		},
+		{
+			name: "wildcard",
+			arns: []interface{}{
+				"arn:aws:iam::123456789012:role/MyRole",
+				"arn:aws:iam::123456789012:role/team/*",
+			},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// validation runs on the configuration alone, before the role
+			// is written to Vault
+			diags := awsAuthBackendRoleResource().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"role":                     "test",
+				"bound_iam_principal_arns": tt.arns,
+			}))
+			if diags.HasError() {
+				t.Fatalf("unexpected errors %#v", diags)
+			}
+
+			if len(diags) != tt.wantWarnings {
+				t.Fatalf("expected %d warnings, got %#v", tt.wantWarnings, diags)
+			}
+			for _, d := range diags {
+				if d.Severity != diag.Warning || !strings.Contains(d.Summary, "arn:aws:iam::123456789012:role/team/*") {
+					t.Errorf("unexpected diagnostic %#v", d)
+				}
+			}
+		})
+	}
+}
+
+func TestAWSAuthBackendRoleCustomizeDiff_arns(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "iam",
+			raw: map[string]interface{}{
+				"role":                     "test",
+				"auth_type":                "iam",
+				"bound_iam_principal_arns": []interface{}{"arn:aws:iam::123456789012:role/MyRole"},
+			},
+		},
+		{
+			name: "iam-inferred",
+			raw: map[string]interface{}{
+				"role":                            "test",
+				"auth_type":                       "iam",
+				"inferred_entity_type":            "ec2_instance",
+				"inferred_aws_region":             "us-east-1",
+				"bound_iam_role_arns":             []interface{}{"arn:aws:iam::123456789012:role/S3Access"},
+				"bound_iam_instance_profile_arns": []interface{}{"arn:aws:iam::123456789012:instance-profile/Webserver"},
+			},
+		},
+		{
+			name: "ec2",
+			raw: map[string]interface{}{
+				"role":                            "test",
+				"auth_type":                       "ec2",
+				"bound_iam_role_arns":             []interface{}{"arn:aws:iam::123456789012:role/S3Access"},
+				"bound_iam_instance_profile_arns": []interface{}{"arn:aws:iam::123456789012:instance-profile/Web*"},
+			},
+		},
+		{
+			name: "iam-invalid-principal",
+			raw: map[string]interface{}{
+				"role":                     "test",
+				"auth_type":                "iam",
+				"bound_iam_principal_arns": []interface{}{"arn:aws:iam::123456789012:rol/MyRole"},
+			},
+			wantErr: `invalid ARN "arn:aws:iam::123456789012:rol/MyRole" in "bound_iam_principal_arns"`,
+		},
+		{
+			name: "iam-role-arns",
+			raw: map[string]interface{}{
+				"role":                "test",
+				"auth_type":           "iam",
+				"bound_iam_role_arns": []interface{}{"arn:aws:iam::123456789012:role/S3Access"},
+			},
+			wantErr: `"bound_iam_role_arns" can only be set when auth_type is "ec2"`,
+		},
+		{
+			name: "ec2-principal-arns",
+			raw: map[string]interface{}{
+				"role":                     "test",
+				"auth_type":                "ec2",
+				"bound_iam_principal_arns": []interface{}{"arn:aws:iam::123456789012:role/MyRole"},
+			},
+			wantErr: `"bound_iam_principal_arns" can only be set when auth_type is "iam"`,
+		},
+		{
+			name: "ec2-invalid-instance-profile",
+			raw: map[string]interface{}{
+				"role":                            "test",
+				"auth_type":                       "ec2",
+				"bound_iam_instance_profile_arns": []interface{}{"arn:aws:iam::123456789012:role/Webserver"},
+			},
+			wantErr: `unsupported resource type "role"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := awsAuthBackendRoleResource()
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %q", tt.wantErr, err)
+			}
+		})
+	}
+}
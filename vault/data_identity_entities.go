@@ -0,0 +1,296 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const defaultIdentityMaxParallelReads = 10
+
+// identitySearchFilter holds the filters shared by the
+// vault_identity_entities and vault_identity_groups data sources.
+type identitySearchFilter struct {
+	metadata           map[string]string
+	policies           []string
+	aliasMountAccessor string
+	// disabled is nil when the filter is not set.
+	disabled *bool
+}
+
+func newIdentitySearchFilter(d *schema.ResourceData) *identitySearchFilter {
+	f := &identitySearchFilter{
+		metadata:           map[string]string{},
+		aliasMountAccessor: d.Get(consts.FieldAliasMountAccessor).(string),
+	}
+
+	for k, v := range d.Get(consts.FieldMetadata).(map[string]interface{}) {
+		f.metadata[k] = v.(string)
+	}
+
+	for _, v := range d.Get(consts.FieldPolicies).(*schema.Set).List() {
+		f.policies = append(f.policies, v.(string))
+	}
+
+	return f
+}
+
+// newIdentityEntitiesSearchFilter extends the shared filters with the
+// disabled state, which only entities have.
+func newIdentityEntitiesSearchFilter(d *schema.ResourceData) *identitySearchFilter {
+	f := newIdentitySearchFilter(d)
+	if v, ok := d.GetOkExists(consts.FieldDisabled); ok {
+		disabled := v.(bool)
+		f.disabled = &disabled
+	}
+
+	return f
+}
+
+// match reports whether an entry with the given attributes satisfies every
+// filter that is set.
+func (f *identitySearchFilter) match(metadata map[string]interface{}, policies []interface{}, mountAccessors []string, disabled bool) bool {
+	for k, v := range f.metadata {
+		if actual, ok := metadata[k]; !ok || actual != v {
+			return false
+		}
+	}
+
+	for _, p := range f.policies {
+		var found bool
+		for _, actual := range policies {
+			if actual == p {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.aliasMountAccessor != "" {
+		var found bool
+		for _, actual := range mountAccessors {
+			if actual == f.aliasMountAccessor {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.disabled != nil && *f.disabled != disabled {
+		return false
+	}
+
+	return true
+}
+
+// identitySearchSchema returns the filter arguments shared by the identity
+// search data sources.
+func identitySearchSchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		consts.FieldMetadata: {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: fmt.Sprintf("Only return %s whose metadata contains all of these key/value pairs.", kind),
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldPolicies: {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: fmt.Sprintf("Only return %s that have all of these policies.", kind),
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldAliasMountAccessor: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Only return %s that have an alias on the mount with this accessor.", kind),
+		},
+		consts.FieldMaxParallelReads: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultIdentityMaxParallelReads,
+			Description:  fmt.Sprintf("Maximum number of %s read from Vault in parallel.", kind),
+			ValidateFunc: validation.IntBetween(1, 100),
+		},
+		consts.FieldIDs: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: fmt.Sprintf("IDs of the matching %s.", kind),
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldNames: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: fmt.Sprintf("Names of the matching %s.", kind),
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func identityEntitiesDataSource() *schema.Resource {
+	s := identitySearchSchema("entities")
+	s[consts.FieldDisabled] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Only return entities with this disabled state.",
+	}
+	s[consts.FieldEntities] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The matching entities, sorted by name.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				consts.FieldID: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the entity.",
+				},
+				consts.FieldName: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the entity.",
+				},
+				consts.FieldDisabled: {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the entity is disabled.",
+				},
+				consts.FieldPolicies: {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Policies of the entity.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				consts.FieldMetadata: {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Description: "Metadata of the entity.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				consts.FieldAliasIDs: {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "IDs of the aliases of the entity.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(identityEntitiesDataSourceRead),
+		Schema:      s,
+	}
+}
+
+func identityEntitiesDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	filter := newIdentityEntitiesSearchFilter(d)
+
+	log.Printf("[DEBUG] Listing IdentityEntities from %q", entity.RootEntityIDPath)
+	resp, err := entity.ReadAll(ctx, client, entity.RootEntityIDPath, d.Get(consts.FieldMaxParallelReads).(int))
+	if err != nil {
+		return diag.Errorf("error reading IdentityEntities: %s", err)
+	}
+
+	var entities []map[string]interface{}
+	for _, data := range resp {
+		var ent entity.Entity
+		if err := mapstructure.Decode(data, &ent); err != nil {
+			return diag.FromErr(err)
+		}
+
+		var aliasIDs, accessors []string
+		for _, a := range ent.Aliases {
+			aliasIDs = append(aliasIDs, a.ID)
+			accessors = append(accessors, a.MountAccessor)
+		}
+
+		metadata, _ := ent.Metadata.(map[string]interface{})
+		policies := make([]interface{}, len(ent.Policies))
+		for i, p := range ent.Policies {
+			policies[i] = p
+		}
+
+		if !filter.match(metadata, policies, accessors, ent.Disabled) {
+			continue
+		}
+
+		entities = append(entities, map[string]interface{}{
+			consts.FieldID:       ent.ID,
+			consts.FieldName:     ent.Name,
+			consts.FieldDisabled: ent.Disabled,
+			consts.FieldPolicies: ent.Policies,
+			consts.FieldMetadata: metadata,
+			consts.FieldAliasIDs: aliasIDs,
+		})
+	}
+	log.Printf("[DEBUG] Found %d matching IdentityEntities", len(entities))
+
+	d.SetId(entity.RootEntityIDPath)
+
+	return setIdentitySearchResults(d, consts.FieldEntities, entities)
+}
+
+// setIdentitySearchResults sorts the matched entries by name and sets them
+// along with their IDs and names.
+func setIdentitySearchResults(d *schema.ResourceData, field string, results []map[string]interface{}) diag.Diagnostics {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i][consts.FieldName].(string) < results[j][consts.FieldName].(string)
+	})
+
+	ids := make([]string, len(results))
+	names := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r[consts.FieldID].(string)
+		names[i] = r[consts.FieldName].(string)
+	}
+
+	if err := d.Set(consts.FieldIDs, ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldNames, names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(field, results); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourceIdentityEntities(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-entity")
+	dataSourceName := "data.vault_identity_entities.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceIdentityEntitiesConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", name+"-a"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.1", name+"-b"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", "vault_identity_entity.a", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "entities.0.metadata.team", name),
+					resource.TestCheckResourceAttr(dataSourceName, "entities.0.policies.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "entities.0.disabled", "false"),
+					resource.TestCheckResourceAttr(dataSourceName+"_policy", "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName+"_policy", "names.0", name+"-b"),
+					resource.TestCheckResourceAttr(dataSourceName+"_disabled", "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName+"_disabled", "names.0", name+"-c"),
+					resource.TestCheckResourceAttr(dataSourceName+"_alias", "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName+"_alias", "names.0", name+"-a"),
+					resource.TestCheckResourceAttr(dataSourceName+"_alias", "entities.0.alias_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceIdentityEntitiesConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%[1]s"
+}
+
+resource "vault_identity_entity" "a" {
+  name     = "%[1]s-a"
+  policies = ["default"]
+  metadata = {
+    team = "%[1]s"
+  }
+}
+
+resource "vault_identity_entity" "b" {
+  name     = "%[1]s-b"
+  policies = ["%[1]s"]
+  metadata = {
+    team = "%[1]s"
+  }
+}
+
+resource "vault_identity_entity" "c" {
+  name     = "%[1]s-c"
+  disabled = true
+  metadata = {
+    team = "%[1]s"
+  }
+}
+
+resource "vault_identity_entity_alias" "a" {
+  name           = "%[1]s-a"
+  mount_accessor = vault_auth_backend.test.accessor
+  canonical_id   = vault_identity_entity.a.id
+}
+
+data "vault_identity_entities" "test" {
+  disabled = false
+  metadata = {
+    team = "%[1]s"
+  }
+
+  depends_on = [
+    vault_identity_entity.a,
+    vault_identity_entity.b,
+    vault_identity_entity.c,
+  ]
+}
+
+data "vault_identity_entities" "test_policy" {
+  policies = ["%[1]s"]
+  metadata = {
+    team = "%[1]s"
+  }
+
+  depends_on = [vault_identity_entity.b]
+}
+
+data "vault_identity_entities" "test_disabled" {
+  disabled = true
+  metadata = {
+    team = "%[1]s"
+  }
+
+  depends_on = [vault_identity_entity.c]
+}
+
+data "vault_identity_entities" "test_alias" {
+  alias_mount_accessor = vault_auth_backend.test.accessor
+
+  depends_on = [vault_identity_entity_alias.a]
+}
+`, name)
+}
+
+func TestIdentitySearchFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            map[string]interface{}
+		metadata       map[string]interface{}
+		policies       []interface{}
+		mountAccessors []string
+		disabled       bool
+		want           bool
+	}{
+		{
+			name:     "no-filters",
+			raw:      map[string]interface{}{},
+			disabled: true,
+			want:     true,
+		},
+		{
+			name: "metadata",
+			raw: map[string]interface{}{
+				consts.FieldMetadata: map[string]interface{}{"team": "payments"},
+			},
+			metadata: map[string]interface{}{"team": "payments", "env": "prod"},
+			want:     true,
+		},
+		{
+			name: "metadata-mismatch",
+			raw: map[string]interface{}{
+				consts.FieldMetadata: map[string]interface{}{"team": "payments"},
+			},
+			metadata: map[string]interface{}{"team": "billing"},
+			want:     false,
+		},
+		{
+			name: "policies",
+			raw: map[string]interface{}{
+				consts.FieldPolicies: []interface{}{"a", "b"},
+			},
+			policies: []interface{}{"b", "c", "a"},
+			want:     true,
+		},
+		{
+			name: "policies-missing",
+			raw: map[string]interface{}{
+				consts.FieldPolicies: []interface{}{"a", "b"},
+			},
+			policies: []interface{}{"a"},
+			want:     false,
+		},
+		{
+			name: "alias-mount-accessor",
+			raw: map[string]interface{}{
+				consts.FieldAliasMountAccessor: "auth_userpass_1",
+			},
+			mountAccessors: []string{"auth_approle_1", "auth_userpass_1"},
+			want:           true,
+		},
+		{
+			name: "alias-mount-accessor-mismatch",
+			raw: map[string]interface{}{
+				consts.FieldAliasMountAccessor: "auth_userpass_1",
+			},
+			want: false,
+		},
+		{
+			name: "enabled-only",
+			raw: map[string]interface{}{
+				consts.FieldDisabled: false,
+			},
+			disabled: true,
+			want:     false,
+		},
+		{
+			name: "disabled-only",
+			raw: map[string]interface{}{
+				consts.FieldDisabled: true,
+			},
+			disabled: true,
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, identityEntitiesDataSource().Schema, tt.raw)
+			f := newIdentityEntitiesSearchFilter(d)
+
+			if got := f.match(tt.metadata, tt.policies, tt.mountAccessors, tt.disabled); got != tt.want {
+				t.Errorf("match() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
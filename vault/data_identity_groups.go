@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/group"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const identityGroupIDListPath = group.IdentityGroupPath + "/id"
+
+func identityGroupsDataSource() *schema.Resource {
+	s := identitySearchSchema("groups")
+	s[consts.FieldGroups] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The matching groups, sorted by name.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				consts.FieldID: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the group.",
+				},
+				consts.FieldName: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the group.",
+				},
+				consts.FieldType: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Type of the group, internal or external.",
+				},
+				consts.FieldPolicies: {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Policies of the group.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				consts.FieldMetadata: {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Description: "Metadata of the group.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				consts.FieldMemberEntityIDs: {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "IDs of the member entities of the group.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				consts.FieldMemberGroupIDs: {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "IDs of the member groups of the group.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(identityGroupsDataSourceRead),
+		Schema:      s,
+	}
+}
+
+func identityGroupsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	filter := newIdentitySearchFilter(d)
+
+	log.Printf("[DEBUG] Listing IdentityGroups from %q", identityGroupIDListPath)
+	resp, err := entity.ReadAll(ctx, client, identityGroupIDListPath, d.Get(consts.FieldMaxParallelReads).(int))
+	if err != nil {
+		return diag.Errorf("error reading IdentityGroups: %s", err)
+	}
+
+	var groups []map[string]interface{}
+	for _, data := range resp {
+		metadata, _ := data[consts.FieldMetadata].(map[string]interface{})
+		policies, _ := data[consts.FieldPolicies].([]interface{})
+
+		// only external groups have an alias
+		var accessors []string
+		if alias, ok := data["alias"].(map[string]interface{}); ok {
+			if v, ok := alias[consts.FieldMountAccessor].(string); ok && v != "" {
+				accessors = append(accessors, v)
+			}
+		}
+
+		if !filter.match(metadata, policies, accessors, false) {
+			continue
+		}
+
+		id, _ := data[consts.FieldID].(string)
+		name, _ := data[consts.FieldName].(string)
+		groups = append(groups, map[string]interface{}{
+			consts.FieldID:              id,
+			consts.FieldName:            name,
+			consts.FieldType:            data[consts.FieldType],
+			consts.FieldPolicies:        policies,
+			consts.FieldMetadata:        metadata,
+			consts.FieldMemberEntityIDs: data[consts.FieldMemberEntityIDs],
+			consts.FieldMemberGroupIDs:  data[consts.FieldMemberGroupIDs],
+		})
+	}
+	log.Printf("[DEBUG] Found %d matching IdentityGroups", len(groups))
+
+	d.SetId(identityGroupIDListPath)
+
+	return setIdentitySearchResults(d, consts.FieldGroups, groups)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourceIdentityGroups(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-group")
+	dataSourceName := "data.vault_identity_groups.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceIdentityGroupsConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.0", name+"-external"),
+					resource.TestCheckResourceAttr(dataSourceName, "names.1", name+"-internal"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.1", "vault_identity_group.internal", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.0.type", "external"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.1.type", "internal"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.1.metadata.team", name),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.1.member_entity_ids.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName+"_policy", "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName+"_policy", "names.0", name+"-internal"),
+					resource.TestCheckResourceAttr(dataSourceName+"_alias", "names.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName+"_alias", "names.0", name+"-external"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceIdentityGroupsConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%[1]s"
+}
+
+resource "vault_identity_entity" "test" {
+  name = "%[1]s"
+}
+
+resource "vault_identity_group" "internal" {
+  name              = "%[1]s-internal"
+  type              = "internal"
+  policies          = ["%[1]s"]
+  member_entity_ids = [vault_identity_entity.test.id]
+  metadata = {
+    team = "%[1]s"
+  }
+}
+
+resource "vault_identity_group" "external" {
+  name = "%[1]s-external"
+  type = "external"
+  metadata = {
+    team = "%[1]s"
+  }
+}
+
+resource "vault_identity_group_alias" "external" {
+  name           = "%[1]s"
+  mount_accessor = vault_auth_backend.test.accessor
+  canonical_id   = vault_identity_group.external.id
+}
+
+data "vault_identity_groups" "test" {
+  metadata = {
+    team = "%[1]s"
+  }
+
+  depends_on = [
+    vault_identity_group.internal,
+    vault_identity_group.external,
+  ]
+}
+
+data "vault_identity_groups" "test_policy" {
+  policies = ["%[1]s"]
+
+  depends_on = [vault_identity_group.internal]
+}
+
+data "vault_identity_groups" "test_alias" {
+  alias_mount_accessor = vault_auth_backend.test.accessor
+
+  depends_on = [vault_identity_group_alias.external]
+}
+`, name)
+}
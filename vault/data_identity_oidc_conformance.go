@@ -0,0 +1,849 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+const (
+	oidcCheckClient       = "client"
+	oidcCheckDiscovery    = "discovery"
+	oidcCheckJWKS         = "jwks"
+	oidcCheckAuthorize    = "authorize"
+	oidcCheckToken        = "token"
+	oidcCheckSignature    = "id_token_signature"
+	oidcCheckAlgorithm    = "id_token_algorithm"
+	oidcCheckIDTokenClaim = "id_token_claims"
+	oidcCheckUserinfo     = "userinfo"
+
+	oidcClaimSourceIDToken  = "id_token"
+	oidcClaimSourceUserinfo = "userinfo"
+)
+
+// oidcTemplatePlaceholderRegex matches the identity template placeholders of
+// a scope template, which are not valid JSON until they are rendered.
+var oidcTemplatePlaceholderRegex = regexp.MustCompile(`{{[^}]*}}`)
+
+func identityOIDCConformanceDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readOIDCConformanceDataSource),
+		Schema: map[string]*schema.Schema{
+			consts.FieldName: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the OIDC provider to check.",
+			},
+			consts.FieldClientName: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the OIDC client to run the authorization code flow with.",
+			},
+			consts.FieldEntityToken: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Vault token of an entity that is assigned to the client, used to authorize the request.",
+			},
+			consts.FieldScopes: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "Scopes to request in addition to openid. " +
+					"Defaults to all the scopes supported by the provider.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldRedirectURI: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Redirect URI to request the code for. Defaults to the first redirect URI of the client.",
+			},
+			consts.FieldExpectedAlgorithm: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Signing algorithm the ID token must use.",
+			},
+			consts.FieldExpectedClaims: {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: "Expected values of the ID token claims. Values that are not strings must be " +
+					"JSON encoded.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldPassed: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether all the checks and claims passed.",
+			},
+			consts.FieldChecks: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results of the steps of the flow, in order. The flow stops at the first failed step.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the check.",
+						},
+						consts.FieldPassed: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the check passed.",
+						},
+						consts.FieldMessage: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Details of the result.",
+						},
+					},
+				},
+			},
+			consts.FieldClaims: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results for the claims of each scope template and the expected claims.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the claim.",
+						},
+						consts.FieldScope: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Scope whose template renders the claim, empty for other expected claims.",
+						},
+						consts.FieldSource: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Where the claim was found, id_token or userinfo.",
+						},
+						consts.FieldValue: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Value of the claim, JSON encoded if it is not a string.",
+						},
+						consts.FieldExpected: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expected value of the claim, if any.",
+						},
+						consts.FieldPassed: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the claim is present and has the expected value.",
+						},
+						consts.FieldMessage: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Details of the result.",
+						},
+					},
+				},
+			},
+			consts.FieldIDTokenClaimsJSON: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Claims of the ID token, serialized in JSON format.",
+			},
+		},
+	}
+}
+
+type oidcConformanceCheck struct {
+	name    string
+	passed  bool
+	message string
+}
+
+type oidcConformanceClaim struct {
+	name     string
+	scope    string
+	source   string
+	value    string
+	expected string
+	passed   bool
+	message  string
+}
+
+// oidcConformanceReport collects the results of an authorization code flow
+// against a Vault OIDC provider.
+type oidcConformanceReport struct {
+	checks        []*oidcConformanceCheck
+	claims        []*oidcConformanceClaim
+	idTokenClaims map[string]interface{}
+}
+
+func (r *oidcConformanceReport) pass(name, format string, a ...interface{}) {
+	r.checks = append(r.checks, &oidcConformanceCheck{name: name, passed: true, message: fmt.Sprintf(format, a...)})
+}
+
+func (r *oidcConformanceReport) fail(name, format string, a ...interface{}) {
+	r.checks = append(r.checks, &oidcConformanceCheck{name: name, message: fmt.Sprintf(format, a...)})
+}
+
+func (r *oidcConformanceReport) passed() bool {
+	for _, c := range r.checks {
+		if !c.passed {
+			return false
+		}
+	}
+	for _, c := range r.claims {
+		if !c.passed {
+			return false
+		}
+	}
+	return true
+}
+
+// oidcConformanceParams configures the flow run by runOIDCConformance.
+type oidcConformanceParams struct {
+	provider          string
+	clientName        string
+	entityToken       string
+	scopes            []string
+	redirectURI       string
+	expectedAlgorithm string
+	expectedClaims    map[string]string
+}
+
+func readOIDCConformanceDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	params := &oidcConformanceParams{
+		provider:          d.Get(consts.FieldName).(string),
+		clientName:        d.Get(consts.FieldClientName).(string),
+		entityToken:       d.Get(consts.FieldEntityToken).(string),
+		redirectURI:       d.Get(consts.FieldRedirectURI).(string),
+		expectedAlgorithm: d.Get(consts.FieldExpectedAlgorithm).(string),
+		expectedClaims:    map[string]string{},
+	}
+	if v, ok := d.GetOk(consts.FieldScopes); ok {
+		for _, s := range v.([]interface{}) {
+			params.scopes = append(params.scopes, s.(string))
+		}
+	}
+	for k, v := range d.Get(consts.FieldExpectedClaims).(map[string]interface{}) {
+		params.expectedClaims[k] = v.(string)
+	}
+
+	report, err := runOIDCConformance(ctx, client, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var checks []map[string]interface{}
+	for _, c := range report.checks {
+		checks = append(checks, map[string]interface{}{
+			consts.FieldName:    c.name,
+			consts.FieldPassed:  c.passed,
+			consts.FieldMessage: c.message,
+		})
+	}
+
+	var claims []map[string]interface{}
+	for _, c := range report.claims {
+		claims = append(claims, map[string]interface{}{
+			consts.FieldName:     c.name,
+			consts.FieldScope:    c.scope,
+			consts.FieldSource:   c.source,
+			consts.FieldValue:    c.value,
+			consts.FieldExpected: c.expected,
+			consts.FieldPassed:   c.passed,
+			consts.FieldMessage:  c.message,
+		})
+	}
+
+	var claimsJSON string
+	if report.idTokenClaims != nil {
+		b, err := json.Marshal(report.idTokenClaims)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		claimsJSON = string(b)
+	}
+
+	d.SetId(getOIDCProviderPath(params.provider))
+
+	fields := map[string]interface{}{
+		consts.FieldPassed:            report.passed(),
+		consts.FieldChecks:            checks,
+		consts.FieldClaims:            claims,
+		consts.FieldIDTokenClaimsJSON: claimsJSON,
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// runOIDCConformance runs an authorization code flow with PKCE against the
+// OIDC provider and reports the result of each step. Failures of the flow are
+// recorded in the report, an error is only returned if the provider or client
+// configuration cannot be read.
+func runOIDCConformance(ctx context.Context, client *api.Client, params *oidcConformanceParams) (*oidcConformanceReport, error) {
+	report := &oidcConformanceReport{}
+
+	providerPath := getOIDCProviderPath(params.provider)
+	providerResp, err := client.Logical().ReadWithContext(ctx, providerPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OIDC provider %q: %w", providerPath, err)
+	}
+	if providerResp == nil {
+		return nil, fmt.Errorf("OIDC provider %q not found", providerPath)
+	}
+
+	clientPath := getOIDCClientPath(params.clientName)
+	clientResp, err := client.Logical().ReadWithContext(ctx, clientPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading OIDC client %q: %w", clientPath, err)
+	}
+	if clientResp == nil {
+		return nil, fmt.Errorf("OIDC client %q not found", clientPath)
+	}
+
+	clientID, _ := clientResp.Data[consts.FieldClientID].(string)
+	clientSecret, _ := clientResp.Data["client_secret"].(string)
+
+	redirectURI := params.redirectURI
+	if redirectURI == "" {
+		if uris, ok := clientResp.Data["redirect_uris"].([]interface{}); ok && len(uris) > 0 {
+			redirectURI, _ = uris[0].(string)
+		}
+	}
+
+	scopes := params.scopes
+	if len(scopes) == 0 {
+		if v, ok := providerResp.Data["scopes_supported"].([]interface{}); ok {
+			for _, s := range v {
+				scopes = append(scopes, s.(string))
+			}
+		}
+	}
+
+	if !oidcClientAllowed(providerResp.Data["allowed_client_ids"], clientID) {
+		report.fail(oidcCheckClient, "client %q is not in the allowed_client_ids of provider %q", params.clientName, params.provider)
+		return report, nil
+	}
+	if redirectURI == "" {
+		report.fail(oidcCheckClient, "client %q has no redirect URIs", params.clientName)
+		return report, nil
+	}
+	report.pass(oidcCheckClient, "client %q is allowed by provider %q", params.clientName, params.provider)
+
+	// the discovery, token and userinfo endpoints do not take a Vault token
+	anonClient, err := client.CloneWithHeaders()
+	if err != nil {
+		return nil, err
+	}
+	anonClient.ClearToken()
+
+	entityClient, err := client.CloneWithHeaders()
+	if err != nil {
+		return nil, err
+	}
+	entityClient.SetToken(params.entityToken)
+
+	basePath := "/v1/" + providerPath
+
+	// discovery
+	var discovery struct {
+		Issuer                 string   `json:"issuer"`
+		JWKSURI                string   `json:"jwks_uri"`
+		AuthorizationEndpoint  string   `json:"authorization_endpoint"`
+		TokenEndpoint          string   `json:"token_endpoint"`
+		UserinfoEndpoint       string   `json:"userinfo_endpoint"`
+		ResponseTypesSupported []string `json:"response_types_supported"`
+		ScopesSupported        []string `json:"scopes_supported"`
+		IDTokenSigningAlgs     []string `json:"id_token_signing_alg_values_supported"`
+	}
+	if status, body, err := oidcConformanceRequest(ctx, anonClient, http.MethodGet,
+		basePath+"/.well-known/openid-configuration", nil, nil); err != nil {
+		report.fail(oidcCheckDiscovery, "request failed: %s", err)
+		return report, nil
+	} else if status != http.StatusOK {
+		report.fail(oidcCheckDiscovery, "unexpected status %d: %s", status, body)
+		return report, nil
+	} else if err := json.Unmarshal(body, &discovery); err != nil {
+		report.fail(oidcCheckDiscovery, "invalid discovery document: %s", err)
+		return report, nil
+	}
+
+	var problems []string
+	if discovery.Issuer == "" {
+		problems = append(problems, "issuer is not set")
+	}
+	for _, endpoint := range [][2]string{
+		{"jwks_uri", discovery.JWKSURI},
+		{"token_endpoint", discovery.TokenEndpoint},
+		{"userinfo_endpoint", discovery.UserinfoEndpoint},
+	} {
+		if !strings.HasPrefix(endpoint[1], discovery.Issuer+"/") {
+			problems = append(problems, fmt.Sprintf("%s %q is not below the issuer", endpoint[0], endpoint[1]))
+		}
+	}
+	if discovery.AuthorizationEndpoint == "" {
+		problems = append(problems, "authorization_endpoint is not set")
+	}
+	if !slices.Contains(discovery.ResponseTypesSupported, "code") {
+		problems = append(problems, "response type code is not supported")
+	}
+	if !slices.Contains(discovery.ScopesSupported, "openid") {
+		problems = append(problems, "scope openid is not supported")
+	}
+	if len(discovery.IDTokenSigningAlgs) == 0 {
+		problems = append(problems, "no ID token signing algorithms are supported")
+	}
+	if len(problems) > 0 {
+		report.fail(oidcCheckDiscovery, "%s", strings.Join(problems, ", "))
+		return report, nil
+	}
+	report.pass(oidcCheckDiscovery, "issuer %q", discovery.Issuer)
+
+	// jwks
+	var jwks jose.JSONWebKeySet
+	if status, body, err := oidcConformanceRequest(ctx, anonClient, http.MethodGet,
+		basePath+"/.well-known/keys", nil, nil); err != nil {
+		report.fail(oidcCheckJWKS, "request failed: %s", err)
+		return report, nil
+	} else if status != http.StatusOK {
+		report.fail(oidcCheckJWKS, "unexpected status %d: %s", status, body)
+		return report, nil
+	} else if err := json.Unmarshal(body, &jwks); err != nil {
+		report.fail(oidcCheckJWKS, "invalid key set: %s", err)
+		return report, nil
+	}
+	if len(jwks.Keys) == 0 {
+		report.fail(oidcCheckJWKS, "the key set is empty")
+		return report, nil
+	}
+	report.pass(oidcCheckJWKS, "%d keys", len(jwks.Keys))
+
+	// authorize
+	state, err := oidcRandomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidcRandomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidcRandomString()
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	requestScopes := []string{"openid"}
+	for _, s := range scopes {
+		if s != "openid" {
+			requestScopes = append(requestScopes, s)
+		}
+	}
+
+	var authorize struct {
+		Code             string `json:"code"`
+		State            string `json:"state"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	authorizeParams := url.Values{
+		"client_id":             {clientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(requestScopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if status, body, err := oidcConformanceRequest(ctx, entityClient, http.MethodGet,
+		basePath+"/authorize", authorizeParams, nil); err != nil {
+		report.fail(oidcCheckAuthorize, "request failed: %s", err)
+		return report, nil
+	} else if err := json.Unmarshal(body, &authorize); err != nil {
+		report.fail(oidcCheckAuthorize, "unexpected response with status %d: %s", status, body)
+		return report, nil
+	}
+	switch {
+	case authorize.Error != "":
+		report.fail(oidcCheckAuthorize, "%s: %s", authorize.Error, authorize.ErrorDescription)
+		return report, nil
+	case authorize.Code == "":
+		report.fail(oidcCheckAuthorize, "no authorization code returned")
+		return report, nil
+	case authorize.State != state:
+		report.fail(oidcCheckAuthorize, "state %q does not match the requested state", authorize.State)
+		return report, nil
+	}
+	report.pass(oidcCheckAuthorize, "scopes %q", strings.Join(requestScopes, " "))
+
+	// token
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		IDToken          string `json:"id_token"`
+		TokenType        string `json:"token_type"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {authorize.Code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	}
+	header := http.Header{
+		"Content-Type": {"application/x-www-form-urlencoded"},
+	}
+	if clientSecret != "" {
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString(
+			[]byte(url.QueryEscape(clientID)+":"+url.QueryEscape(clientSecret))))
+	} else {
+		form.Set("client_id", clientID)
+	}
+	if status, body, err := oidcConformanceRequest(ctx, anonClient, http.MethodPost,
+		basePath+"/token", nil, &oidcConformanceBody{header: header, body: []byte(form.Encode())}); err != nil {
+		report.fail(oidcCheckToken, "request failed: %s", err)
+		return report, nil
+	} else if err := json.Unmarshal(body, &token); err != nil {
+		report.fail(oidcCheckToken, "unexpected response with status %d: %s", status, body)
+		return report, nil
+	}
+	switch {
+	case token.Error != "":
+		report.fail(oidcCheckToken, "%s: %s", token.Error, token.ErrorDescription)
+		return report, nil
+	case token.IDToken == "" || token.AccessToken == "":
+		report.fail(oidcCheckToken, "the response does not contain an ID token and an access token")
+		return report, nil
+	case !strings.EqualFold(token.TokenType, "Bearer"):
+		report.fail(oidcCheckToken, "unexpected token type %q", token.TokenType)
+		return report, nil
+	}
+	report.pass(oidcCheckToken, "issued an ID token and an access token")
+
+	// id token signature and algorithm
+	idTokenClaims, ok := verifyOIDCIDToken(report, token.IDToken, &jwks, discovery.IDTokenSigningAlgs, params.expectedAlgorithm)
+	if !ok {
+		return report, nil
+	}
+	report.idTokenClaims = idTokenClaims
+
+	// standard id token claims
+	problems = checkOIDCIDTokenClaims(idTokenClaims, discovery.Issuer, clientID, nonce, time.Now())
+	if len(problems) > 0 {
+		report.fail(oidcCheckIDTokenClaim, "%s", strings.Join(problems, ", "))
+		return report, nil
+	}
+	report.pass(oidcCheckIDTokenClaim, "subject %q", idTokenClaims["sub"])
+
+	// userinfo
+	var userinfo map[string]interface{}
+	if status, body, err := oidcConformanceRequest(ctx, anonClient, http.MethodGet, basePath+"/userinfo", nil,
+		&oidcConformanceBody{header: http.Header{"Authorization": {"Bearer " + token.AccessToken}}}); err != nil {
+		report.fail(oidcCheckUserinfo, "request failed: %s", err)
+		return report, nil
+	} else if status != http.StatusOK {
+		report.fail(oidcCheckUserinfo, "unexpected status %d: %s", status, body)
+		return report, nil
+	} else if err := decodeJSONWithNumbers(body, &userinfo); err != nil {
+		report.fail(oidcCheckUserinfo, "invalid response: %s", err)
+		return report, nil
+	}
+	if userinfo["sub"] != idTokenClaims["sub"] {
+		report.fail(oidcCheckUserinfo, "subject %q does not match the ID token subject %q", userinfo["sub"], idTokenClaims["sub"])
+		return report, nil
+	}
+	report.pass(oidcCheckUserinfo, "%d claims", len(userinfo))
+
+	// claims rendered from the scope templates
+	seen := map[string]bool{}
+	for _, scope := range requestScopes[1:] {
+		resp, err := client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/%s", identityOIDCScopePathPrefix, scope))
+		if err != nil {
+			return nil, fmt.Errorf("error reading OIDC scope %q: %w", scope, err)
+		}
+		if resp == nil {
+			report.claims = append(report.claims, &oidcConformanceClaim{
+				scope:   scope,
+				message: fmt.Sprintf("scope %q not found", scope),
+			})
+			continue
+		}
+
+		template, _ := resp.Data["template"].(string)
+		names, err := oidcScopeTemplateClaims(template)
+		if err != nil {
+			report.claims = append(report.claims, &oidcConformanceClaim{
+				scope:   scope,
+				message: err.Error(),
+			})
+			continue
+		}
+
+		for _, name := range names {
+			seen[name] = true
+			report.claims = append(report.claims, checkOIDCClaim(name, scope, idTokenClaims, userinfo, params.expectedClaims))
+		}
+	}
+
+	var other []string
+	for name := range params.expectedClaims {
+		if !seen[name] {
+			other = append(other, name)
+		}
+	}
+	sort.Strings(other)
+	for _, name := range other {
+		report.claims = append(report.claims, checkOIDCClaim(name, "", idTokenClaims, userinfo, params.expectedClaims))
+	}
+
+	return report, nil
+}
+
+// verifyOIDCIDToken verifies the signature of the ID token with the provider's
+// key set and returns its claims. The results are recorded in the report.
+func verifyOIDCIDToken(report *oidcConformanceReport, idToken string, jwks *jose.JSONWebKeySet,
+	supportedAlgs []string, expectedAlg string,
+) (map[string]interface{}, bool) {
+	jws, err := jose.ParseSigned(idToken)
+	if err != nil {
+		report.fail(oidcCheckSignature, "invalid ID token: %s", err)
+		return nil, false
+	}
+	if len(jws.Signatures) != 1 {
+		report.fail(oidcCheckSignature, "expected 1 signature, got %d", len(jws.Signatures))
+		return nil, false
+	}
+
+	h := jws.Signatures[0].Header
+	keys := jwks.Key(h.KeyID)
+	if len(keys) == 0 {
+		report.fail(oidcCheckSignature, "key %q is not in the key set", h.KeyID)
+		return nil, false
+	}
+
+	payload, err := jws.Verify(&keys[0])
+	if err != nil {
+		report.fail(oidcCheckSignature, "signature verification with key %q failed: %s", h.KeyID, err)
+		return nil, false
+	}
+	report.pass(oidcCheckSignature, "signed with key %q", h.KeyID)
+
+	switch {
+	case !slices.Contains(supportedAlgs, h.Algorithm):
+		report.fail(oidcCheckAlgorithm, "algorithm %q is not in id_token_signing_alg_values_supported %v", h.Algorithm, supportedAlgs)
+		return nil, false
+	case keys[0].Algorithm != "" && keys[0].Algorithm != h.Algorithm:
+		report.fail(oidcCheckAlgorithm, "algorithm %q does not match the algorithm %q of key %q", h.Algorithm, keys[0].Algorithm, h.KeyID)
+		return nil, false
+	case expectedAlg != "" && expectedAlg != h.Algorithm:
+		report.fail(oidcCheckAlgorithm, "algorithm %q does not match the expected algorithm %q", h.Algorithm, expectedAlg)
+		return nil, false
+	}
+	report.pass(oidcCheckAlgorithm, "%s", h.Algorithm)
+
+	var claims map[string]interface{}
+	if err := decodeJSONWithNumbers(payload, &claims); err != nil {
+		report.fail(oidcCheckIDTokenClaim, "invalid claims: %s", err)
+		return nil, false
+	}
+
+	return claims, true
+}
+
+// checkOIDCIDTokenClaims returns the problems with the standard claims of an
+// ID token.
+func checkOIDCIDTokenClaims(claims map[string]interface{}, issuer, clientID, nonce string, now time.Time) []string {
+	var problems []string
+	if claims["iss"] != issuer {
+		problems = append(problems, fmt.Sprintf("iss %q does not match the issuer %q", claims["iss"], issuer))
+	}
+
+	switch aud := claims["aud"].(type) {
+	case string:
+		if aud != clientID {
+			problems = append(problems, fmt.Sprintf("aud %q does not match the client ID", aud))
+		}
+	case []interface{}:
+		if ok, _ := util.SliceHasElement(aud, clientID); !ok {
+			problems = append(problems, fmt.Sprintf("aud %v does not contain the client ID", aud))
+		}
+	default:
+		problems = append(problems, "aud is not set")
+	}
+
+	if claims["nonce"] != nonce {
+		problems = append(problems, "nonce does not match the requested nonce")
+	}
+
+	if sub, _ := claims["sub"].(string); sub == "" {
+		problems = append(problems, "sub is not set")
+	}
+
+	if exp := jwtNumericDate(claims["exp"]); exp == 0 {
+		problems = append(problems, "exp is not set")
+	} else if !now.Before(time.Unix(exp, 0)) {
+		problems = append(problems, fmt.Sprintf("the token expired at %s", time.Unix(exp, 0).UTC().Format(time.RFC3339)))
+	}
+
+	return problems
+}
+
+// checkOIDCClaim looks the claim up in the ID token, then in the userinfo
+// response, and compares it to its expected value.
+func checkOIDCClaim(name, scope string, idToken, userinfo map[string]interface{}, expected map[string]string) *oidcConformanceClaim {
+	c := &oidcConformanceClaim{
+		name:     name,
+		scope:    scope,
+		expected: expected[name],
+	}
+
+	v, ok := idToken[name]
+	if ok {
+		c.source = oidcClaimSourceIDToken
+	} else if v, ok = userinfo[name]; ok {
+		c.source = oidcClaimSourceUserinfo
+	} else {
+		c.message = "the claim is not in the ID token or the userinfo response"
+		return c
+	}
+
+	flat, err := flattenJWTClaims(map[string]interface{}{name: v})
+	if err != nil {
+		c.message = err.Error()
+		return c
+	}
+	c.value = flat[name]
+
+	if _, ok := expected[name]; ok && c.value != c.expected {
+		c.message = fmt.Sprintf("expected %q, got %q", c.expected, c.value)
+		return c
+	}
+
+	c.passed = true
+	return c
+}
+
+// oidcScopeTemplateClaims returns the sorted top level claim names of a scope
+// template.
+func oidcScopeTemplateClaims(template string) ([]string, error) {
+	if template == "" {
+		return nil, nil
+	}
+
+	if b, err := base64.StdEncoding.DecodeString(template); err == nil {
+		template = string(b)
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal([]byte(oidcTemplatePlaceholderRegex.ReplaceAllString(template, "null")), &v); err != nil {
+		return nil, fmt.Errorf("invalid scope template: %w", err)
+	}
+
+	var names []string
+	for k := range v {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func oidcClientAllowed(allowed interface{}, clientID string) bool {
+	ids, _ := allowed.([]interface{})
+	for _, id := range ids {
+		if id == "*" || id == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+type oidcConformanceBody struct {
+	header http.Header
+	body   []byte
+}
+
+// oidcConformanceRequest performs a raw request against the OIDC provider and
+// returns the status and body of the response, error statuses are not
+// returned as an error.
+func oidcConformanceRequest(ctx context.Context, client *api.Client, method, path string, params url.Values,
+	body *oidcConformanceBody,
+) (int, []byte, error) {
+	r := client.NewRequest(method, path)
+	r.Params = params
+	if body != nil {
+		if r.Headers == nil {
+			r.Headers = http.Header{}
+		}
+		for k, v := range body.header {
+			r.Headers[k] = v
+		}
+		r.BodyBytes = body.body
+	}
+
+	log.Printf("[DEBUG] Performing %s at %q", method, path)
+	resp, err := client.RawRequestWithContext(ctx, r)
+	if resp == nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, b, nil
+}
+
+func oidcRandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeJSONWithNumbers(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
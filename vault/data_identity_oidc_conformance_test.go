@@ -0,0 +1,462 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourceIdentityOIDCConformance(t *testing.T) {
+	testutil.SkipTestAcc(t)
+	testutil.TestAccPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-oidc-conformance")
+	client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+
+	if err := client.Sys().EnableAuthWithOptions(name, &api.EnableAuthOptions{Type: consts.AuthMethodUserpass}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := client.Sys().DisableAuth(name); err != nil {
+			t.Logf("failed to disable auth %q: %s", name, err)
+		}
+	})
+
+	userPath := fmt.Sprintf("auth/%s/users/%s", name, name)
+	if _, err := client.Logical().Write(userPath, map[string]interface{}{
+		"password": name,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Logical().Write(fmt.Sprintf("auth/%s/login/%s", name, name), map[string]interface{}{
+		"password": name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, entityID := resp.Auth.ClientToken, resp.Auth.EntityID
+	t.Cleanup(func() {
+		if _, err := client.Logical().Delete("identity/entity/id/" + entityID); err != nil {
+			t.Logf("failed to delete entity %q: %s", entityID, err)
+		}
+	})
+
+	dataSourceName := "data.vault_identity_oidc_conformance.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck: func() {
+			SkipIfAPIVersionLT(t, testProvider.Meta(), provider.VaultVersion110)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceIdentityOIDCConformanceConfig(name, entityID, token, "RS256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldPassed, "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.#", "9"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.0.name", oidcCheckClient),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.8.name", oidcCheckUserinfo),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.8.passed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.0.name", "team"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.0.scope", name),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.0.source", oidcClaimSourceIDToken),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.0.value", "payments"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.0.passed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.1.name", "sub"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.1.scope", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.1.value", entityID),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.1.passed", "true"),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldIDTokenClaimsJSON),
+				),
+			},
+			{
+				Config: testDataSourceIdentityOIDCConformanceConfig(name, entityID, token, "ES256"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldPassed, "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.#", "7"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.6.name", oidcCheckAlgorithm),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.6.passed", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceIdentityOIDCConformanceConfig(name, entityID, token, algorithm string) string {
+	return fmt.Sprintf(`
+resource "vault_identity_oidc_key" "test" {
+  name               = "%[1]s"
+  algorithm          = "RS256"
+  allowed_client_ids = ["*"]
+}
+
+resource "vault_identity_oidc_assignment" "test" {
+  name       = "%[1]s"
+  entity_ids = ["%[2]s"]
+}
+
+resource "vault_identity_oidc_client" "test" {
+  name          = "%[1]s"
+  key           = vault_identity_oidc_key.test.name
+  redirect_uris = ["http://127.0.0.1:8251/callback"]
+  assignments   = [vault_identity_oidc_assignment.test.name]
+}
+
+resource "vault_identity_oidc_scope" "test" {
+  name     = "%[1]s"
+  template = jsonencode({
+    team = "payments"
+  })
+}
+
+resource "vault_identity_oidc_provider" "test" {
+  name               = "%[1]s"
+  allowed_client_ids = [vault_identity_oidc_client.test.client_id]
+  scopes_supported   = [vault_identity_oidc_scope.test.name]
+}
+
+data "vault_identity_oidc_conformance" "test" {
+  name               = vault_identity_oidc_provider.test.name
+  client_name        = vault_identity_oidc_client.test.name
+  entity_token       = "%[3]s"
+  expected_algorithm = "%[4]s"
+  expected_claims = {
+    team = "payments"
+    sub  = "%[2]s"
+  }
+}
+`, name, entityID, token, algorithm)
+}
+
+func TestOIDCScopeTemplateClaims(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "empty",
+			template: "",
+		},
+		{
+			name:     "placeholders",
+			template: `{"groups": {{identity.entity.groups.names}}, "contact": {"email": {{identity.entity.metadata.email}}}, "team": "payments"}`,
+			expected: []string{"contact", "groups", "team"},
+		},
+		{
+			name:     "base64",
+			template: base64.StdEncoding.EncodeToString([]byte(`{"username": {{identity.entity.name}}}`)),
+			expected: []string{"username"},
+		},
+		{
+			name:      "invalid",
+			template:  `{"groups": `,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := oidcScopeTemplateClaims(tt.template)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Errorf("expected claims %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVerifyOIDCIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(t *testing.T, key *rsa.PrivateKey, kid string) string {
+		t.Helper()
+
+		signer, err := jose.NewSigner(jose.SigningKey{
+			Algorithm: jose.RS256,
+			Key:       jose.JSONWebKey{Key: key, KeyID: kid},
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jws, err := signer.Sign([]byte(`{"sub":"entity-1","exp":1700086400}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := jws.CompactSerialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return token
+	}
+
+	jwks := &jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key-1", Algorithm: "RS256", Use: "sig"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		supportedAlgs []string
+		expectedAlg   string
+		expectedCheck string
+		expectOK      bool
+	}{
+		{
+			name:          "valid",
+			token:         sign(t, key, "key-1"),
+			supportedAlgs: []string{"RS256"},
+			expectedAlg:   "RS256",
+			expectOK:      true,
+		},
+		{
+			name:          "unknown-key",
+			token:         sign(t, key, "key-2"),
+			supportedAlgs: []string{"RS256"},
+			expectedCheck: oidcCheckSignature,
+		},
+		{
+			name:          "bad-signature",
+			token:         sign(t, otherKey, "key-1"),
+			supportedAlgs: []string{"RS256"},
+			expectedCheck: oidcCheckSignature,
+		},
+		{
+			name:          "invalid",
+			token:         "not-a-jwt",
+			supportedAlgs: []string{"RS256"},
+			expectedCheck: oidcCheckSignature,
+		},
+		{
+			name:          "unsupported-algorithm",
+			token:         sign(t, key, "key-1"),
+			supportedAlgs: []string{"ES256"},
+			expectedCheck: oidcCheckAlgorithm,
+		},
+		{
+			name:          "unexpected-algorithm",
+			token:         sign(t, key, "key-1"),
+			supportedAlgs: []string{"RS256", "ES256"},
+			expectedAlg:   "ES256",
+			expectedCheck: oidcCheckAlgorithm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &oidcConformanceReport{}
+			claims, ok := verifyOIDCIDToken(report, tt.token, jwks, tt.supportedAlgs, tt.expectedAlg)
+			if ok != tt.expectOK {
+				t.Fatalf("expected ok %t, got %t, checks %v", tt.expectOK, ok, report.checks)
+			}
+
+			if tt.expectOK {
+				if !report.passed() {
+					t.Errorf("expected all checks to pass, got %v", report.checks)
+				}
+				if claims["sub"] != "entity-1" || jwtNumericDate(claims["exp"]) != 1700086400 {
+					t.Errorf("unexpected claims %v", claims)
+				}
+				return
+			}
+
+			last := report.checks[len(report.checks)-1]
+			if last.name != tt.expectedCheck || last.passed {
+				t.Errorf("expected check %q to fail, got %+v", tt.expectedCheck, last)
+			}
+		})
+	}
+}
+
+func TestCheckOIDCIDTokenClaims(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss":   "https://vault.example.com/v1/identity/oidc/provider/default",
+			"aud":   "client-1",
+			"sub":   "entity-1",
+			"nonce": "nonce-1",
+			"exp":   json.Number("1700086400"),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(map[string]interface{})
+		expected string
+	}{
+		{
+			name:   "valid",
+			modify: func(map[string]interface{}) {},
+		},
+		{
+			name: "audience-list",
+			modify: func(c map[string]interface{}) {
+				c["aud"] = []interface{}{"client-2", "client-1"}
+			},
+		},
+		{
+			name: "wrong-issuer",
+			modify: func(c map[string]interface{}) {
+				c["iss"] = "https://other.example.com"
+			},
+			expected: "iss",
+		},
+		{
+			name: "wrong-audience",
+			modify: func(c map[string]interface{}) {
+				c["aud"] = []interface{}{"client-2"}
+			},
+			expected: "aud",
+		},
+		{
+			name: "wrong-nonce",
+			modify: func(c map[string]interface{}) {
+				c["nonce"] = "nonce-2"
+			},
+			expected: "nonce",
+		},
+		{
+			name: "no-subject",
+			modify: func(c map[string]interface{}) {
+				delete(c, "sub")
+			},
+			expected: "sub",
+		},
+		{
+			name: "expired",
+			modify: func(c map[string]interface{}) {
+				c["exp"] = json.Number("1699999999")
+			},
+			expected: "expired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := valid()
+			tt.modify(claims)
+
+			problems := checkOIDCIDTokenClaims(claims,
+				"https://vault.example.com/v1/identity/oidc/provider/default", "client-1", "nonce-1", now)
+			if tt.expected == "" {
+				if len(problems) > 0 {
+					t.Errorf("expected no problems, got %v", problems)
+				}
+				return
+			}
+
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.expected) {
+				t.Errorf("expected one problem about %q, got %v", tt.expected, problems)
+			}
+		})
+	}
+}
+
+func TestCheckOIDCClaim(t *testing.T) {
+	idToken := map[string]interface{}{
+		"team":   "payments",
+		"groups": []interface{}{"a", "b"},
+	}
+	userinfo := map[string]interface{}{
+		"email": "alice@example.com",
+	}
+	expected := map[string]string{
+		"groups": `["a","b"]`,
+		"email":  "bob@example.com",
+	}
+
+	tests := []struct {
+		name     string
+		expected *oidcConformanceClaim
+	}{
+		{
+			name: "team",
+			expected: &oidcConformanceClaim{
+				name:   "team",
+				scope:  "scope",
+				source: oidcClaimSourceIDToken,
+				value:  "payments",
+				passed: true,
+			},
+		},
+		{
+			name: "groups",
+			expected: &oidcConformanceClaim{
+				name:     "groups",
+				scope:    "scope",
+				source:   oidcClaimSourceIDToken,
+				value:    `["a","b"]`,
+				expected: `["a","b"]`,
+				passed:   true,
+			},
+		},
+		{
+			name: "email",
+			expected: &oidcConformanceClaim{
+				name:     "email",
+				scope:    "scope",
+				source:   oidcClaimSourceUserinfo,
+				value:    "alice@example.com",
+				expected: "bob@example.com",
+				message:  `expected "bob@example.com", got "alice@example.com"`,
+			},
+		},
+		{
+			name: "missing",
+			expected: &oidcConformanceClaim{
+				name:    "missing",
+				scope:   "scope",
+				message: "the claim is not in the ID token or the userinfo response",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := checkOIDCClaim(tt.name, "scope", idToken, userinfo, expected)
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
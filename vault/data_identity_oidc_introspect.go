@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const identityOIDCIntrospectPath = "identity/oidc/introspect"
+
+func identityOIDCIntrospectDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readOIDCIntrospectDataSource),
+		Schema: map[string]*schema.Schema{
+			consts.FieldToken: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The identity token to introspect.",
+			},
+			consts.FieldClientID: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Client ID the token must have been issued for.",
+			},
+			consts.FieldActive: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the token is valid, unexpired and issued by this Vault.",
+			},
+			consts.FieldError: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason the token is not active.",
+			},
+		},
+	}
+}
+
+func readOIDCIntrospectDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	active, reason, err := introspectOIDCToken(ctx, client, d.Get(consts.FieldToken).(string), d.Get(consts.FieldClientID).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(identityOIDCIntrospectPath)
+
+	if err := d.Set(consts.FieldActive, active); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldError, reason); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// introspectOIDCToken returns whether the token is active and if not, the
+// reason given by Vault.
+func introspectOIDCToken(ctx context.Context, client *api.Client, token, clientID string) (bool, string, error) {
+	data := map[string]interface{}{
+		consts.FieldToken: token,
+	}
+	if clientID != "" {
+		data[consts.FieldClientID] = clientID
+	}
+
+	path := "/v1/" + identityOIDCIntrospectPath
+	r := client.NewRequest("POST", path)
+	if err := r.SetJSONBody(data); err != nil {
+		return false, "", err
+	}
+
+	// the response is not wrapped in a secret, and an inactive token is
+	// reported with an error status.
+	log.Printf("[DEBUG] Introspecting identity token at %q", path)
+	resp, err := client.RawRequestWithContext(ctx, r)
+	if resp == nil {
+		return false, "", fmt.Errorf("error performing POST at %s, err=%w", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, e := io.ReadAll(resp.Body)
+	if e != nil {
+		return false, "", e
+	}
+
+	var result struct {
+		Active *bool  `json:"active"`
+		Error  string `json:"error"`
+	}
+	if e := json.Unmarshal(body, &result); e != nil || result.Active == nil {
+		if err != nil {
+			return false, "", fmt.Errorf("error performing POST at %s, err=%w", path, err)
+		}
+		return false, "", fmt.Errorf("unexpected introspection response from %s: %s", path, body)
+	}
+	log.Printf("[DEBUG] Introspected identity token at %q, active=%t", path, *result.Active)
+
+	return *result.Active, result.Error, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourceIdentityOIDCIntrospect(t *testing.T) {
+	testutil.SkipTestAcc(t)
+	testutil.TestAccPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-oidc-introspect")
+	token, _ := testIdentityOIDCTokenSetup(t, name)
+
+	dataSourceName := "data.vault_identity_oidc_introspect.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceIdentityOIDCIntrospectConfig(token, name, "data.vault_identity_oidc_token.test.client_id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldActive, "true"),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldError, ""),
+				),
+			},
+			{
+				Config: testDataSourceIdentityOIDCIntrospectConfig(token, name, `"wrong-client-id"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldActive, "false"),
+					resource.TestMatchResourceAttr(dataSourceName, consts.FieldError, regexp.MustCompile(`.+`)),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceIdentityOIDCIntrospectConfig(token, role, clientID string) string {
+	return testDataSourceIdentityOIDCTokenConfig(token, role) + fmt.Sprintf(`
+data "vault_identity_oidc_introspect" "test" {
+  token     = data.vault_identity_oidc_token.test.token
+  client_id = %s
+}
+`, clientID)
+}
+
+func TestIntrospectOIDCToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantActive bool
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "active",
+			status:     http.StatusOK,
+			body:       `{"active": true}`,
+			wantActive: true,
+		},
+		{
+			name:       "inactive",
+			status:     http.StatusBadRequest,
+			body:       `{"active": false, "error": "token is expired"}`,
+			wantReason: "token is expired",
+		},
+		{
+			name:    "permission-denied",
+			status:  http.StatusForbidden,
+			body:    `{"errors": ["permission denied"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unexpected-response",
+			status:  http.StatusOK,
+			body:    `{"data": {}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, ln := testutil.TestHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodPost || req.URL.Path != "/v1/"+identityOIDCIntrospectPath {
+					w.WriteHeader(http.StatusNotImplemented)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ln.Close()
+
+			config.MaxRetries = 0
+			client, err := api.NewClient(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			active, reason, err := introspectOIDCToken(context.Background(), client, "token", "client")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("introspectOIDCToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if active != tt.wantActive || reason != tt.wantReason {
+				t.Errorf("introspectOIDCToken() got = (%t, %q), want (%t, %q)",
+					active, reason, tt.wantActive, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const identityOIDCTokenPathPrefix = "identity/oidc/token"
+
+func identityOIDCTokenDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readOIDCTokenDataSource),
+		Schema: map[string]*schema.Schema{
+			consts.FieldRoleName: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the role to issue the token for.",
+			},
+			consts.FieldToken: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The signed identity token.",
+			},
+			consts.FieldClientID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Client ID of the role, the audience of the token.",
+			},
+			consts.FieldTTL: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "TTL of the token in seconds.",
+			},
+			consts.FieldClaims: {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Description: "Claims of the token. Values that are not strings are JSON encoded, " +
+					"use claims_json to access them as structured data.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldClaimsJSON: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Claims of the token, serialized in JSON format.",
+			},
+			consts.FieldIssuer: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The iss claim of the token.",
+			},
+			consts.FieldSubject: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The sub claim of the token, the ID of the entity.",
+			},
+			consts.FieldAudience: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The aud claim of the token.",
+			},
+			consts.FieldIssuedAt: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The iat claim of the token, in seconds since the Unix epoch.",
+			},
+			consts.FieldExpiresAt: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The exp claim of the token, in seconds since the Unix epoch.",
+			},
+			consts.FieldAlgorithm: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Signing algorithm of the token, from its header.",
+			},
+			consts.FieldKeyID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the key that signed the token, from its header.",
+			},
+		},
+	}
+}
+
+func readOIDCTokenDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := fmt.Sprintf("%s/%s", identityOIDCTokenPathPrefix, d.Get(consts.FieldRoleName).(string))
+	log.Printf("[DEBUG] Issuing identity token from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error issuing identity token from %q: %s", path, err)
+	}
+	if resp == nil {
+		return diag.Errorf("no identity token returned from %q", path)
+	}
+	log.Printf("[DEBUG] Issued identity token from %q", path)
+
+	token, ok := resp.Data[consts.FieldToken].(string)
+	if !ok || token == "" {
+		return diag.Errorf("token is not set in response")
+	}
+
+	header, claims, err := decodeJWT(token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flatClaims, err := flattenJWTClaims(claims)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var ttl int64
+	if v, ok := resp.Data[consts.FieldTTL].(json.Number); ok {
+		if ttl, err = v.Int64(); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(path)
+
+	fields := map[string]interface{}{
+		consts.FieldToken:      token,
+		consts.FieldClientID:   resp.Data[consts.FieldClientID],
+		consts.FieldTTL:        ttl,
+		consts.FieldClaims:     flatClaims,
+		consts.FieldClaimsJSON: string(claimsJSON),
+		consts.FieldIssuer:     flatClaims["iss"],
+		consts.FieldSubject:    flatClaims["sub"],
+		consts.FieldAudience:   flatClaims["aud"],
+		consts.FieldIssuedAt:   jwtNumericDate(claims["iat"]),
+		consts.FieldExpiresAt:  jwtNumericDate(claims["exp"]),
+		consts.FieldAlgorithm:  header["alg"],
+		consts.FieldKeyID:      header["kid"],
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// decodeJWT returns the header and claims of a compact serialized JWT. The
+// signature is not verified, that is left to the relying party.
+func decodeJWT(token string) (map[string]interface{}, map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("invalid JWT, expected 3 parts, got %d", len(parts))
+	}
+
+	decode := func(name, part string) (map[string]interface{}, error) {
+		b, err := base64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT %s: %w", name, err)
+		}
+
+		var v map[string]interface{}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("invalid JWT %s: %w", name, err)
+		}
+
+		return v, nil
+	}
+
+	header, err := decode("header", parts[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	claims, err := decode("claims", parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return header, claims, nil
+}
+
+// flattenJWTClaims converts the claims to a map of strings, values that are
+// not strings are JSON encoded.
+func flattenJWTClaims(claims map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string, len(claims))
+	for k, v := range claims {
+		if s, ok := v.(string); ok {
+			result[k] = s
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT claim %q: %w", k, err)
+		}
+		result[k] = string(b)
+	}
+
+	return result, nil
+}
+
+// jwtNumericDate returns the seconds of a NumericDate claim, or 0 if it is
+// not set.
+func jwtNumericDate(v interface{}) int64 {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0
+	}
+
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return 0
+	}
+
+	return int64(f)
+}
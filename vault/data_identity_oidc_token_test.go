@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+// testIdentityOIDCTokenSetup creates an identity token role and logs in with
+// userpass, since only tokens that belong to an entity can be issued an
+// identity token. It returns the client token and the ID of its entity.
+func testIdentityOIDCTokenSetup(t *testing.T, name string) (string, string) {
+	t.Helper()
+
+	client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+
+	cleanup := func(path string) {
+		t.Cleanup(func() {
+			if _, err := client.Logical().Delete(path); err != nil {
+				t.Logf("failed to delete %q: %s", path, err)
+			}
+		})
+	}
+
+	if err := client.Sys().EnableAuthWithOptions(name, &api.EnableAuthOptions{Type: consts.AuthMethodUserpass}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := client.Sys().DisableAuth(name); err != nil {
+			t.Logf("failed to disable auth %q: %s", name, err)
+		}
+	})
+
+	writes := []struct {
+		path string
+		data map[string]interface{}
+	}{
+		{
+			path: identityOidcKeyPath(name),
+			data: map[string]interface{}{
+				"allowed_client_ids": "*",
+			},
+		},
+		{
+			path: "identity/oidc/role/" + name,
+			data: map[string]interface{}{
+				"key":      name,
+				"template": `{"team": "payments"}`,
+			},
+		},
+		{
+			path: "sys/policy/" + name,
+			data: map[string]interface{}{
+				"policy": fmt.Sprintf(`
+path "identity/oidc/token/%s" {
+  capabilities = ["read"]
+}
+
+path "identity/oidc/introspect" {
+  capabilities = ["update"]
+}
+`, name),
+			},
+		},
+		{
+			path: fmt.Sprintf("auth/%s/users/%s", name, name),
+			data: map[string]interface{}{
+				"password":       name,
+				"token_policies": name,
+			},
+		},
+	}
+	for _, w := range writes {
+		if _, err := client.Logical().Write(w.path, w.data); err != nil {
+			t.Fatal(err)
+		}
+		// cleanups run in reverse order, so the role is deleted before its key
+		cleanup(w.path)
+	}
+
+	resp, err := client.Logical().Write(fmt.Sprintf("auth/%s/login/%s", name, name), map[string]interface{}{
+		"password": name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup("identity/entity/id/" + resp.Auth.EntityID)
+
+	return resp.Auth.ClientToken, resp.Auth.EntityID
+}
+
+func TestDataSourceIdentityOIDCToken(t *testing.T) {
+	testutil.SkipTestAcc(t)
+	testutil.TestAccPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-oidc-token")
+	token, entityID := testIdentityOIDCTokenSetup(t, name)
+
+	dataSourceName := "data.vault_identity_oidc_token.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceIdentityOIDCTokenConfig(token, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldRoleName, name),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldToken),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldClientID),
+					resource.TestCheckResourceAttrPair(dataSourceName, consts.FieldAudience, dataSourceName, consts.FieldClientID),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldSubject, entityID),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldTTL, "86400"),
+					resource.TestCheckResourceAttr(dataSourceName, "claims.team", "payments"),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldAlgorithm, "RS256"),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldKeyID),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldIssuer),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldIssuedAt),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldExpiresAt),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceIdentityOIDCTokenConfig(token, role string) string {
+	return fmt.Sprintf(`
+provider "vault" {
+  token            = "%s"
+  skip_child_token = true
+}
+
+data "vault_identity_oidc_token" "test" {
+  role_name = "%s"
+}
+`, token, role)
+}
+
+func TestDecodeJWT(t *testing.T) {
+	encode := func(v string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(v))
+	}
+
+	token := fmt.Sprintf("%s.%s.%s",
+		encode(`{"alg":"RS256","kid":"key-1"}`),
+		encode(`{"iss":"https://vault.example.com/v1/identity/oidc","sub":"entity-1","aud":"client-1","iat":1700000000,"exp":1700086400,"groups":["a","b"],"team":"payments"}`),
+		encode("signature"),
+	)
+
+	header, claims, err := decodeJWT(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header["alg"] != "RS256" || header["kid"] != "key-1" {
+		t.Errorf("unexpected header %v", header)
+	}
+
+	if actual := jwtNumericDate(claims["iat"]); actual != 1700000000 {
+		t.Errorf("expected iat 1700000000, got %d", actual)
+	}
+	if actual := jwtNumericDate(claims["nbf"]); actual != 0 {
+		t.Errorf("expected unset nbf to be 0, got %d", actual)
+	}
+
+	flat, err := flattenJWTClaims(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"iss":    "https://vault.example.com/v1/identity/oidc",
+		"sub":    "entity-1",
+		"aud":    "client-1",
+		"iat":    "1700000000",
+		"exp":    "1700086400",
+		"groups": `["a","b"]`,
+		"team":   "payments",
+	}
+	if !reflect.DeepEqual(expected, flat) {
+		t.Errorf("expected claims %v, got %v", expected, flat)
+	}
+
+	for _, invalid := range []string{
+		"not-a-jwt",
+		fmt.Sprintf("%s.%s.%s", encode(`{"alg":"RS256"}`), "!!!", encode("signature")),
+		fmt.Sprintf("%s.%s.%s", encode(`{"alg":"RS256"}`), encode(`[1, 2]`), encode("signature")),
+	} {
+		if _, _, err := decodeJWT(invalid); err == nil {
+			t.Errorf("expected an error decoding %q", invalid)
+		}
+	}
+}
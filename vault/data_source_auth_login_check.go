@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+// authLoginTestFields are the auth login fields supported by the
+// vault_auth_login_test data source.
+var authLoginTestFields = []string{
+	consts.FieldAuthLoginJWT,
+	consts.FieldAuthLoginAppRole,
+	consts.FieldAuthLoginKubernetes,
+}
+
+func authLoginTestDataSource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		consts.FieldLoginPath: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The path the login request was sent to.",
+		},
+		consts.FieldPolicies: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "All the policies of the token.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldTokenPolicies: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The policies attached to the token by the role.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldIdentityPolicies: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The policies inherited from the entity and its groups.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldEntityID: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the entity of the token.",
+		},
+		consts.FieldAliasMetadata: {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The metadata of the entity alias of the token.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldLeaseDuration: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The TTL of the token in seconds.",
+		},
+		consts.FieldExplicitMaxTTL: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The explicit max TTL of the token in seconds, 0 if none.",
+		},
+		consts.FieldRenewable: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the token is renewable.",
+		},
+		consts.FieldTokenType: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of the token.",
+		},
+	}
+
+	for _, field := range authLoginTestFields {
+		loginSchema, err := provider.GetAuthLoginSchema(field)
+		if err != nil {
+			panic(err)
+		}
+		loginSchema.ExactlyOneOf = authLoginTestFields
+		s[field] = loginSchema
+	}
+
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readAuthLoginTestDataSource),
+		Schema:      s,
+	}
+}
+
+func readAuthLoginTestDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	authLogin, err := provider.GetAuthLoginFromFields(d, authLoginTestFields...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if authLogin == nil {
+		return diag.Errorf("one of %v must be set", authLoginTestFields)
+	}
+
+	loginClient, err := client.CloneWithHeaders()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	loginClient.ClearToken()
+
+	// the token is looked up and revoked with the provider's token, in the
+	// namespace of the login
+	tokenClient := client
+	if ns, ok := authLogin.Namespace(); ok {
+		loginClient.SetNamespace(ns)
+
+		tokenClient, err = client.CloneWithHeaders()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tokenClient.SetNamespace(ns)
+	}
+
+	path := authLogin.LoginPath()
+	log.Printf("[DEBUG] Testing login to %q", path)
+	secret, err := authLogin.Login(loginClient)
+	if err != nil {
+		return diag.Errorf("error logging in to %q: %s", path, err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return diag.Errorf("login to %q returned no token", path)
+	}
+	if secret.Auth.MFARequirement != nil {
+		return diag.Errorf("login to %q requires MFA, which is not supported", path)
+	}
+	if secret.Auth.ClientToken == "" {
+		return diag.Errorf("login to %q returned no token", path)
+	}
+
+	// batch tokens have no accessor
+	tokenType := "batch"
+	var explicitMaxTTL int64
+	var lookupErr error
+	if secret.Auth.Accessor != "" {
+		var lookup *api.Secret
+		lookup, lookupErr = tokenClient.Auth().Token().LookupAccessorWithContext(ctx, secret.Auth.Accessor)
+		if lookup != nil {
+			if v, ok := lookup.Data["type"].(string); ok {
+				tokenType = v
+			}
+			if v, err := parseutil.ParseInt(lookup.Data["explicit_max_ttl"]); err == nil {
+				explicitMaxTTL = v
+			}
+		}
+	}
+
+	// revoke the token before anything else
+	if err := revokeAuthLoginTestToken(ctx, tokenClient, secret.Auth); err != nil {
+		return diag.Errorf("error revoking the token of login %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Tested login to %q", path)
+
+	if lookupErr != nil {
+		return diag.Errorf("error looking up the token of login %q: %s", path, lookupErr)
+	}
+
+	metadata := secret.Auth.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	d.SetId(path)
+
+	fields := map[string]interface{}{
+		consts.FieldLoginPath:        path,
+		consts.FieldPolicies:         secret.Auth.Policies,
+		consts.FieldTokenPolicies:    secret.Auth.TokenPolicies,
+		consts.FieldIdentityPolicies: secret.Auth.IdentityPolicies,
+		consts.FieldEntityID:         secret.Auth.EntityID,
+		consts.FieldAliasMetadata:    metadata,
+		consts.FieldLeaseDuration:    secret.Auth.LeaseDuration,
+		consts.FieldExplicitMaxTTL:   explicitMaxTTL,
+		consts.FieldRenewable:        secret.Auth.Renewable,
+		consts.FieldTokenType:        tokenType,
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// revokeAuthLoginTestToken revokes the token of auth by its accessor with
+// client, so that it does not depend on the policies of the token. Batch
+// tokens have no accessor, they cannot be revoked and expire with their TTL.
+func revokeAuthLoginTestToken(ctx context.Context, client *api.Client, auth *api.SecretAuth) error {
+	if auth.Accessor == "" {
+		log.Printf("[DEBUG] Not revoking batch token")
+		return nil
+	}
+
+	return client.Auth().Token().RevokeAccessorWithContext(ctx, auth.Accessor)
+}
@@ -0,0 +1,276 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccDataSourceAuthLoginTest(t *testing.T) {
+	backend := acctest.RandomWithPrefix("approle")
+	role := acctest.RandomWithPrefix("test-role")
+	dataSourceName := "data.vault_auth_login_test.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAuthLoginTestConfig(backend, role, "service"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldLoginPath,
+						fmt.Sprintf("auth/%s/login", backend)),
+					resource.TestCheckResourceAttr(dataSourceName, "token_policies.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "token_policies.0", "dev"),
+					resource.TestCheckResourceAttr(dataSourceName, "token_policies.1", "prod"),
+					resource.TestCheckResourceAttrSet(dataSourceName, consts.FieldEntityID),
+					resource.TestCheckResourceAttr(dataSourceName, "alias_metadata.role_name", role),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldLeaseDuration, "3600"),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldRenewable, "true"),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldTokenType, "service"),
+				),
+			},
+			{
+				Config: testAccDataSourceAuthLoginTestConfig(backend, role, "batch"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldTokenType, "batch"),
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldRenewable, "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "vault_auth_backend" "approle" {
+  type = "approle"
+  path = "%s"
+}
+
+data "vault_auth_login_test" "test" {
+  auth_login_approle {
+    mount     = vault_auth_backend.approle.path
+    role_id   = "unknown"
+    secret_id = "unknown"
+  }
+}
+`, backend),
+				ExpectError: regexp.MustCompile("error logging in to"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAuthLoginTestConfig(backend, role, tokenType string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "approle" {
+  type = "approle"
+  path = "%s"
+}
+
+resource "vault_approle_auth_backend_role" "role" {
+  backend        = vault_auth_backend.approle.path
+  role_name      = "%s"
+  token_policies = ["dev", "prod"]
+  token_ttl      = 3600
+  token_type     = "%s"
+
+  # the token cannot look up or revoke itself
+  token_no_default_policy = true
+}
+
+resource "vault_approle_auth_backend_role_secret_id" "secret" {
+  backend   = vault_auth_backend.approle.path
+  role_name = vault_approle_auth_backend_role.role.role_name
+}
+
+data "vault_auth_login_test" "test" {
+  auth_login_approle {
+    mount     = vault_auth_backend.approle.path
+    role_id   = vault_approle_auth_backend_role.role.role_id
+    secret_id = vault_approle_auth_backend_role_secret_id.secret.secret_id
+  }
+}
+`, backend, role, tokenType)
+}
+
+func TestAuthLoginTestDataSource_schema(t *testing.T) {
+	r := authLoginTestDataSource()
+	if err := r.InternalValidate(nil, false); err != nil {
+		t.Fatalf("invalid data source: %s", err)
+	}
+
+	for _, field := range authLoginTestFields {
+		s, ok := r.Schema[field]
+		if !ok {
+			t.Fatalf("expected field %q", field)
+		}
+		if !reflect.DeepEqual(s.ExactlyOneOf, authLoginTestFields) {
+			t.Errorf("expected %q to be exactly one of %v, got %v", field, authLoginTestFields, s.ExactlyOneOf)
+		}
+		if len(s.ConflictsWith) > 0 {
+			t.Errorf("expected %q to have no conflicts, got %v", field, s.ConflictsWith)
+		}
+	}
+}
+
+func TestAuthLoginTestDataSource_login(t *testing.T) {
+	tests := []struct {
+		name           string
+		raw            map[string]interface{}
+		accessor       string
+		wantLoginPath  string
+		wantLoginBody  map[string]interface{}
+		wantRevocation bool
+	}{
+		{
+			name: "approle",
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginAppRole: []interface{}{
+					map[string]interface{}{
+						consts.FieldMount:    "approle-test",
+						consts.FieldRoleID:   "role-id-1",
+						consts.FieldSecretID: "secret-id-1",
+					},
+				},
+			},
+			accessor:      "accessor-1",
+			wantLoginPath: "/v1/auth/approle-test/login",
+			wantLoginBody: map[string]interface{}{
+				consts.FieldRoleID:   "role-id-1",
+				consts.FieldSecretID: "secret-id-1",
+			},
+			wantRevocation: true,
+		},
+		{
+			name: "kubernetes",
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginKubernetes: []interface{}{
+					map[string]interface{}{
+						consts.FieldRole: "app",
+						consts.FieldJWT:  "sa-token",
+					},
+				},
+			},
+			accessor:      "accessor-1",
+			wantLoginPath: "/v1/auth/kubernetes/login",
+			wantLoginBody: map[string]interface{}{
+				consts.FieldRole: "app",
+				consts.FieldJWT:  "sa-token",
+			},
+			wantRevocation: true,
+		},
+		{
+			name: "jwt-batch",
+			raw: map[string]interface{}{
+				consts.FieldAuthLoginJWT: []interface{}{
+					map[string]interface{}{
+						consts.FieldRole: "app",
+						consts.FieldJWT:  "jwt-1",
+					},
+				},
+			},
+			wantLoginPath: "/v1/auth/jwt/login",
+			wantLoginBody: map[string]interface{}{
+				consts.FieldRole: "app",
+				consts.FieldJWT:  "jwt-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var loginBody map[string]interface{}
+			var revoked bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var resp *api.Secret
+				switch req.URL.Path {
+				case tt.wantLoginPath:
+					if err := json.NewDecoder(req.Body).Decode(&loginBody); err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					resp = &api.Secret{
+						Auth: &api.SecretAuth{
+							ClientToken:   "token-1",
+							Accessor:      tt.accessor,
+							Policies:      []string{"default", "dev"},
+							TokenPolicies: []string{"default", "dev"},
+							EntityID:      "entity-1",
+							LeaseDuration: 3600,
+						},
+					}
+				case "/v1/auth/token/revoke-accessor":
+					// the token is revoked with the provider's token
+					var body map[string]interface{}
+					if req.Header.Get("X-Vault-Token") != "provider-token" {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body["accessor"] != tt.accessor {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					revoked = true
+					w.WriteHeader(http.StatusNoContent)
+					return
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				if err := json.NewEncoder(w).Encode(resp); err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			config := api.DefaultConfig()
+			config.Address = server.URL
+			client, err := api.NewClient(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client.ClearToken()
+
+			d := schema.TestResourceDataRaw(t, authLoginTestDataSource().Schema, tt.raw)
+			authLogin, err := provider.GetAuthLoginFromFields(d, authLoginTestFields...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if authLogin == nil {
+				t.Fatal("expected an auth login")
+			}
+
+			secret, err := authLogin.Login(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(loginBody, tt.wantLoginBody) {
+				t.Errorf("expected login body %v, got %v", tt.wantLoginBody, loginBody)
+			}
+
+			client.SetToken("provider-token")
+			if err := revokeAuthLoginTestToken(context.Background(), client, secret.Auth); err != nil {
+				t.Fatal(err)
+			}
+			if revoked != tt.wantRevocation {
+				t.Errorf("expected revocation %t, got %t", tt.wantRevocation, revoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+func jwtAuthBackendVerifyDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readJWTAuthBackendVerifyDataSource),
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the JWT auth backend to verify.",
+			},
+			consts.FieldSampleJWT: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "JWT issued by the identity provider, evaluated against the roles of the backend.",
+			},
+			consts.FieldRoles: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Names of the roles to evaluate the sample JWT against. Defaults to all the roles of the backend.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldPassed: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether all the checks passed.",
+			},
+			consts.FieldChecks: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results of the checks of the configuration and of the sample JWT.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the check.",
+						},
+						consts.FieldPassed: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the check passed.",
+						},
+						consts.FieldMessage: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Details of the result.",
+						},
+					},
+				},
+			},
+			consts.FieldRoleResults: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results of the evaluation of the sample JWT against each role.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the role.",
+						},
+						consts.FieldAccepted: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the role would accept the sample JWT.",
+						},
+						consts.FieldMessage: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Reason the role would reject the sample JWT, if any.",
+						},
+						consts.FieldMetadata: {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Alias metadata set from the claim_mappings of the role.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			consts.FieldAcceptedRoles: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the roles that would accept the sample JWT.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// readJWTAuthBackendVerifyDataSource verifies the configuration of the JWT
+// auth backend, as read from Vault, against the identity provider and
+// evaluates the sample JWT against its roles. Failed checks are reported in
+// the results, an error is only returned if the backend or its roles cannot
+// be read.
+func readJWTAuthBackendVerifyDataSource(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Get(consts.FieldMount).(string)
+	resp, err := client.Logical().ReadWithContext(ctx, jwtConfigEndpoint(path))
+	if err != nil {
+		return diag.Errorf("error reading JWT auth backend %q config: %s", path, err)
+	}
+	if resp == nil {
+		return diag.Errorf("JWT auth backend %q is not configured", path)
+	}
+
+	verifyCtx, cancel := context.WithTimeout(ctx, jwtVerifyTimeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Verifying JWT auth backend %q", path)
+	report, claims := runJWTAuthBackendVerify(verifyCtx, jwtVerifyConfigFromData(resp.Data), d.Get(consts.FieldSampleJWT).(string))
+	if claims != nil {
+		var names []string
+		for _, v := range d.Get(consts.FieldRoles).([]interface{}) {
+			names = append(names, v.(string))
+		}
+
+		roles, err := jwtVerifyReadRoles(client, path, names)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, name := range jwtVerifySortedKeys(roles) {
+			report.roles = append(report.roles, evaluateJWTAuthBackendRole(name, roles[name], claims))
+		}
+	}
+	log.Printf("[DEBUG] Verified JWT auth backend %q", path)
+
+	var checks []map[string]interface{}
+	for _, c := range report.checks {
+		checks = append(checks, map[string]interface{}{
+			consts.FieldName:    c.name,
+			consts.FieldPassed:  c.passed,
+			consts.FieldMessage: c.message,
+		})
+	}
+
+	var roleResults []map[string]interface{}
+	for _, r := range report.roles {
+		roleResults = append(roleResults, map[string]interface{}{
+			consts.FieldName:     r.name,
+			consts.FieldAccepted: r.accepted,
+			consts.FieldMessage:  r.message,
+			consts.FieldMetadata: r.metadata,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("auth/%s/config", path))
+
+	fields := map[string]interface{}{
+		consts.FieldPassed:        report.passed(),
+		consts.FieldChecks:        checks,
+		consts.FieldRoleResults:   roleResults,
+		consts.FieldAcceptedRoles: report.acceptedRoles(),
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
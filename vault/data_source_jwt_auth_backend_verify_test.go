@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccDataSourceJWTAuthBackendVerify(t *testing.T) {
+	path := acctest.RandomWithPrefix("jwt")
+	dataSourceName := "data.vault_jwt_auth_backend_verify.test"
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubKeyDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyDER}))
+
+	sign := func(key *rsa.PrivateKey) string {
+		signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payload, err := json.Marshal(map[string]interface{}{
+			"iss":    "https://idp.example.com",
+			"sub":    "user-1",
+			"aud":    []string{"vault"},
+			"email":  "user-1@example.com",
+			"groups": []string{"admins"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jws, err := signer.Sign(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := jws.CompactSerialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return token
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckMountDestroyed("vault_jwt_auth_backend", consts.MountTypeJWT, consts.FieldPath),
+		Steps: []resource.TestStep{
+			{
+				// the roles are created in the same apply
+				Config: testAccDataSourceJWTAuthBackendVerifyConfig(path, pubKeyPEM, sign(key), `["nomad"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldPassed, "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.#", "5"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.0.name", "public_keys"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.0.passed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.3.name", "sample_jwt_signature"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.3.passed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.0.name", "admins"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.0.accepted", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.0.metadata.email", "user-1@example.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.0.metadata.role", "admins"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.1.name", "nomad"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.1.accepted", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.1.message",
+						"aud claim does not match any bound audience"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepted_roles.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepted_roles.0", "admins"),
+				),
+			},
+			{
+				// only the role changes, the results follow it
+				Config: testAccDataSourceJWTAuthBackendVerifyConfig(path, pubKeyPEM, sign(key), `["vault"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.1.name", "nomad"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.1.accepted", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepted_roles.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepted_roles.0", "admins"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepted_roles.1", "nomad"),
+				),
+			},
+			{
+				// a failed check is reported without failing the apply
+				Config: testAccDataSourceJWTAuthBackendVerifyConfig(path, pubKeyPEM, sign(otherKey), `["vault"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, consts.FieldPassed, "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.3.name", "sample_jwt_signature"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.3.passed", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "checks.3.message", "none of the keys verify the signature"),
+					resource.TestCheckResourceAttr(dataSourceName, "role_results.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "accepted_roles.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceJWTAuthBackendVerifyConfig(path, pubKey, sampleJWT, nomadAudiences string) string {
+	return fmt.Sprintf(`
+resource "vault_jwt_auth_backend" "jwt" {
+  path                   = "%s"
+  bound_issuer           = "https://idp.example.com"
+  jwt_validation_pubkeys = [<<EOT
+%sEOT
+  ]
+}
+
+resource "vault_jwt_auth_backend_role" "admins" {
+  backend         = vault_jwt_auth_backend.jwt.path
+  role_name       = "admins"
+  role_type       = "jwt"
+  user_claim      = "sub"
+  bound_audiences = ["vault"]
+  bound_claims = {
+    groups = "admins"
+  }
+  claim_mappings = {
+    email = "email"
+  }
+}
+
+resource "vault_jwt_auth_backend_role" "nomad" {
+  backend         = vault_jwt_auth_backend.jwt.path
+  role_name       = "nomad"
+  role_type       = "jwt"
+  user_claim      = "sub"
+  bound_audiences = %s
+}
+
+data "vault_jwt_auth_backend_verify" "test" {
+  mount      = vault_jwt_auth_backend.jwt.path
+  sample_jwt = "%s"
+  roles = [
+    vault_jwt_auth_backend_role.admins.role_name,
+    vault_jwt_auth_backend_role.nomad.role_name,
+  ]
+
+  depends_on = [
+    vault_jwt_auth_backend_role.admins,
+    vault_jwt_auth_backend_role.nomad,
+  ]
+}
+`, path, pubKey, nomadAudiences, sampleJWT)
+}
@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+func pkiCertificateInfoDataSource() *schema.Resource {
+	stringList := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: description,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readPKICertificateInfo),
+		Schema: map[string]*schema.Schema{
+			consts.FieldPEM: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "PEM encoded certificate or chain to inspect, the first certificate is treated as the leaf.",
+			},
+			consts.FieldCAChain: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "PEM encoded CA certificates used in addition to the ones in pem " +
+					"to verify the chain of the leaf certificate.",
+			},
+			consts.FieldChainVerified: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the leaf certificate chains up to a self-signed root.",
+			},
+			consts.FieldChainVerificationError: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason the chain could not be verified.",
+			},
+			consts.FieldCertificates: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The parsed certificates, in the order they appear in pem.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldSubject: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subject distinguished name.",
+						},
+						consts.FieldIssuer: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The issuer distinguished name.",
+						},
+						consts.FieldCommonName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subject common name.",
+						},
+						consts.FieldSerialNumber: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The serial number.",
+						},
+						consts.FieldDNSNames:       stringList("DNS subject alternative names."),
+						consts.FieldIPAddresses:    stringList("IP subject alternative names."),
+						consts.FieldURIs:           stringList("URI subject alternative names."),
+						consts.FieldEmailAddresses: stringList("Email subject alternative names."),
+						consts.FieldKeyUsage:       stringList("Key usages."),
+						consts.FieldExtKeyUsage:    stringList("Well known extended key usages."),
+						consts.FieldExtKeyUsageOIDs: stringList(
+							"OIDs of all extended key usages, including unknown ones."),
+						consts.FieldPolicyIdentifiers: stringList("OIDs of the certificate policies."),
+						consts.FieldPolicyIdentifier: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Certificate policies along with their qualifiers.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									consts.FieldOID: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "OID",
+									},
+									consts.FieldCPS: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "CPS URL",
+									},
+									consts.FieldNotice: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "User notice",
+									},
+								},
+							},
+						},
+						consts.FieldNotBefore: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Start of the validity window in RFC3339 format.",
+						},
+						consts.FieldNotAfter: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "End of the validity window in RFC3339 format.",
+						},
+						consts.FieldExpiration: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "End of the validity window in unix epoch format.",
+						},
+						consts.FieldIsCA: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the certificate is a CA certificate.",
+						},
+						consts.FieldMaxPathLength: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The maximum path length of a CA certificate, -1 if unconstrained.",
+						},
+						consts.FieldPublicKeyAlgorithm: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public key algorithm.",
+						},
+						consts.FieldSignatureAlgorithm: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The signature algorithm.",
+						},
+						consts.FieldSubjectKeyID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hex encoded subject key identifier.",
+						},
+						consts.FieldAuthorityKeyID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hex encoded authority key identifier.",
+						},
+						consts.FieldSHA1Fingerprint: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SHA-1 fingerprint.",
+						},
+						consts.FieldSHA256Fingerprint: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SHA-256 fingerprint.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readPKICertificateInfo(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	certs, err := pki.ParseCertificates(d.Get(consts.FieldPEM).(string))
+	if err != nil {
+		return diag.Errorf("invalid %q: %s", consts.FieldPEM, err)
+	}
+
+	chain := certs[1:]
+	if v, ok := d.GetOk(consts.FieldCAChain); ok {
+		caChain, err := pki.ParseCertificates(v.(string))
+		if err != nil {
+			return diag.Errorf("invalid %q: %s", consts.FieldCAChain, err)
+		}
+		chain = append(chain, caChain...)
+	}
+
+	var infos []map[string]interface{}
+	for i, cert := range certs {
+		info, err := pkiCertificateInfo(cert)
+		if err != nil {
+			return diag.Errorf("error inspecting certificate %d: %s", i, err)
+		}
+		infos = append(infos, info)
+	}
+
+	if err := d.Set(consts.FieldCertificates, infos); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(chain) == 0 {
+		// a lone certificate only verifies if it is self-signed
+		chain = certs[:1]
+	}
+
+	var verificationError string
+	if _, err := pki.VerifyChain(certs[0], chain); err != nil {
+		verificationError = err.Error()
+	}
+
+	if err := d.Set(consts.FieldChainVerified, verificationError == ""); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldChainVerificationError, verificationError); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(infos[0][consts.FieldSHA256Fingerprint].(string))
+
+	return nil
+}
+
+func pkiCertificateInfo(cert *x509.Certificate) (map[string]interface{}, error) {
+	rawPolicies, err := pki.PolicyIdentifiers(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	_, policySet, err := pki.MakePkiPolicyIdentifiersListOrSet(rawPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	var policyOIDs []string
+	var policies []map[string]interface{}
+	if policySet != nil {
+		for _, v := range policySet.List() {
+			p := v.(map[string]string)
+			policyOIDs = append(policyOIDs, p[consts.FieldOID])
+			policies = append(policies, map[string]interface{}{
+				consts.FieldOID:    p[consts.FieldOID],
+				consts.FieldCPS:    p[consts.FieldCPS],
+				consts.FieldNotice: p[consts.FieldNotice],
+			})
+		}
+	}
+
+	var ips []string
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+
+	var uris []string
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+
+	maxPathLength := -1
+	if cert.IsCA && (cert.MaxPathLen > 0 || cert.MaxPathLenZero) {
+		maxPathLength = cert.MaxPathLen
+	}
+
+	sha1Fingerprint, sha256Fingerprint := pki.Fingerprints(cert)
+
+	return map[string]interface{}{
+		consts.FieldSubject:            cert.Subject.String(),
+		consts.FieldIssuer:             cert.Issuer.String(),
+		consts.FieldCommonName:         cert.Subject.CommonName,
+		consts.FieldSerialNumber:       pki.SerialNumber(cert),
+		consts.FieldDNSNames:           cert.DNSNames,
+		consts.FieldIPAddresses:        ips,
+		consts.FieldURIs:               uris,
+		consts.FieldEmailAddresses:     cert.EmailAddresses,
+		consts.FieldKeyUsage:           pki.KeyUsageNames(cert),
+		consts.FieldExtKeyUsage:        pki.ExtKeyUsageNames(cert),
+		consts.FieldExtKeyUsageOIDs:    pki.ExtKeyUsageOIDs(cert),
+		consts.FieldPolicyIdentifiers:  policyOIDs,
+		consts.FieldPolicyIdentifier:   policies,
+		consts.FieldNotBefore:          cert.NotBefore.UTC().Format(time.RFC3339),
+		consts.FieldNotAfter:           cert.NotAfter.UTC().Format(time.RFC3339),
+		consts.FieldExpiration:         cert.NotAfter.Unix(),
+		consts.FieldIsCA:               cert.IsCA,
+		consts.FieldMaxPathLength:      maxPathLength,
+		consts.FieldPublicKeyAlgorithm: cert.PublicKeyAlgorithm.String(),
+		consts.FieldSignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		consts.FieldSubjectKeyID:       hex.EncodeToString(cert.SubjectKeyId),
+		consts.FieldAuthorityKeyID:     hex.EncodeToString(cert.AuthorityKeyId),
+		consts.FieldSHA1Fingerprint:    sha1Fingerprint,
+		consts.FieldSHA256Fingerprint:  sha256Fingerprint,
+	}, nil
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourcePKICertificateInfo(t *testing.T) {
+	backend := acctest.RandomWithPrefix("tf-test-pki")
+	dataName := "data.vault_pki_certificate_info.test"
+	rootName := "data.vault_pki_certificate_info.root"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck: func() {
+			testutil.TestAccPreCheck(t)
+			SkipIfAPIVersionLT(t, testProvider.Meta(), provider.VaultVersion111)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourcePKICertificateInfoConfig(backend),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataName, consts.FieldChainVerified, "true"),
+					resource.TestCheckResourceAttr(dataName, consts.FieldChainVerificationError, ""),
+					resource.TestCheckResourceAttr(dataName, "certificates.#", "1"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.common_name", "cert.test.my.domain"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.issuer", "CN=test Root CA"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.dns_names.#", "2"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.dns_names.0", "cert.test.my.domain"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.dns_names.1", "alt.test.my.domain"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.is_ca", "false"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.key_usage.#", "1"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.key_usage.0", "DigitalSignature"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.ext_key_usage.#", "1"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.ext_key_usage.0", "ServerAuth"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.ext_key_usage_oids.#", "2"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.ext_key_usage_oids.0", "1.3.6.1.5.5.7.3.1"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.ext_key_usage_oids.1", "1.3.6.1.4.1.311.4"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.policy_identifiers.#", "1"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.policy_identifiers.0", "1.2.3.4"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.policy_identifier.0.oid", "1.2.3.4"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.policy_identifier.0.cps", "https://example.com/cps"),
+					resource.TestCheckResourceAttr(dataName, "certificates.0.policy_identifier.0.notice", "Some notice"),
+					resource.TestCheckResourceAttrPair(dataName, "certificates.0.serial_number",
+						"vault_pki_secret_backend_cert.test", consts.FieldSerialNumber),
+					resource.TestCheckResourceAttrPair(dataName, "certificates.0.expiration",
+						"vault_pki_secret_backend_cert.test", consts.FieldExpiration),
+					resource.TestCheckResourceAttrSet(dataName, "certificates.0.sha256_fingerprint"),
+					resource.TestCheckResourceAttr(rootName, consts.FieldChainVerified, "true"),
+					resource.TestCheckResourceAttr(rootName, "certificates.0.is_ca", "true"),
+					resource.TestCheckResourceAttr(rootName, "certificates.0.max_path_length", "-1"),
+					resource.TestCheckResourceAttr(rootName, "certificates.0.subject", "CN=test Root CA"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourcePKICertificateInfoConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+  path                      = "%s"
+  type                      = "pki"
+  default_lease_ttl_seconds = 3600
+  max_lease_ttl_seconds     = 86400
+}
+
+resource "vault_pki_secret_backend_root_cert" "test" {
+  backend     = vault_mount.test.path
+  type        = "internal"
+  common_name = "test Root CA"
+  ttl         = "86400"
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+  backend            = vault_pki_secret_backend_root_cert.test.backend
+  name               = "test"
+  allowed_domains    = ["test.my.domain"]
+  allow_subdomains   = true
+  key_usage          = ["DigitalSignature"]
+  ext_key_usage      = ["ServerAuth"]
+  ext_key_usage_oids = ["1.3.6.1.4.1.311.4"]
+
+  policy_identifier {
+    oid    = "1.2.3.4"
+    cps    = "https://example.com/cps"
+    notice = "Some notice"
+  }
+}
+
+resource "vault_pki_secret_backend_cert" "test" {
+  backend     = vault_pki_secret_backend_role.test.backend
+  name        = vault_pki_secret_backend_role.test.name
+  common_name = "cert.test.my.domain"
+  alt_names   = ["alt.test.my.domain"]
+  ttl         = "720h"
+}
+
+data "vault_pki_certificate_info" "test" {
+  pem      = vault_pki_secret_backend_cert.test.certificate
+  ca_chain = vault_pki_secret_backend_cert.test.issuing_ca
+}
+
+data "vault_pki_certificate_info" "root" {
+  pem = vault_pki_secret_backend_root_cert.test.certificate
+}
+`, backend)
+}
+
+func TestReadPKICertificateInfo(t *testing.T) {
+	caCert, caKey, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCACert, _, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, _, err := testutil.GenerateCSR("intermediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediate, err := testutil.SignIntermediateCSR(caCert, caKey, csr, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		pem           string
+		caChain       string
+		wantVerified  bool
+		wantCerts     int
+		wantPathLen   int
+		wantErrorDiag bool
+	}{
+		{
+			name:         "chain",
+			pem:          string(intermediate) + string(caCert),
+			wantVerified: true,
+			wantCerts:    2,
+			wantPathLen:  1,
+		},
+		{
+			name:         "ca-chain",
+			pem:          string(intermediate),
+			caChain:      string(caCert),
+			wantVerified: true,
+			wantCerts:    1,
+			wantPathLen:  1,
+		},
+		{
+			name:         "wrong-ca-chain",
+			pem:          string(intermediate),
+			caChain:      string(otherCACert),
+			wantVerified: false,
+			wantCerts:    1,
+			wantPathLen:  1,
+		},
+		{
+			name:         "missing-chain",
+			pem:          string(intermediate),
+			wantVerified: false,
+			wantCerts:    1,
+			wantPathLen:  1,
+		},
+		{
+			name:         "self-signed",
+			pem:          string(caCert),
+			wantVerified: true,
+			wantCerts:    1,
+			wantPathLen:  -1,
+		},
+		{
+			name:          "invalid",
+			pem:           string(csr),
+			wantErrorDiag: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := pkiCertificateInfoDataSource()
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				consts.FieldPEM:     tt.pem,
+				consts.FieldCAChain: tt.caChain,
+			})
+
+			diags := readPKICertificateInfo(nil, d, nil)
+			if tt.wantErrorDiag {
+				if !diags.HasError() {
+					t.Fatalf("readPKICertificateInfo() expected an error")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("readPKICertificateInfo() unexpected error: %v", diags)
+			}
+
+			if actual := d.Get(consts.FieldChainVerified).(bool); actual != tt.wantVerified {
+				t.Fatalf("expected %s to be %t, got %t (%s)", consts.FieldChainVerified, tt.wantVerified, actual,
+					d.Get(consts.FieldChainVerificationError))
+			}
+			if actual := d.Get(consts.FieldChainVerificationError).(string); tt.wantVerified != (actual == "") {
+				t.Fatalf("unexpected %s %q", consts.FieldChainVerificationError, actual)
+			}
+			if actual := d.Get("certificates.#").(int); actual != tt.wantCerts {
+				t.Fatalf("expected %d certificates, got %d", tt.wantCerts, actual)
+			}
+			if actual := d.Get("certificates.0.max_path_length").(int); actual != tt.wantPathLen {
+				t.Fatalf("expected max_path_length %d, got %d", tt.wantPathLen, actual)
+			}
+			if d.Id() != d.Get("certificates.0.sha256_fingerprint").(string) {
+				t.Fatalf("expected ID to be the leaf fingerprint, got %q", d.Id())
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const (
+	pkiCRLTypeComplete     = "complete"
+	pkiCRLTypeDelta        = "delta"
+	pkiCRLTypeUnified      = "unified"
+	pkiCRLTypeUnifiedDelta = "unified_delta"
+
+	pkiOCSPTypeOCSP    = "ocsp"
+	pkiOCSPTypeUnified = "unified_ocsp"
+
+	pkiOCSPStatusGood    = "good"
+	pkiOCSPStatusRevoked = "revoked"
+	pkiOCSPStatusUnknown = "unknown"
+)
+
+func pkiSecretBackendRevocationStatusDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(readPKISecretBackendRevocationStatus),
+		Schema: map[string]*schema.Schema{
+			consts.FieldBackend: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Full path where PKI backend is mounted.",
+			},
+			consts.FieldIssuerRef: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Reference to the issuer whose CRLs are fetched. " +
+					"The default issuer of the mount is used if unset.",
+			},
+			consts.FieldSerialNumber: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Serial number of a certificate to look up in the CRLs and the OCSP responder.",
+			},
+			consts.FieldIncludeDelta: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Also fetch the delta CRLs.",
+			},
+			consts.FieldIncludeUnified: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Also fetch the unified CRLs and query the unified OCSP responder.",
+			},
+			consts.FieldOCSP: {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Description:  "Query the OCSP responder for serial_number.",
+				RequiredWith: []string{consts.FieldSerialNumber},
+			},
+			consts.FieldRevoked: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether serial_number is reported as revoked by any of the CRLs or OCSP responses.",
+			},
+			consts.FieldCRLs: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The fetched CRLs.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The CRL variant, one of complete, delta, unified or unified_delta.",
+						},
+						consts.FieldPath: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path the CRL was fetched from.",
+						},
+						consts.FieldCRLNumber: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The CRL number.",
+						},
+						consts.FieldThisUpdate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the CRL was issued in RFC3339 format.",
+						},
+						consts.FieldNextUpdate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the next CRL is expected in RFC3339 format.",
+						},
+						consts.FieldEntryCount: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of revoked certificates in the CRL.",
+						},
+						consts.FieldStale: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the next update time of the CRL has passed.",
+						},
+						consts.FieldSignatureValid: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the CRL is signed by the issuer.",
+						},
+						consts.FieldSerialRevoked: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether serial_number is listed in the CRL.",
+						},
+					},
+				},
+			},
+			consts.FieldOCSPResponses: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The OCSP responses for serial_number.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The responder queried, either ocsp or unified_ocsp.",
+						},
+						consts.FieldStatus: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the certificate, one of good, revoked or unknown.",
+						},
+						consts.FieldRevokedAt: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The revocation time in RFC3339 format, if revoked.",
+						},
+						consts.FieldThisUpdate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the response was produced in RFC3339 format.",
+						},
+						consts.FieldNextUpdate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the response expires in RFC3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readPKISecretBackendRevocationStatus(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	backend := strings.Trim(d.Get(consts.FieldBackend).(string), "/")
+	issuerRef := d.Get(consts.FieldIssuerRef).(string)
+
+	var issuer *x509.Certificate
+	var err error
+	if issuerRef != "" {
+		issuer, err = getIssuerPEM(client, backend, issuerRef)
+	} else {
+		issuer, err = getDefaultCAPEM(client, backend)
+	}
+	if err != nil {
+		return diag.Errorf("error reading issuer certificate from PKI secret backend %q: %s", backend, err)
+	}
+	if issuer == nil {
+		return diag.Errorf("no issuer certificate found on PKI secret backend %q", backend)
+	}
+
+	var serial *big.Int
+	if v, ok := d.GetOk(consts.FieldSerialNumber); ok {
+		serial, err = pki.ParseSerialNumber(v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	crlTypes := []string{pkiCRLTypeComplete}
+	if d.Get(consts.FieldIncludeDelta).(bool) {
+		crlTypes = append(crlTypes, pkiCRLTypeDelta)
+	}
+	if d.Get(consts.FieldIncludeUnified).(bool) {
+		crlTypes = append(crlTypes, pkiCRLTypeUnified)
+		if d.Get(consts.FieldIncludeDelta).(bool) {
+			crlTypes = append(crlTypes, pkiCRLTypeUnifiedDelta)
+		}
+	}
+
+	var revoked bool
+	var crls []map[string]interface{}
+	for _, crlType := range crlTypes {
+		path := pkiSecretBackendCRLPath(backend, issuerRef, crlType)
+		log.Printf("[DEBUG] Fetching %s CRL from %q", crlType, path)
+		body, err := pkiUnauthenticatedRequest(ctx, client, http.MethodGet, path, nil)
+		if err != nil {
+			return diag.Errorf("error fetching %s CRL from %q: %s", crlType, path, err)
+		}
+
+		crl, err := pkiCRLStatus(body, issuer, serial)
+		if err != nil {
+			return diag.Errorf("error parsing %s CRL from %q: %s", crlType, path, err)
+		}
+		crl[consts.FieldType] = crlType
+		crl[consts.FieldPath] = path
+
+		revoked = revoked || crl[consts.FieldSerialRevoked].(bool)
+		crls = append(crls, crl)
+	}
+
+	var ocspResponses []map[string]interface{}
+	if d.Get(consts.FieldOCSP).(bool) {
+		ocspTypes := []string{pkiOCSPTypeOCSP}
+		if d.Get(consts.FieldIncludeUnified).(bool) {
+			ocspTypes = append(ocspTypes, pkiOCSPTypeUnified)
+		}
+
+		req, err := ocsp.CreateRequest(&x509.Certificate{SerialNumber: serial}, issuer, nil)
+		if err != nil {
+			return diag.Errorf("error creating OCSP request: %s", err)
+		}
+
+		for _, ocspType := range ocspTypes {
+			path := fmt.Sprintf("%s/%s", backend, strings.ReplaceAll(ocspType, "_", "-"))
+			log.Printf("[DEBUG] Querying OCSP responder %q", path)
+			body, err := pkiUnauthenticatedRequest(ctx, client, http.MethodPost, path, req)
+			if err != nil {
+				return diag.Errorf("error querying OCSP responder %q: %s", path, err)
+			}
+
+			resp, err := pkiOCSPStatus(body, issuer)
+			if err != nil {
+				return diag.Errorf("error parsing response of OCSP responder %q: %s", path, err)
+			}
+			resp[consts.FieldType] = ocspType
+
+			revoked = revoked || resp[consts.FieldStatus] == pkiOCSPStatusRevoked
+			ocspResponses = append(ocspResponses, resp)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/crl-status/%s", backend, pki.SerialNumber(issuer)))
+
+	if err := d.Set(consts.FieldCRLs, crls); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldOCSPResponses, ocspResponses); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldRevoked, revoked); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func pkiSecretBackendCRLPath(backend, issuerRef, crlType string) string {
+	variant := "crl"
+	switch crlType {
+	case pkiCRLTypeDelta:
+		variant = "crl/delta"
+	case pkiCRLTypeUnified:
+		variant = "unified-crl"
+	case pkiCRLTypeUnifiedDelta:
+		variant = "unified-crl/delta"
+	}
+
+	if issuerRef != "" {
+		return fmt.Sprintf("%s/issuer/%s/%s/der", backend, issuerRef, variant)
+	}
+	return fmt.Sprintf("%s/%s", backend, variant)
+}
+
+// pkiCRLStatus parses the DER encoded CRL and summarizes it, checking whether
+// it was signed by issuer and whether serial, if set, is listed in it.
+func pkiCRLStatus(der []byte, issuer *x509.Certificate, serial *big.Int) (map[string]interface{}, error) {
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, err
+	}
+
+	var serialRevoked bool
+	if serial != nil {
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(serial) == 0 {
+				serialRevoked = true
+				break
+			}
+		}
+	}
+
+	var crlNumber string
+	if crl.Number != nil {
+		crlNumber = crl.Number.String()
+	}
+
+	return map[string]interface{}{
+		consts.FieldCRLNumber:      crlNumber,
+		consts.FieldThisUpdate:     crl.ThisUpdate.UTC().Format(time.RFC3339),
+		consts.FieldNextUpdate:     crl.NextUpdate.UTC().Format(time.RFC3339),
+		consts.FieldEntryCount:     len(crl.RevokedCertificateEntries),
+		consts.FieldStale:          !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate),
+		consts.FieldSignatureValid: crl.CheckSignatureFrom(issuer) == nil,
+		consts.FieldSerialRevoked:  serialRevoked,
+	}, nil
+}
+
+// pkiOCSPStatus parses the DER encoded OCSP response, verifying it was signed
+// by issuer or a responder delegated by it.
+func pkiOCSPStatus(der []byte, issuer *x509.Certificate) (map[string]interface{}, error) {
+	resp, err := ocsp.ParseResponse(der, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	status := pkiOCSPStatusUnknown
+	var revokedAt string
+	switch resp.Status {
+	case ocsp.Good:
+		status = pkiOCSPStatusGood
+	case ocsp.Revoked:
+		status = pkiOCSPStatusRevoked
+		revokedAt = resp.RevokedAt.UTC().Format(time.RFC3339)
+	}
+
+	var nextUpdate string
+	if !resp.NextUpdate.IsZero() {
+		nextUpdate = resp.NextUpdate.UTC().Format(time.RFC3339)
+	}
+
+	return map[string]interface{}{
+		consts.FieldStatus:     status,
+		consts.FieldRevokedAt:  revokedAt,
+		consts.FieldThisUpdate: resp.ThisUpdate.UTC().Format(time.RFC3339),
+		consts.FieldNextUpdate: nextUpdate,
+	}, nil
+}
+
+// pkiUnauthenticatedRequest sends a raw request to one of the unauthenticated
+// PKI endpoints that do not return JSON, e.g. the CRL and OCSP endpoints.
+func pkiUnauthenticatedRequest(ctx context.Context, client *api.Client, method, path string, body []byte) ([]byte, error) {
+	req := client.NewRequest(method, consts.VaultAPIV1Root+"/"+path)
+	req.ClientToken = ""
+	if body != nil {
+		req.BodyBytes = body
+		if req.Headers == nil {
+			req.Headers = http.Header{}
+		}
+		req.Headers.Set("Content-Type", "application/ocsp-request")
+	}
+
+	resp, err := client.RawRequestWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("expected a response body, got nil response")
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
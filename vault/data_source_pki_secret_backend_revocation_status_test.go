@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourcePKISecretBackendRevocationStatus(t *testing.T) {
+	backend := acctest.RandomWithPrefix("tf-test-pki")
+	dataName := "data.vault_pki_secret_backend_revocation_status.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck: func() {
+			testutil.TestAccPreCheck(t)
+			SkipIfAPIVersionLT(t, testProvider.Meta(), provider.VaultVersion113)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourcePKISecretBackendRevocationStatusConfig(backend),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataName, consts.FieldRevoked, "false"),
+					resource.TestCheckResourceAttr(dataName, "crls.#", "4"),
+					resource.TestCheckResourceAttr(dataName, "crls.0.type", "complete"),
+					resource.TestCheckResourceAttr(dataName, "crls.0.path", backend+"/crl"),
+					resource.TestCheckResourceAttr(dataName, "crls.0.stale", "false"),
+					resource.TestCheckResourceAttr(dataName, "crls.0.signature_valid", "true"),
+					resource.TestCheckResourceAttr(dataName, "crls.0.serial_revoked", "false"),
+					resource.TestCheckResourceAttrSet(dataName, "crls.0.next_update"),
+					resource.TestCheckResourceAttr(dataName, "crls.1.type", "delta"),
+					resource.TestCheckResourceAttr(dataName, "crls.1.path", backend+"/crl/delta"),
+					resource.TestCheckResourceAttr(dataName, "crls.2.type", "unified"),
+					resource.TestCheckResourceAttr(dataName, "crls.2.path", backend+"/unified-crl"),
+					resource.TestCheckResourceAttr(dataName, "crls.3.type", "unified_delta"),
+					resource.TestCheckResourceAttr(dataName, "crls.3.path", backend+"/unified-crl/delta"),
+					resource.TestCheckResourceAttr(dataName, "ocsp_responses.#", "2"),
+					resource.TestCheckResourceAttr(dataName, "ocsp_responses.0.type", "ocsp"),
+					resource.TestCheckResourceAttr(dataName, "ocsp_responses.0.status", "good"),
+					resource.TestCheckResourceAttr(dataName, "ocsp_responses.1.type", "unified_ocsp"),
+					resource.TestCheckResourceAttr(dataName, "ocsp_responses.1.status", "good"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourcePKISecretBackendRevocationStatusConfig(backend string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test" {
+  path                      = "%s"
+  type                      = "pki"
+  default_lease_ttl_seconds = 3600
+  max_lease_ttl_seconds     = 86400
+}
+
+resource "vault_pki_secret_backend_root_cert" "test" {
+  backend     = vault_mount.test.path
+  type        = "internal"
+  common_name = "test Root CA"
+  ttl         = "86400"
+}
+
+resource "vault_pki_secret_backend_crl_config" "test" {
+  backend      = vault_pki_secret_backend_root_cert.test.backend
+  auto_rebuild = true
+  enable_delta = true
+  unified_crl  = true
+  ocsp_disable = false
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+  backend          = vault_pki_secret_backend_crl_config.test.backend
+  name             = "test"
+  allowed_domains  = ["test.my.domain"]
+  allow_subdomains = true
+}
+
+resource "vault_pki_secret_backend_cert" "test" {
+  backend     = vault_pki_secret_backend_role.test.backend
+  name        = vault_pki_secret_backend_role.test.name
+  common_name = "cert.test.my.domain"
+}
+
+data "vault_pki_secret_backend_revocation_status" "test" {
+  backend         = vault_pki_secret_backend_cert.test.backend
+  serial_number   = vault_pki_secret_backend_cert.test.serial_number
+  include_delta   = true
+  include_unified = true
+  ocsp            = true
+}
+`, backend)
+}
+
+func TestPKICRLStatus(t *testing.T) {
+	issuer, signer := testPKIRevocationIssuer(t)
+	_, otherSigner := testPKIRevocationIssuer(t)
+
+	createCRL := func(nextUpdate time.Time, signer crypto.Signer, serials ...int64) []byte {
+		var entries []x509.RevocationListEntry
+		for _, s := range serials {
+			entries = append(entries, x509.RevocationListEntry{
+				SerialNumber:   big.NewInt(s),
+				RevocationTime: time.Now().Add(-time.Minute),
+			})
+		}
+
+		der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:                    big.NewInt(42),
+			ThisUpdate:                time.Now().Add(-time.Hour),
+			NextUpdate:                nextUpdate,
+			RevokedCertificateEntries: entries,
+		}, issuer, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+
+	tests := []struct {
+		name        string
+		der         []byte
+		serial      *big.Int
+		wantRevoked bool
+		wantStale   bool
+		wantValid   bool
+		wantEntries int
+	}{
+		{
+			name:        "revoked",
+			der:         createCRL(time.Now().Add(time.Hour), signer, 1, 2),
+			serial:      big.NewInt(2),
+			wantRevoked: true,
+			wantValid:   true,
+			wantEntries: 2,
+		},
+		{
+			name:        "not-revoked",
+			der:         createCRL(time.Now().Add(time.Hour), signer, 1, 2),
+			serial:      big.NewInt(3),
+			wantValid:   true,
+			wantEntries: 2,
+		},
+		{
+			name:      "no-serial",
+			der:       createCRL(time.Now().Add(time.Hour), signer),
+			wantValid: true,
+		},
+		{
+			name:      "stale",
+			der:       createCRL(time.Now().Add(-time.Minute), signer),
+			wantStale: true,
+			wantValid: true,
+		},
+		{
+			name:        "bad-signature",
+			der:         createCRL(time.Now().Add(time.Hour), otherSigner, 1),
+			wantEntries: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := pkiCRLStatus(tt.der, issuer, tt.serial)
+			if err != nil {
+				t.Fatalf("pkiCRLStatus() unexpected error: %s", err)
+			}
+
+			for k, v := range map[string]interface{}{
+				consts.FieldSerialRevoked:  tt.wantRevoked,
+				consts.FieldStale:          tt.wantStale,
+				consts.FieldSignatureValid: tt.wantValid,
+				consts.FieldEntryCount:     tt.wantEntries,
+				consts.FieldCRLNumber:      "42",
+			} {
+				if actual[k] != v {
+					t.Errorf("pkiCRLStatus() expected %s to be %v, got %v", k, v, actual[k])
+				}
+			}
+		})
+	}
+
+	if _, err := pkiCRLStatus([]byte("garbage"), issuer, nil); err == nil {
+		t.Fatalf("pkiCRLStatus() expected an error for an invalid CRL")
+	}
+}
+
+func TestPKIOCSPStatus(t *testing.T) {
+	issuer, signer := testPKIRevocationIssuer(t)
+	_, otherSigner := testPKIRevocationIssuer(t)
+
+	revokedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	createResponse := func(status int, signer crypto.Signer) []byte {
+		der, err := ocsp.CreateResponse(issuer, issuer, ocsp.Response{
+			Status:       status,
+			SerialNumber: big.NewInt(1),
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    revokedAt,
+		}, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+
+	tests := []struct {
+		name          string
+		der           []byte
+		wantStatus    string
+		wantRevokedAt string
+		wantErr       bool
+	}{
+		{
+			name:       "good",
+			der:        createResponse(ocsp.Good, signer),
+			wantStatus: pkiOCSPStatusGood,
+		},
+		{
+			name:          "revoked",
+			der:           createResponse(ocsp.Revoked, signer),
+			wantStatus:    pkiOCSPStatusRevoked,
+			wantRevokedAt: revokedAt.UTC().Format(time.RFC3339),
+		},
+		{
+			name:       "unknown",
+			der:        createResponse(ocsp.Unknown, signer),
+			wantStatus: pkiOCSPStatusUnknown,
+		},
+		{
+			name:    "bad-signature",
+			der:     createResponse(ocsp.Good, otherSigner),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := pkiOCSPStatus(tt.der, issuer)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("pkiOCSPStatus() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pkiOCSPStatus() unexpected error: %s", err)
+			}
+
+			if actual[consts.FieldStatus] != tt.wantStatus {
+				t.Errorf("pkiOCSPStatus() expected status %q, got %q", tt.wantStatus, actual[consts.FieldStatus])
+			}
+			if actual[consts.FieldRevokedAt] != tt.wantRevokedAt {
+				t.Errorf("pkiOCSPStatus() expected revoked_at %q, got %q", tt.wantRevokedAt, actual[consts.FieldRevokedAt])
+			}
+		})
+	}
+}
+
+func TestPKISecretBackendCRLPath(t *testing.T) {
+	tests := map[string][]string{
+		pkiCRLTypeComplete:     {"pki/crl", "pki/issuer/default/crl/der"},
+		pkiCRLTypeDelta:        {"pki/crl/delta", "pki/issuer/default/crl/delta/der"},
+		pkiCRLTypeUnified:      {"pki/unified-crl", "pki/issuer/default/unified-crl/der"},
+		pkiCRLTypeUnifiedDelta: {"pki/unified-crl/delta", "pki/issuer/default/unified-crl/delta/der"},
+	}
+	for crlType, expected := range tests {
+		if actual := pkiSecretBackendCRLPath("pki", "", crlType); actual != expected[0] {
+			t.Errorf("pkiSecretBackendCRLPath() expected %q, got %q", expected[0], actual)
+		}
+		if actual := pkiSecretBackendCRLPath("pki", "default", crlType); actual != expected[1] {
+			t.Errorf("pkiSecretBackendCRLPath() expected %q, got %q", expected[1], actual)
+		}
+	}
+}
+
+func testPKIRevocationIssuer(t *testing.T) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+
+	caCert, caKey, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, key, err := testutil.GenerateCSR("issuer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := testutil.SignIntermediateCSR(caCert, caKey, csr, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := pem.Decode(cert)
+	issuer, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ = pem.Decode(key)
+	signer, err := x509.ParseECPrivateKey(b.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return issuer, signer
+}
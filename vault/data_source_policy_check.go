@@ -0,0 +1,555 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/vault/api"
+	"github.com/hashicorp/vault/sdk/helper/identitytpl"
+	"github.com/hashicorp/vault/sdk/logical"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/terraform-provider-vault/helper"
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/group"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const policyCheckRootPolicy = "root"
+
+// policyCheckOperations are the operations that can be checked, each one
+// requires the capability of the same name.
+var policyCheckOperations = []string{
+	"create",
+	"read",
+	"update",
+	"delete",
+	"list",
+	"patch",
+	"sudo",
+	"subscribe",
+}
+
+func policyCheckDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: provider.ReadContextWrapper(policyCheckDataSourceRead),
+		Schema: map[string]*schema.Schema{
+			consts.FieldPolicies: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Names of policies to read from Vault and evaluate.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{consts.FieldPolicies, consts.FieldPolicyHCL},
+			},
+			consts.FieldPolicyHCL: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Policy documents in HCL format to evaluate.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{consts.FieldPolicies, consts.FieldPolicyHCL},
+			},
+			consts.FieldEntityID: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "ID of the entity used to render templated policy paths. " +
+					"Templated paths are skipped if it is not set.",
+			},
+			consts.FieldToken: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Token to cross-check the results against sys/capabilities.",
+			},
+			consts.FieldRequest: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The requests to evaluate.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldPath: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Path of the request.",
+						},
+						consts.FieldOperation: {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Operation of the request.",
+							ValidateFunc: validation.StringInSlice(policyCheckOperations, false),
+						},
+						consts.FieldAllowed: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the policies allow the request.",
+						},
+						consts.FieldCapabilities: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Capabilities the policies grant on the path.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						consts.FieldMatchedPath: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The policy path that matched the request, empty if none did.",
+						},
+						consts.FieldVaultCapabilities: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Capabilities of the token on the path as reported by sys/capabilities.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						consts.FieldVaultAllowed: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the token is allowed the request as reported by sys/capabilities.",
+						},
+					},
+				},
+			},
+			consts.FieldUnresolvedPaths: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Templated policy paths that could not be rendered and were skipped, as Vault does.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// policyPermissions are the merged capabilities of all the rules for the
+// same path.
+type policyPermissions struct {
+	capabilities map[string]bool
+}
+
+func (p *policyPermissions) merge(capabilities []string) {
+	if p.capabilities["deny"] {
+		return
+	}
+
+	for _, c := range capabilities {
+		if c == "deny" {
+			// deny takes precedence over every other capability
+			p.capabilities = map[string]bool{"deny": true}
+			return
+		}
+		p.capabilities[c] = true
+	}
+}
+
+func (p *policyPermissions) list() []string {
+	var result []string
+	for c := range p.capabilities {
+		result = append(result, c)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// policyACL evaluates requests against a set of policies following Vault's
+// matching rules.
+type policyACL struct {
+	root             bool
+	exact            map[string]*policyPermissions
+	prefix           map[string]*policyPermissions
+	segmentWildcards map[string]*policyPermissions
+	unresolved       []string
+}
+
+// newPolicyACL merges the rules of the policies. Templated paths are rendered
+// for the entity and its groups, paths that cannot be rendered are skipped.
+func newPolicyACL(policies []*Policy, ent *logical.Entity, groups []*logical.Group) (*policyACL, error) {
+	acl := &policyACL{
+		exact:            map[string]*policyPermissions{},
+		prefix:           map[string]*policyPermissions{},
+		segmentWildcards: map[string]*policyPermissions{},
+	}
+
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			path := strings.TrimPrefix(rule.Path, "/")
+
+			if strings.Contains(path, "{{") {
+				rendered, err := policyRenderTemplatedPath(path, ent, groups)
+				if err != nil {
+					log.Printf("[DEBUG] Skipping templated policy path %q: %s", path, err)
+					acl.unresolved = append(acl.unresolved, rule.Path)
+					continue
+				}
+				path = rendered
+			}
+
+			if strings.Contains(path, "+*") {
+				return nil, fmt.Errorf("path %q: invalid use of wildcards ('+*' is forbidden)", rule.Path)
+			}
+
+			rules := acl.exact
+			switch {
+			case path == "+" || strings.Contains(path, "/+") || strings.HasPrefix(path, "+/"):
+				rules = acl.segmentWildcards
+			case strings.HasSuffix(path, "*"):
+				rules = acl.prefix
+				path = strings.TrimSuffix(path, "*")
+			}
+
+			perms, ok := rules[path]
+			if !ok {
+				perms = &policyPermissions{capabilities: map[string]bool{}}
+				rules[path] = perms
+			}
+			perms.merge(rule.Capabilities)
+		}
+	}
+
+	return acl, nil
+}
+
+func policyRenderTemplatedPath(path string, ent *logical.Entity, groups []*logical.Group) (string, error) {
+	if ent == nil {
+		return "", identitytpl.ErrNoEntityAttachedToToken
+	}
+
+	_, rendered, err := identitytpl.PopulateString(identitytpl.PopulateStringInput{
+		Mode:        identitytpl.ACLTemplating,
+		String:      path,
+		Entity:      ent,
+		Groups:      groups,
+		NamespaceID: ent.NamespaceID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return rendered, nil
+}
+
+// capabilities returns the capabilities on the path and the policy path
+// that granted them.
+func (a *policyACL) capabilities(path, operation string) ([]string, string) {
+	if a.root {
+		return []string{policyCheckRootPolicy}, ""
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	if operation == "list" && !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	if perms, ok := a.exact[path]; ok {
+		return perms.list(), path
+	}
+
+	// list operations fall back to the path without the trailing slash
+	if operation == "list" {
+		trimmed := strings.TrimSuffix(path, "/")
+		if perms, ok := a.exact[trimmed]; ok {
+			return perms.list(), trimmed
+		}
+	}
+
+	return a.nonExactCapabilities(path)
+}
+
+type policyWildcardPath struct {
+	firstWildcard int
+	path          string
+	isPrefix      bool
+	numWildcards  int
+	perms         *policyPermissions
+}
+
+// less reports whether p has a lower priority than other.
+func (p *policyWildcardPath) less(other *policyWildcardPath) bool {
+	switch {
+	case p.firstWildcard != other.firstWildcard:
+		return p.firstWildcard < other.firstWildcard
+	case p.isPrefix != other.isPrefix:
+		return p.isPrefix
+	case p.numWildcards != other.numWildcards:
+		return p.numWildcards > other.numWildcards
+	case len(p.path) != len(other.path):
+		return len(p.path) < len(other.path)
+	default:
+		return p.path < other.path
+	}
+}
+
+func (p *policyWildcardPath) String() string {
+	if p.isPrefix {
+		return p.path + "*"
+	}
+	return p.path
+}
+
+func (a *policyACL) nonExactCapabilities(path string) ([]string, string) {
+	var candidates []*policyWildcardPath
+
+	var longest string
+	var longestPerms *policyPermissions
+	for prefix, perms := range a.prefix {
+		if strings.HasPrefix(path, prefix) && (longestPerms == nil || len(prefix) > len(longest)) {
+			longest, longestPerms = prefix, perms
+		}
+	}
+	if longestPerms != nil {
+		candidates = append(candidates, &policyWildcardPath{
+			firstWildcard: len(longest),
+			path:          longest,
+			isPrefix:      true,
+			perms:         longestPerms,
+		})
+	}
+
+	pathParts := strings.Split(path, "/")
+WILDCARDS:
+	for wildcardPath, perms := range a.segmentWildcards {
+		c := &policyWildcardPath{
+			firstWildcard: strings.Index(wildcardPath, "+"),
+			path:          wildcardPath,
+			perms:         perms,
+		}
+		if strings.HasSuffix(wildcardPath, "*") {
+			c.isPrefix = true
+			c.path = strings.TrimSuffix(wildcardPath, "*")
+		}
+
+		parts := strings.Split(c.path, "/")
+		if len(pathParts) < len(parts) || (!c.isPrefix && len(pathParts) != len(parts)) {
+			continue
+		}
+
+		for i, part := range parts {
+			switch {
+			case part == "+":
+				c.numWildcards++
+			case part == pathParts[i]:
+			case c.isPrefix && i == len(parts)-1 && strings.HasPrefix(pathParts[i], part):
+			default:
+				continue WILDCARDS
+			}
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	if len(candidates) == 0 {
+		return nil, ""
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].less(candidates[j])
+	})
+	match := candidates[len(candidates)-1]
+
+	return match.perms.list(), match.String()
+}
+
+// policyCapabilitiesAllow reports whether the capabilities allow the
+// operation.
+func policyCapabilitiesAllow(capabilities []string, operation string) bool {
+	if slices.Contains(capabilities, "deny") {
+		return false
+	}
+	return slices.Contains(capabilities, policyCheckRootPolicy) || slices.Contains(capabilities, operation)
+}
+
+func policyCheckDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	var policies []*Policy
+	var root bool
+	var names []string
+	for _, v := range d.Get(consts.FieldPolicies).([]interface{}) {
+		name := v.(string)
+		names = append(names, name)
+		if name == policyCheckRootPolicy {
+			root = true
+			continue
+		}
+
+		log.Printf("[DEBUG] Reading policy %q from Vault", name)
+		rules, err := client.Sys().GetPolicyWithContext(ctx, name)
+		if err != nil {
+			return diag.Errorf("error reading policy %q from Vault: %s", name, err)
+		}
+		if rules == "" {
+			return diag.Errorf("policy %q not found", name)
+		}
+
+		policy, err := parsePolicy(rules)
+		if err != nil {
+			return diag.Errorf("policy %q: %s", name, err)
+		}
+		policies = append(policies, policy)
+	}
+
+	for i, v := range d.Get(consts.FieldPolicyHCL).([]interface{}) {
+		rules := v.(string)
+		names = append(names, strconv.Itoa(helper.HashCodeString(rules)))
+		policy, err := parsePolicy(rules)
+		if err != nil {
+			return diag.Errorf("%s.%d: %s", consts.FieldPolicyHCL, i, err)
+		}
+		policies = append(policies, policy)
+	}
+
+	var ent *logical.Entity
+	var groups []*logical.Group
+	if entityID, ok := d.GetOk(consts.FieldEntityID); ok {
+		var err error
+		ent, groups, err = readPolicyTemplateEntity(ctx, client, entityID.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	acl, err := newPolicyACL(policies, ent, groups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	acl.root = root
+
+	token := d.Get(consts.FieldToken).(string)
+
+	requests := d.Get(consts.FieldRequest).([]interface{})
+	for _, r := range requests {
+		request := r.(map[string]interface{})
+		path := request[consts.FieldPath].(string)
+		operation := request[consts.FieldOperation].(string)
+
+		capabilities, matched := acl.capabilities(path, operation)
+		request[consts.FieldCapabilities] = capabilities
+		request[consts.FieldMatchedPath] = matched
+		request[consts.FieldAllowed] = policyCapabilitiesAllow(capabilities, operation)
+
+		if token != "" {
+			log.Printf("[DEBUG] Reading capabilities on %q from Vault", path)
+			vaultCapabilities, err := client.Sys().CapabilitiesWithContext(ctx, token, path)
+			if err != nil {
+				return diag.Errorf("error reading capabilities on %q from Vault: %s", path, err)
+			}
+			sort.Strings(vaultCapabilities)
+			request[consts.FieldVaultCapabilities] = vaultCapabilities
+			request[consts.FieldVaultAllowed] = policyCapabilitiesAllow(vaultCapabilities, operation)
+		}
+	}
+
+	d.SetId(strconv.Itoa(helper.HashCodeString(strings.Join(names, ","))))
+
+	if err := d.Set(consts.FieldRequest, requests); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldUnresolvedPaths, acl.unresolved); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// readPolicyTemplateEntity reads the entity and the groups it belongs to, as
+// used by Vault to render templated policy paths.
+func readPolicyTemplateEntity(ctx context.Context, client *api.Client, entityID string) (*logical.Entity, []*logical.Group, error) {
+	path := entity.JoinEntityID(entityID)
+	log.Printf("[DEBUG] Reading IdentityEntity %q from %q", entityID, path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading IdentityEntity %q: %w", entityID, err)
+	}
+	if resp == nil {
+		return nil, nil, fmt.Errorf("IdentityEntity %q not found", entityID)
+	}
+
+	var e entity.Entity
+	if err := mapstructure.Decode(resp.Data, &e); err != nil {
+		return nil, nil, err
+	}
+
+	ent := &logical.Entity{
+		ID:          e.ID,
+		Name:        e.Name,
+		Metadata:    policyTemplateMetadata(e.Metadata),
+		NamespaceID: e.NamespaceId,
+	}
+	for _, a := range e.Aliases {
+		ent.Aliases = append(ent.Aliases, &logical.Alias{
+			ID:             a.ID,
+			Name:           a.Name,
+			MountAccessor:  a.MountAccessor,
+			MountType:      a.MountType,
+			Metadata:       policyTemplateMetadata(a.Metadata),
+			CustomMetadata: policyTemplateMetadata(a.CustomMetadata),
+		})
+	}
+
+	var groups []*logical.Group
+	for _, id := range e.GroupIds {
+		groupID, ok := id.(string)
+		if !ok {
+			continue
+		}
+
+		resp, err := group.ReadIdentityGroup(client, groupID, false)
+		if err != nil {
+			if group.IsIdentityNotFoundError(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("error reading IdentityGroup %q: %w", groupID, err)
+		}
+		if resp == nil {
+			continue
+		}
+
+		name, _ := resp.Data[consts.FieldName].(string)
+		namespaceID, _ := resp.Data["namespace_id"].(string)
+		groups = append(groups, &logical.Group{
+			ID:          groupID,
+			Name:        name,
+			Metadata:    policyTemplateMetadata(resp.Data["metadata"]),
+			NamespaceID: namespaceID,
+		})
+	}
+
+	return ent, groups, nil
+}
+
+func policyTemplateMetadata(v interface{}) map[string]string {
+	result := map[string]string{}
+	switch m := v.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			result[k] = fmt.Sprint(v)
+		}
+	case map[string]string:
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
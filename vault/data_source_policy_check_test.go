@@ -0,0 +1,398 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/vault/sdk/logical"
+
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourcePolicyCheck(t *testing.T) {
+	testutil.SkipTestAcc(t)
+
+	name := acctest.RandomWithPrefix("test-policy-check")
+	dataSourceName := "data.vault_policy_check.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourcePolicyCheckConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "request.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.0.allowed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.0.matched_path", "secret/data/+/config"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.0.vault_allowed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.1.allowed", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.1.capabilities.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.1.capabilities.0", "deny"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.1.vault_allowed", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.2.allowed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.2.matched_path", "secret/metadata/app"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.2.vault_allowed", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.3.allowed", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "request.3.matched_path", ""),
+					resource.TestCheckResourceAttr(dataSourceName, "request.3.vault_allowed", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "unresolved_paths.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "unresolved_paths.0", "secret/data/{{identity.entity.name}}/*"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourcePolicyCheckConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_policy" "test" {
+  name   = "%[1]s"
+  policy = <<EOT
+path "secret/data/*" {
+  capabilities = ["read"]
+}
+
+path "secret/data/+/config" {
+  capabilities = ["read", "update"]
+}
+
+path "secret/data/admin/*" {
+  capabilities = ["deny"]
+}
+
+path "secret/metadata/app" {
+  capabilities = ["list"]
+}
+
+path "secret/data/{{identity.entity.name}}/*" {
+  capabilities = ["create"]
+}
+EOT
+}
+
+resource "vault_token" "test" {
+  policies = [vault_policy.test.name]
+  ttl      = "1h"
+}
+
+data "vault_policy_check" "test" {
+  policies = [vault_policy.test.name]
+  token    = vault_token.test.client_token
+
+  request {
+    path      = "secret/data/app/config"
+    operation = "update"
+  }
+
+  request {
+    path      = "secret/data/admin/root"
+    operation = "read"
+  }
+
+  request {
+    path      = "secret/metadata/app"
+    operation = "list"
+  }
+
+  request {
+    path      = "sys/mounts"
+    operation = "read"
+  }
+}
+`, name)
+}
+
+func TestPolicyACLCapabilities(t *testing.T) {
+	policies := []string{
+		`
+path "secret/*" {
+  capabilities = ["read"]
+}
+
+path "secret/data/+/config" {
+  capabilities = ["read", "update"]
+}
+
+path "secret/data/+/+" {
+  capabilities = ["create"]
+}
+
+path "secret/data/team/*" {
+  capabilities = ["list"]
+}
+
+path "secret/data/team/+/shared*" {
+  capabilities = ["patch"]
+}
+
+path "secret/data/admin/*" {
+  capabilities = ["deny"]
+}
+
+path "secret/metadata/app" {
+  capabilities = ["list"]
+}
+
+path "secret/metadata/shared" {
+  capabilities = ["read"]
+}
+
+path "secret/metadata/shared/" {
+  capabilities = ["list", "delete"]
+}
+
+path "/sys/mounts" {
+  capabilities = ["read"]
+}
+
+path "users/{{identity.entity.name}}/*" {
+  capabilities = ["update"]
+}
+
+path "aliases/{{identity.entity.aliases.auth_userpass_1234.name}}" {
+  capabilities = ["read"]
+}
+
+path "groups/{{identity.groups.names.ops.id}}" {
+  capabilities = ["read"]
+}
+`,
+		`
+path "secret/data/admin/*" {
+  capabilities = ["read", "update"]
+}
+
+path "secret/data/app/config" {
+  capabilities = ["delete"]
+}
+
+path "secret/data/app/config" {
+  capabilities = ["sudo"]
+}
+`,
+	}
+
+	var parsed []*Policy
+	for _, p := range policies {
+		policy, err := parsePolicy(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed = append(parsed, policy)
+	}
+
+	ent := &logical.Entity{
+		ID:   "entity-1",
+		Name: "alice",
+		Aliases: []*logical.Alias{
+			{MountAccessor: "auth_userpass_1234", Name: "alice-userpass"},
+		},
+	}
+
+	acl, err := newPolicyACL(parsed, ent, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUnresolved := []string{"groups/{{identity.groups.names.ops.id}}"}
+	if !reflect.DeepEqual(expectedUnresolved, acl.unresolved) {
+		t.Errorf("expected unresolved paths %v, got %v", expectedUnresolved, acl.unresolved)
+	}
+
+	tests := []struct {
+		path                 string
+		operation            string
+		expectedCapabilities []string
+		expectedMatch        string
+		expectedAllowed      bool
+	}{
+		{
+			// exact paths take precedence, rules for the same path are merged
+			path:                 "secret/data/app/config",
+			operation:            "delete",
+			expectedCapabilities: []string{"delete", "sudo"},
+			expectedMatch:        "secret/data/app/config",
+			expectedAllowed:      true,
+		},
+		{
+			// with the first wildcard at the same position, fewer + segments
+			// have a higher priority
+			path:                 "secret/data/other/config",
+			operation:            "update",
+			expectedCapabilities: []string{"read", "update"},
+			expectedMatch:        "secret/data/+/config",
+			expectedAllowed:      true,
+		},
+		{
+			// the longest prefix is chosen among globs
+			path:                 "secret/data/team/keys",
+			operation:            "list",
+			expectedCapabilities: []string{"list"},
+			expectedMatch:        "secret/data/team/*",
+			expectedAllowed:      true,
+		},
+		{
+			// both are prefixes starting at the same position, the one without
+			// + segments has a higher priority
+			path:                 "secret/data/team/a/shared-keys",
+			operation:            "patch",
+			expectedCapabilities: []string{"list"},
+			expectedMatch:        "secret/data/team/*",
+			expectedAllowed:      false,
+		},
+		{
+			// a segment wildcard only matches a single segment
+			path:                 "secret/data/app/nested/key",
+			operation:            "create",
+			expectedCapabilities: []string{"read"},
+			expectedMatch:        "secret/*",
+			expectedAllowed:      false,
+		},
+		{
+			// a wildcard later in the path has a higher priority than a glob
+			path:                 "secret/data/app/key",
+			operation:            "create",
+			expectedCapabilities: []string{"create"},
+			expectedMatch:        "secret/data/+/+",
+			expectedAllowed:      true,
+		},
+		{
+			// deny takes precedence when merging
+			path:                 "secret/data/admin/root",
+			operation:            "read",
+			expectedCapabilities: []string{"deny"},
+			expectedMatch:        "secret/data/admin/*",
+			expectedAllowed:      false,
+		},
+		{
+			// list operations fall back to the path without the trailing slash
+			path:                 "secret/metadata/app/",
+			operation:            "list",
+			expectedCapabilities: []string{"list"},
+			expectedMatch:        "secret/metadata/app",
+			expectedAllowed:      true,
+		},
+		{
+			// the path with the trailing slash takes precedence for list operations
+			path:                 "secret/metadata/shared/",
+			operation:            "list",
+			expectedCapabilities: []string{"delete", "list"},
+			expectedMatch:        "secret/metadata/shared/",
+			expectedAllowed:      true,
+		},
+		{
+			path:                 "secret/metadata/shared",
+			operation:            "list",
+			expectedCapabilities: []string{"delete", "list"},
+			expectedMatch:        "secret/metadata/shared/",
+			expectedAllowed:      true,
+		},
+		{
+			path:                 "secret/metadata/shared",
+			operation:            "read",
+			expectedCapabilities: []string{"read"},
+			expectedMatch:        "secret/metadata/shared",
+			expectedAllowed:      true,
+		},
+		{
+			path:                 "/sys/mounts",
+			operation:            "read",
+			expectedCapabilities: []string{"read"},
+			expectedMatch:        "sys/mounts",
+			expectedAllowed:      true,
+		},
+		{
+			path:                 "sys/auth",
+			operation:            "read",
+			expectedCapabilities: nil,
+			expectedMatch:        "",
+			expectedAllowed:      false,
+		},
+		{
+			path:                 "users/alice/keys",
+			operation:            "update",
+			expectedCapabilities: []string{"update"},
+			expectedMatch:        "users/alice/*",
+			expectedAllowed:      true,
+		},
+		{
+			path:                 "users/bob/keys",
+			operation:            "update",
+			expectedCapabilities: nil,
+			expectedMatch:        "",
+			expectedAllowed:      false,
+		},
+		{
+			path:                 "aliases/alice-userpass",
+			operation:            "read",
+			expectedCapabilities: []string{"read"},
+			expectedMatch:        "aliases/alice-userpass",
+			expectedAllowed:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path+"/"+tt.operation, func(t *testing.T) {
+			capabilities, match := acl.capabilities(tt.path, tt.operation)
+			if !reflect.DeepEqual(tt.expectedCapabilities, capabilities) {
+				t.Errorf("expected capabilities %v, got %v", tt.expectedCapabilities, capabilities)
+			}
+			if tt.expectedMatch != match {
+				t.Errorf("expected match %q, got %q", tt.expectedMatch, match)
+			}
+			if actual := policyCapabilitiesAllow(capabilities, tt.operation); actual != tt.expectedAllowed {
+				t.Errorf("expected allowed %t, got %t", tt.expectedAllowed, actual)
+			}
+		})
+	}
+}
+
+func TestPolicyACLRootAndErrors(t *testing.T) {
+	acl, err := newPolicyACL(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl.root = true
+
+	capabilities, _ := acl.capabilities("sys/raw/anything", "sudo")
+	if !policyCapabilitiesAllow(capabilities, "sudo") {
+		t.Errorf("expected the root policy to allow everything, got %v", capabilities)
+	}
+
+	policy, err := parsePolicy(`path "secret/+*" { capabilities = ["read"] }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newPolicyACL([]*Policy{policy}, nil, nil); err == nil {
+		t.Error("expected an error for the invalid use of wildcards")
+	}
+
+	policy, err = parsePolicy(`path "users/{{identity.entity.id}}" { capabilities = ["read"] }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl, err = newPolicyACL([]*Policy{policy}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"users/{{identity.entity.id}}"}; !reflect.DeepEqual(expected, acl.unresolved) {
+		t.Errorf("expected templated paths to be skipped without an entity, got %v", acl.unresolved)
+	}
+}
+
+func TestPolicyTemplateMetadata(t *testing.T) {
+	expected := map[string]string{"team": "payments", "count": "3"}
+	actual := policyTemplateMetadata(map[string]interface{}{"team": "payments", "count": 3})
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if actual := policyTemplateMetadata(nil); len(actual) != 0 {
+		t.Errorf("expected empty metadata, got %v", actual)
+	}
+}
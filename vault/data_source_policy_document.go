@@ -4,6 +4,7 @@
 package vault
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sort"
@@ -14,7 +15,9 @@ import (
 	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type Policy struct {
@@ -30,6 +33,11 @@ type PolicyRule struct {
 	RequiredParameters []string
 	AllowedParameters  map[string][]string
 	DeniedParameters   map[string][]string
+	// SubscribeEventTypes and SubscribeEventPaths restrict the events that
+	// can be subscribed to with the subscribe capability.
+	SubscribeEventTypes []string
+	SubscribeEventPaths []string
+	PaginationLimit     int
 }
 
 var allowedCapabilities = []string{
@@ -41,112 +49,142 @@ var allowedCapabilities = []string{
 	"sudo",
 	"deny",
 	"patch",
+	"subscribe",
 }
 
 func policyDocumentDataSource() *schema.Resource {
 	return &schema.Resource{
-		Read: provider.ReadWrapper(policyDocumentDataSourceRead),
+		ReadContext: provider.ReadContextWrapper(policyDocumentDataSourceRead),
 		Schema: map[string]*schema.Schema{
 			"rule": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Computed:    true,
 				Description: "The policy rule",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						consts.FieldPath: {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+				Elem:        policyRuleResource(),
+			},
 
-						"description": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+			"hcl": {
+				Type:     schema.TypeString,
+				Computed: true, Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 
-						"min_wrapping_ttl": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+			consts.FieldValidateIdentityTemplates: policyValidateIdentityTemplatesSchema(),
+		},
+	}
+}
 
-						"max_wrapping_ttl": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+// policyRuleResource is the schema of a rule block.
+func policyRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			consts.FieldPath: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
 
-						"capabilities": {
-							Type:     schema.TypeList,
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"min_wrapping_ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"max_wrapping_ttl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"capabilities": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: capabilityValidation,
+				},
+			},
+
+			"required_parameters": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"subscribe_event_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"subscribe_event_paths": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"pagination_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"allowed_parameter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
 							Required: true,
-							Elem: &schema.Schema{
-								Type:         schema.TypeString,
-								ValidateFunc: capabilityValidation,
-							},
 						},
 
-						"required_parameters": {
+						"value": {
 							Type:     schema.TypeList,
-							Optional: true,
+							Required: true,
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
 						},
+					},
+				},
+			},
 
-						"allowed_parameter": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"key": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-
-									"value": {
-										Type:     schema.TypeList,
-										Required: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-								},
-							},
+			"denied_parameter": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
 						},
 
-						"denied_parameter": {
+						"value": {
 							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"key": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-
-									"value": {
-										Type:     schema.TypeList,
-										Required: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-								},
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
 							},
 						},
 					},
 				},
 			},
-
-			"hcl": {
-				Type:     schema.TypeString,
-				Computed: true, Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
 		},
 	}
 }
 
-func policyDocumentDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+func policyDocumentDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	policy := &Policy{}
 
 	if rawRules, hasRawRules := d.GetOk("rule"); hasRawRules {
@@ -156,10 +194,11 @@ func policyDocumentDataSourceRead(d *schema.ResourceData, meta interface{}) erro
 		for i, ruleI := range rawRuleIntfs {
 			rawRule := ruleI.(map[string]interface{})
 			rule := &PolicyRule{
-				Path:           rawRule["path"].(string),
-				Description:    rawRule["description"].(string),
-				MinWrappingTTL: rawRule["min_wrapping_ttl"].(string),
-				MaxWrappingTTL: rawRule["max_wrapping_ttl"].(string),
+				Path:            rawRule["path"].(string),
+				Description:     rawRule["description"].(string),
+				MinWrappingTTL:  rawRule["min_wrapping_ttl"].(string),
+				MaxWrappingTTL:  rawRule["max_wrapping_ttl"].(string),
+				PaginationLimit: rawRule["pagination_limit"].(int),
 			}
 
 			if capabilityIntfs := rawRule["capabilities"].([]interface{}); len(capabilityIntfs) > 0 {
@@ -170,11 +209,19 @@ func policyDocumentDataSourceRead(d *schema.ResourceData, meta interface{}) erro
 				rule.RequiredParameters = policyDecodeConfigListOfStrings(reqParamIntfs)
 			}
 
+			if eventTypeIntfs := rawRule["subscribe_event_types"].([]interface{}); len(eventTypeIntfs) > 0 {
+				rule.SubscribeEventTypes = policyDecodeConfigListOfStrings(eventTypeIntfs)
+			}
+
+			if eventPathIntfs := rawRule["subscribe_event_paths"].([]interface{}); len(eventPathIntfs) > 0 {
+				rule.SubscribeEventPaths = policyDecodeConfigListOfStrings(eventPathIntfs)
+			}
+
 			if allowedParamIntfs := rawRule["allowed_parameter"].([]interface{}); len(allowedParamIntfs) > 0 {
 				var err error
 				rule.AllowedParameters, err = policyDecodeConfigListOfMapsOfListToString(allowedParamIntfs)
 				if err != nil {
-					return fmt.Errorf("error reading argument allowed_parameter: %s", err)
+					return diag.Errorf("error reading argument allowed_parameter: %s", err)
 				}
 			}
 
@@ -182,7 +229,7 @@ func policyDocumentDataSourceRead(d *schema.ResourceData, meta interface{}) erro
 				var err error
 				rule.DeniedParameters, err = policyDecodeConfigListOfMapsOfListToString(deniedParamIntfs)
 				if err != nil {
-					return fmt.Errorf("error reading argument denied_parameter: %s", err)
+					return diag.Errorf("error reading argument denied_parameter: %s", err)
 				}
 			}
 
@@ -194,12 +241,30 @@ func policyDocumentDataSourceRead(d *schema.ResourceData, meta interface{}) erro
 		policy.Rules = rules
 	}
 
+	if d.Get(consts.FieldValidateIdentityTemplates).(bool) {
+		paths := make([]string, len(policy.Rules))
+		for i, rule := range policy.Rules {
+			paths[i] = rule.Path
+		}
+
+		if policyPathsHaveTemplates(paths) {
+			client, err := provider.GetClient(d, meta)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if err := validatePolicyTemplates(ctx, client, paths); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	policyHCL := renderPolicy(policy)
 	log.Printf("[DEBUG] Policy HCL is: %s", policyHCL)
 
 	err := d.Set("hcl", policyHCL)
 	if err != nil {
-		return fmt.Errorf("failed to store policy hcl: %s", err)
+		return diag.Errorf("failed to store policy hcl: %s", err)
 	}
 	d.SetId(strconv.Itoa(helper.HashCodeString(policyHCL)))
 
@@ -291,6 +356,18 @@ func policyRenderPolicyRule(rule *PolicyRule) string {
 		renderedRule = fmt.Sprintf("%s  max_wrapping_ttl = \"%s\"\n", renderedRule, rule.MaxWrappingTTL)
 	}
 
+	if rule.SubscribeEventTypes != nil {
+		renderedRule = fmt.Sprintf("%s  subscribe_event_types = %s\n", renderedRule, policyRenderListOfStrings(rule.SubscribeEventTypes))
+	}
+
+	if rule.SubscribeEventPaths != nil {
+		renderedRule = fmt.Sprintf("%s  subscribe_event_paths = %s\n", renderedRule, policyRenderListOfStrings(rule.SubscribeEventPaths))
+	}
+
+	if rule.PaginationLimit != 0 {
+		renderedRule = fmt.Sprintf("%s  pagination_limit = %d\n", renderedRule, rule.PaginationLimit)
+	}
+
 	return fmt.Sprintf("%s}\n", renderedRule)
 }
 
@@ -84,6 +84,14 @@ data "vault_policy_document" "test" {
   rule {
     path                = "secret/test3/"
     capabilities        = ["read", "list"]
+    pagination_limit    = 100
+  }
+
+  rule {
+    path                  = "sys/events/subscribe/*"
+    capabilities          = ["read", "subscribe"]
+    subscribe_event_types = ["kv*"]
+    subscribe_event_paths = ["secret/test1/*"]
   }
 }
 `
@@ -119,6 +127,13 @@ path "secret/test2/*" {
 
 path "secret/test3/" {
   capabilities = ["read", "list"]
+  pagination_limit = 100
+}
+
+path "sys/events/subscribe/*" {
+  capabilities = ["read", "subscribe"]
+  subscribe_event_types = ["kv*"]
+  subscribe_event_paths = ["secret/test1/*"]
 }
 `
 
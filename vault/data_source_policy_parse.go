@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/helper"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+// policyPathKeys are the keys supported in a path block of a policy.
+var policyPathKeys = []string{
+	"policy",
+	"capabilities",
+	"required_parameters",
+	"allowed_parameters",
+	"denied_parameters",
+	"min_wrapping_ttl",
+	"max_wrapping_ttl",
+	"subscribe_event_types",
+	"subscribe_event_paths",
+	"pagination_limit",
+}
+
+// policyShorthandCapabilities maps the deprecated policy key of a path block
+// to the capabilities it grants, as Vault does.
+var policyShorthandCapabilities = map[string][]string{
+	"deny":  {"deny"},
+	"read":  {"read", "list"},
+	"write": {"read", "list", "create", "update", "delete"},
+	"sudo":  {"read", "list", "create", "update", "delete", "sudo"},
+}
+
+type policyPathHCL struct {
+	Policy              string                   `hcl:"policy"`
+	Capabilities        []string                 `hcl:"capabilities"`
+	RequiredParameters  []string                 `hcl:"required_parameters"`
+	AllowedParameters   map[string][]interface{} `hcl:"allowed_parameters"`
+	DeniedParameters    map[string][]interface{} `hcl:"denied_parameters"`
+	MinWrappingTTL      interface{}              `hcl:"min_wrapping_ttl"`
+	MaxWrappingTTL      interface{}              `hcl:"max_wrapping_ttl"`
+	SubscribeEventTypes []string                 `hcl:"subscribe_event_types"`
+	SubscribeEventPaths []string                 `hcl:"subscribe_event_paths"`
+	PaginationLimit     int                      `hcl:"pagination_limit"`
+}
+
+func policyParseDataSource() *schema.Resource {
+	return &schema.Resource{
+		Read: provider.ReadWrapper(policyParseDataSourceRead),
+		Schema: map[string]*schema.Schema{
+			"policy": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The policy document in HCL format.",
+			},
+
+			"rule": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rules of the policy, in the format of the vault_policy_document rule blocks.",
+				Elem:        policyComputedResource(policyRuleResource()),
+			},
+
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy rendered in the normalized format of vault_policy_document.",
+			},
+		},
+	}
+}
+
+func policyParseDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	policy, err := parsePolicy(d.Get("policy").(string))
+	if err != nil {
+		return err
+	}
+
+	rules := make([]map[string]interface{}, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		rules[i] = policyFlattenRule(rule)
+	}
+
+	if err := d.Set("rule", rules); err != nil {
+		return fmt.Errorf("failed to store policy rules: %s", err)
+	}
+
+	policyHCL := renderPolicy(policy)
+	log.Printf("[DEBUG] Normalized policy HCL is: %s", policyHCL)
+
+	if err := d.Set("hcl", policyHCL); err != nil {
+		return fmt.Errorf("failed to store policy hcl: %s", err)
+	}
+	d.SetId(strconv.Itoa(helper.HashCodeString(policyHCL)))
+
+	return nil
+}
+
+// parsePolicy parses an ACL policy in HCL format. Path blocks are returned in
+// the order of the document, the comment directly above a path block is
+// returned as its description.
+func parsePolicy(rules string) (*Policy, error) {
+	root, err := hcl.Parse(rules)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing policy: %s", err)
+	}
+
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing policy: does not contain a root object")
+	}
+
+	policy := &Policy{}
+	for _, item := range list.Items {
+		key, ok := policyItemKey(item)
+		if !ok {
+			return nil, fmt.Errorf("error parsing policy: unsupported key at line %d", item.Pos().Line)
+		}
+		if key == "name" {
+			// the name of the policy is ignored by Vault
+			continue
+		}
+		if key != "path" {
+			return nil, fmt.Errorf("error parsing policy: unsupported key %q", key)
+		}
+
+		if len(item.Keys) != 2 {
+			return nil, fmt.Errorf("error parsing policy: path block at line %d must have exactly one label",
+				item.Pos().Line)
+		}
+
+		rule, err := parsePolicyPath(item)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing policy: %s", err)
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+
+	return policy, nil
+}
+
+// policyItemKey returns the first key of the item, if it is a string.
+func policyItemKey(item *ast.ObjectItem) (string, bool) {
+	if len(item.Keys) == 0 {
+		return "", false
+	}
+
+	key, ok := item.Keys[0].Token.Value().(string)
+	return key, ok
+}
+
+func parsePolicyPath(item *ast.ObjectItem) (*PolicyRule, error) {
+	path, ok := item.Keys[1].Token.Value().(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid path at line %d", item.Pos().Line)
+	}
+
+	obj, ok := item.Val.(*ast.ObjectType)
+	if !ok {
+		return nil, fmt.Errorf("path %q: expected a block", path)
+	}
+
+	for _, k := range obj.List.Items {
+		key, ok := policyItemKey(k)
+		if !ok {
+			return nil, fmt.Errorf("path %q: unsupported key at line %d", path, k.Pos().Line)
+		}
+		if !slices.Contains(policyPathKeys, key) {
+			return nil, fmt.Errorf("path %q: unsupported key %q", path, key)
+		}
+	}
+
+	var pc policyPathHCL
+	if err := hcl.DecodeObject(&pc, item.Val); err != nil {
+		return nil, fmt.Errorf("path %q: %s", path, err)
+	}
+
+	rule := &PolicyRule{
+		Path:                path,
+		Capabilities:        pc.Capabilities,
+		RequiredParameters:  pc.RequiredParameters,
+		SubscribeEventTypes: pc.SubscribeEventTypes,
+		SubscribeEventPaths: pc.SubscribeEventPaths,
+		PaginationLimit:     pc.PaginationLimit,
+	}
+
+	if item.LeadComment != nil {
+		var lines []string
+		for _, c := range item.LeadComment.List {
+			text := strings.TrimPrefix(strings.TrimPrefix(c.Text, "#"), "//")
+			lines = append(lines, strings.TrimSpace(text))
+		}
+		rule.Description = strings.Join(lines, " ")
+	}
+
+	if pc.Policy != "" {
+		capabilities, ok := policyShorthandCapabilities[pc.Policy]
+		if !ok {
+			return nil, fmt.Errorf("path %q: invalid policy %q", path, pc.Policy)
+		}
+		for _, c := range capabilities {
+			if !slices.Contains(rule.Capabilities, c) {
+				rule.Capabilities = append(rule.Capabilities, c)
+			}
+		}
+	}
+
+	for _, c := range rule.Capabilities {
+		if !slices.Contains(allowedCapabilities, c) {
+			return nil, fmt.Errorf("path %q: invalid capability %q", path, c)
+		}
+	}
+
+	if pc.MinWrappingTTL != nil {
+		rule.MinWrappingTTL = fmt.Sprint(pc.MinWrappingTTL)
+	}
+	if pc.MaxWrappingTTL != nil {
+		rule.MaxWrappingTTL = fmt.Sprint(pc.MaxWrappingTTL)
+	}
+
+	if pc.AllowedParameters != nil {
+		rule.AllowedParameters = policyParameterStrings(pc.AllowedParameters)
+	}
+	if pc.DeniedParameters != nil {
+		rule.DeniedParameters = policyParameterStrings(pc.DeniedParameters)
+	}
+
+	return rule, nil
+}
+
+func policyParameterStrings(input map[string][]interface{}) map[string][]string {
+	output := make(map[string][]string, len(input))
+	for k, values := range input {
+		output[k] = make([]string, len(values))
+		for i, v := range values {
+			output[k][i] = fmt.Sprint(v)
+		}
+	}
+	return output
+}
+
+// policyFlattenRule converts the rule to the format of a rule block.
+func policyFlattenRule(rule *PolicyRule) map[string]interface{} {
+	return map[string]interface{}{
+		"path":                  rule.Path,
+		"description":           rule.Description,
+		"min_wrapping_ttl":      rule.MinWrappingTTL,
+		"max_wrapping_ttl":      rule.MaxWrappingTTL,
+		"capabilities":          rule.Capabilities,
+		"required_parameters":   rule.RequiredParameters,
+		"subscribe_event_types": rule.SubscribeEventTypes,
+		"subscribe_event_paths": rule.SubscribeEventPaths,
+		"pagination_limit":      rule.PaginationLimit,
+		"allowed_parameter":     policyFlattenParameters(rule.AllowedParameters),
+		"denied_parameter":      policyFlattenParameters(rule.DeniedParameters),
+	}
+}
+
+func policyFlattenParameters(input map[string][]string) []map[string]interface{} {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	output := make([]map[string]interface{}, len(keys))
+	for i, k := range keys {
+		output[i] = map[string]interface{}{
+			"key":   k,
+			"value": input[k],
+		}
+	}
+	return output
+}
+
+// policyComputedResource returns a copy of the resource schema with all its
+// fields computed, for data sources that export a block of another one.
+func policyComputedResource(r *schema.Resource) *schema.Resource {
+	result := make(map[string]*schema.Schema, len(r.Schema))
+	for k, v := range r.Schema {
+		s := &schema.Schema{
+			Type:        v.Type,
+			Computed:    true,
+			Description: v.Description,
+			Elem:        v.Elem,
+		}
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			s.Elem = policyComputedResource(elem)
+		} else if elem, ok := v.Elem.(*schema.Schema); ok {
+			s.Elem = &schema.Schema{Type: elem.Type}
+		}
+		result[k] = s
+	}
+
+	return &schema.Resource{Schema: result}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl/hcl/token"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestDataSourcePolicyParse(t *testing.T) {
+	dataSourceName := "data.vault_policy_parse.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "vault_policy_parse" "test" {
+  policy = <<EOT
+%sEOT
+}
+`, testResultPolicyHCLDocument),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "hcl", testResultPolicyHCLDocument),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.#", "5"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.path", "secret/test1/*"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.description", "test rule 1"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.capabilities.#", "6"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.allowed_parameter.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.allowed_parameter.0.key", "eggs"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.allowed_parameter.0.value.1", "bar"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.0.max_wrapping_ttl", "1h"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.2.pagination_limit", "100"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule.3.subscribe_event_types.0", "kv*"),
+				),
+			},
+			{
+				// the parsed rules can be passed back to vault_policy_document
+				Config: fmt.Sprintf(`
+data "vault_policy_parse" "test" {
+  policy = <<EOT
+%sEOT
+}
+
+data "vault_policy_document" "test" {
+  dynamic "rule" {
+    for_each = data.vault_policy_parse.test.rule
+    content {
+      path                  = rule.value.path
+      description           = rule.value.description
+      capabilities          = rule.value.capabilities
+      required_parameters   = rule.value.required_parameters
+      min_wrapping_ttl      = rule.value.min_wrapping_ttl
+      max_wrapping_ttl      = rule.value.max_wrapping_ttl
+      subscribe_event_types = rule.value.subscribe_event_types
+      subscribe_event_paths = rule.value.subscribe_event_paths
+      pagination_limit      = rule.value.pagination_limit == 0 ? null : rule.value.pagination_limit
+
+      dynamic "allowed_parameter" {
+        for_each = rule.value.allowed_parameter
+        content {
+          key   = allowed_parameter.value.key
+          value = allowed_parameter.value.value
+        }
+      }
+
+      dynamic "denied_parameter" {
+        for_each = rule.value.denied_parameter
+        content {
+          key   = denied_parameter.value.key
+          value = denied_parameter.value.value
+        }
+      }
+    }
+  }
+}
+`, testResultPolicyHCLDocument),
+				Check: resource.TestCheckResourceAttr("data.vault_policy_document.test", "hcl", testResultPolicyHCLDocument),
+			},
+			{
+				Config: `
+data "vault_policy_parse" "test" {
+  policy = <<EOT
+path "secret/*" {
+  capabilities = ["read"]
+  control = "none"
+}
+EOT
+}
+`,
+				ExpectError: regexp.MustCompile(`path "secret/\*": unsupported key "control"`),
+			},
+		},
+	})
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    string
+		expected  *Policy
+		expectErr string
+	}{
+		{
+			name: "basic",
+			policy: `
+name = "ignored"
+
+// read access
+path "secret/data/*" {
+  capabilities = ["read", "list"]
+  min_wrapping_ttl = 300
+  allowed_parameters {
+    "version" = [1, 2]
+  }
+  denied_parameters = {
+    "*" = []
+  }
+}
+
+path "sys/events/subscribe/*" {
+  capabilities = ["subscribe"]
+  subscribe_event_types = ["kv*"]
+  subscribe_event_paths = ["secret/*"]
+  pagination_limit = 50
+}
+`,
+			expected: &Policy{
+				Rules: []*PolicyRule{
+					{
+						Path:              "secret/data/*",
+						Description:       "read access",
+						Capabilities:      []string{"read", "list"},
+						MinWrappingTTL:    "300",
+						AllowedParameters: map[string][]string{"version": {"1", "2"}},
+						DeniedParameters:  map[string][]string{"*": {}},
+					},
+					{
+						Path:                "sys/events/subscribe/*",
+						Capabilities:        []string{"subscribe"},
+						SubscribeEventTypes: []string{"kv*"},
+						SubscribeEventPaths: []string{"secret/*"},
+						PaginationLimit:     50,
+					},
+				},
+			},
+		},
+		{
+			name: "shorthand",
+			policy: `
+path "secret/*" {
+  policy = "write"
+  capabilities = ["read", "patch"]
+}
+`,
+			expected: &Policy{
+				Rules: []*PolicyRule{
+					{
+						Path:         "secret/*",
+						Capabilities: []string{"read", "patch", "list", "create", "update", "delete"},
+					},
+				},
+			},
+		},
+		{
+			name:     "empty",
+			policy:   "",
+			expected: &Policy{},
+		},
+		{
+			name:      "invalid-hcl",
+			policy:    `path "secret/*" {`,
+			expectErr: "error parsing policy",
+		},
+		{
+			name:      "unsupported-root-key",
+			policy:    `rule "secret/*" {}`,
+			expectErr: `unsupported key "rule"`,
+		},
+		{
+			name:      "invalid-capability",
+			policy:    `path "secret/*" { capabilities = ["write"] }`,
+			expectErr: `invalid capability "write"`,
+		},
+		{
+			name:      "invalid-shorthand",
+			policy:    `path "secret/*" { policy = "admin" }`,
+			expectErr: `invalid policy "admin"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parsePolicy(tt.policy)
+			if tt.expectErr != "" {
+				if err == nil {
+					t.Fatalf("expected an error containing %q", tt.expectErr)
+				}
+				if !regexp.MustCompile(regexp.QuoteMeta(tt.expectErr)).MatchString(err.Error()) {
+					t.Fatalf("expected an error containing %q, got %s", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Errorf("expected %#v, got %#v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPolicyItemKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     *ast.ObjectItem
+		expected string
+		ok       bool
+	}{
+		{
+			name: "ident",
+			item: &ast.ObjectItem{Keys: []*ast.ObjectKey{
+				{Token: token.Token{Type: token.IDENT, Text: "path"}},
+			}},
+			expected: "path",
+			ok:       true,
+		},
+		{
+			name: "no-keys",
+			item: &ast.ObjectItem{},
+		},
+		{
+			name: "number",
+			item: &ast.ObjectItem{Keys: []*ast.ObjectKey{
+				{Token: token.Token{Type: token.NUMBER, Text: "1"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, ok := policyItemKey(tt.item)
+			if actual != tt.expected || ok != tt.ok {
+				t.Errorf("expected (%q, %t), got (%q, %t)", tt.expected, tt.ok, actual, ok)
+			}
+		})
+	}
+}
+
+func TestParsePolicyRoundTrip(t *testing.T) {
+	policy, err := parsePolicy(testResultPolicyHCLDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual := renderPolicy(policy); actual != testResultPolicyHCLDocument {
+		t.Errorf("expected %s, got %s", testResultPolicyHCLDocument, actual)
+	}
+}
@@ -0,0 +1,632 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/vault/api"
+	"github.com/hashicorp/vault/sdk/helper/certutil"
+	"github.com/mitchellh/pointerstructure"
+	"github.com/ryanuber/go-glob"
+)
+
+const (
+	jwtVerifyCheckPublicKeys  = "public_keys"
+	jwtVerifyCheckAlgorithms  = "algorithms"
+	jwtVerifyCheckBoundIssuer = "bound_issuer"
+	jwtVerifyCheckSignature   = "sample_jwt_signature"
+	jwtVerifyCheckIssuer      = "sample_jwt_issuer"
+
+	jwtVerifyTimeout     = 30 * time.Second
+	jwtVerifyMaxBodySize = 1 << 20
+)
+
+// jwtDefaultSupportedAlgs are the signing algorithms accepted by the JWT auth
+// engine at login when jwt_supported_algs is not set.
+var jwtDefaultSupportedAlgs = []string{
+	"RS256", "RS384", "RS512",
+	"ES256", "ES384", "ES512",
+	"PS256", "PS384", "PS512",
+	"EdDSA",
+}
+
+// jwtVerifyConfig is the configuration of the JWT auth backend to verify.
+type jwtVerifyConfig struct {
+	oidcDiscoveryURL   string
+	oidcDiscoveryCAPEM string
+	jwksURL            string
+	jwksCAPEM          string
+	pubKeys            []string
+	boundIssuer        string
+	supportedAlgs      []string
+}
+
+type jwtVerifyRoleResult struct {
+	name     string
+	accepted bool
+	message  string
+	metadata map[string]string
+}
+
+// jwtVerifyReport collects the results of the verification of a JWT auth
+// backend configuration.
+type jwtVerifyReport struct {
+	checks []*oidcConformanceCheck
+	roles  []*jwtVerifyRoleResult
+}
+
+func (r *jwtVerifyReport) pass(name, format string, a ...interface{}) {
+	r.checks = append(r.checks, &oidcConformanceCheck{name: name, passed: true, message: fmt.Sprintf(format, a...)})
+}
+
+func (r *jwtVerifyReport) fail(name, format string, a ...interface{}) {
+	r.checks = append(r.checks, &oidcConformanceCheck{name: name, message: fmt.Sprintf(format, a...)})
+}
+
+// passed returns whether all the checks passed.
+func (r *jwtVerifyReport) passed() bool {
+	for _, c := range r.checks {
+		if !c.passed {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *jwtVerifyReport) acceptedRoles() []string {
+	var names []string
+	for _, role := range r.roles {
+		if role.accepted {
+			names = append(names, role.name)
+		}
+	}
+	return names
+}
+
+// jwtVerifyConfigFromData returns the configuration to verify from the
+// configuration of a JWT auth backend read from Vault.
+func jwtVerifyConfigFromData(data map[string]interface{}) *jwtVerifyConfig {
+	config := &jwtVerifyConfig{
+		pubKeys:       jwtVerifyStringList(data["jwt_validation_pubkeys"]),
+		supportedAlgs: jwtVerifyStringList(data["jwt_supported_algs"]),
+	}
+	config.oidcDiscoveryURL, _ = data["oidc_discovery_url"].(string)
+	config.oidcDiscoveryCAPEM, _ = data["oidc_discovery_ca_pem"].(string)
+	config.jwksURL, _ = data["jwks_url"].(string)
+	config.jwksCAPEM, _ = data["jwks_ca_pem"].(string)
+	config.boundIssuer, _ = data["bound_issuer"].(string)
+
+	return config
+}
+
+// jwtVerifyReadRoles reads the roles with the given names from the JWT auth
+// backend mounted at path, or all its roles if no names are given. Roles that
+// do not exist are returned as nil.
+func jwtVerifyReadRoles(client *api.Client, path string, names []string) (map[string]map[string]interface{}, error) {
+	if len(names) == 0 {
+		resp, err := client.Logical().List(fmt.Sprintf("auth/%s/role", path))
+		if err != nil {
+			return nil, fmt.Errorf("error listing the roles of JWT auth backend %q: %w", path, err)
+		}
+		if resp != nil {
+			if keys, ok := resp.Data["keys"].([]interface{}); ok {
+				for _, k := range keys {
+					names = append(names, k.(string))
+				}
+			}
+		}
+	}
+
+	roles := map[string]map[string]interface{}{}
+	for _, name := range names {
+		resp, err := client.Logical().Read(jwtAuthBackendRolePath(path, name))
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT auth backend role %q: %w", name, err)
+		}
+		if resp == nil {
+			roles[name] = nil
+			continue
+		}
+		roles[name] = resp.Data
+	}
+
+	return roles, nil
+}
+
+// runJWTAuthBackendVerify checks that the keys of the configuration can be
+// fetched and support the configured algorithms, and that the sample JWT, if
+// any, is signed by them. The claims of the sample JWT are returned if all the
+// checks passed.
+func runJWTAuthBackendVerify(ctx context.Context, config *jwtVerifyConfig, sampleJWT string) (*jwtVerifyReport, map[string]interface{}) {
+	report := &jwtVerifyReport{}
+
+	algs := config.supportedAlgs
+	if len(algs) == 0 {
+		algs = jwtDefaultSupportedAlgs
+	}
+
+	var keys []jose.JSONWebKey
+	switch {
+	case config.oidcDiscoveryURL != "":
+		client, err := jwtVerifyHTTPClient(config.oidcDiscoveryCAPEM)
+		if err != nil {
+			report.fail(oidcCheckDiscovery, "invalid oidc_discovery_ca_pem: %s", err)
+			return report, nil
+		}
+
+		var discovery struct {
+			Issuer             string   `json:"issuer"`
+			JWKSURI            string   `json:"jwks_uri"`
+			IDTokenSigningAlgs []string `json:"id_token_signing_alg_values_supported"`
+		}
+		wellKnown := strings.TrimSuffix(config.oidcDiscoveryURL, "/") + "/.well-known/openid-configuration"
+		if err := jwtVerifyFetchJSON(ctx, client, wellKnown, &discovery); err != nil {
+			report.fail(oidcCheckDiscovery, "%s", err)
+			return report, nil
+		}
+		if discovery.Issuer != config.oidcDiscoveryURL {
+			report.fail(oidcCheckDiscovery, "issuer %q does not match oidc_discovery_url %q",
+				discovery.Issuer, config.oidcDiscoveryURL)
+			return report, nil
+		}
+		if discovery.JWKSURI == "" {
+			report.fail(oidcCheckDiscovery, "the discovery document has no jwks_uri")
+			return report, nil
+		}
+		report.pass(oidcCheckDiscovery, "issuer %q", discovery.Issuer)
+
+		if config.boundIssuer != "" {
+			if config.boundIssuer != discovery.Issuer {
+				report.fail(jwtVerifyCheckBoundIssuer, "bound_issuer %q does not match the issuer %q of the provider",
+					config.boundIssuer, discovery.Issuer)
+				return report, nil
+			}
+			report.pass(jwtVerifyCheckBoundIssuer, "%q", config.boundIssuer)
+		}
+
+		if len(discovery.IDTokenSigningAlgs) > 0 {
+			var common []string
+			for _, alg := range algs {
+				if slices.Contains(discovery.IDTokenSigningAlgs, alg) {
+					common = append(common, alg)
+				}
+			}
+			if len(common) == 0 {
+				report.fail(jwtVerifyCheckAlgorithms, "none of the algorithms %v are supported by the provider, "+
+					"which supports %v", algs, discovery.IDTokenSigningAlgs)
+				return report, nil
+			}
+			algs = common
+		}
+
+		var jwks jose.JSONWebKeySet
+		if err := jwtVerifyFetchJSON(ctx, client, discovery.JWKSURI, &jwks); err != nil {
+			report.fail(oidcCheckJWKS, "%s", err)
+			return report, nil
+		}
+		keys = jwks.Keys
+	case config.jwksURL != "":
+		client, err := jwtVerifyHTTPClient(config.jwksCAPEM)
+		if err != nil {
+			report.fail(oidcCheckJWKS, "invalid jwks_ca_pem: %s", err)
+			return report, nil
+		}
+
+		var jwks jose.JSONWebKeySet
+		if err := jwtVerifyFetchJSON(ctx, client, config.jwksURL, &jwks); err != nil {
+			report.fail(oidcCheckJWKS, "%s", err)
+			return report, nil
+		}
+		keys = jwks.Keys
+	case len(config.pubKeys) > 0:
+		for i, v := range config.pubKeys {
+			key, err := certutil.ParsePublicKeyPEM([]byte(v))
+			if err != nil {
+				report.fail(jwtVerifyCheckPublicKeys, "invalid public key %d: %s", i, err)
+				return report, nil
+			}
+			keys = append(keys, jose.JSONWebKey{Key: key})
+		}
+		report.pass(jwtVerifyCheckPublicKeys, "%d keys", len(keys))
+	default:
+		report.fail(jwtVerifyCheckPublicKeys, "one of oidc_discovery_url, jwks_url or jwt_validation_pubkeys must be set")
+		return report, nil
+	}
+
+	if config.oidcDiscoveryURL != "" || config.jwksURL != "" {
+		if len(keys) == 0 {
+			report.fail(oidcCheckJWKS, "the key set is empty")
+			return report, nil
+		}
+		report.pass(oidcCheckJWKS, "%d keys", len(keys))
+	}
+
+	if config.oidcDiscoveryURL == "" && config.boundIssuer != "" {
+		report.pass(jwtVerifyCheckBoundIssuer, "%q", config.boundIssuer)
+	}
+
+	var usable []string
+	for _, alg := range algs {
+		for _, key := range keys {
+			if jwtKeySupportsAlg(key, alg) {
+				usable = append(usable, alg)
+				break
+			}
+		}
+	}
+	if len(usable) == 0 {
+		report.fail(jwtVerifyCheckAlgorithms, "none of the keys can verify the algorithms %v", algs)
+		return report, nil
+	}
+	report.pass(jwtVerifyCheckAlgorithms, "%s", strings.Join(usable, ", "))
+
+	if sampleJWT == "" {
+		return report, nil
+	}
+
+	claims, ok := verifyJWTSample(report, sampleJWT, keys, usable)
+	if !ok {
+		return report, nil
+	}
+
+	if config.boundIssuer != "" {
+		if iss, _ := claims["iss"].(string); iss != config.boundIssuer {
+			report.fail(jwtVerifyCheckIssuer, "iss claim %q does not match bound_issuer %q", iss, config.boundIssuer)
+			return report, nil
+		}
+		report.pass(jwtVerifyCheckIssuer, "%q", config.boundIssuer)
+	}
+
+	return report, claims
+}
+
+// verifyJWTSample verifies the signature of the sample JWT with the keys, and
+// returns its claims.
+func verifyJWTSample(report *jwtVerifyReport, sampleJWT string, keys []jose.JSONWebKey, algs []string) (map[string]interface{}, bool) {
+	jws, err := jose.ParseSigned(sampleJWT)
+	if err != nil {
+		report.fail(jwtVerifyCheckSignature, "invalid JWT: %s", err)
+		return nil, false
+	}
+	if len(jws.Signatures) != 1 {
+		report.fail(jwtVerifyCheckSignature, "expected 1 signature, got %d", len(jws.Signatures))
+		return nil, false
+	}
+
+	h := jws.Signatures[0].Header
+	if !slices.Contains(algs, h.Algorithm) {
+		report.fail(jwtVerifyCheckSignature, "algorithm %q is not one of the supported algorithms %v", h.Algorithm, algs)
+		return nil, false
+	}
+
+	var payload []byte
+	for _, key := range keys {
+		if h.KeyID != "" && key.KeyID != "" && h.KeyID != key.KeyID {
+			continue
+		}
+		if payload, err = jws.Verify(key.Key); err == nil {
+			break
+		}
+	}
+	if payload == nil {
+		if h.KeyID != "" {
+			report.fail(jwtVerifyCheckSignature, "none of the keys verify the signature with key ID %q", h.KeyID)
+		} else {
+			report.fail(jwtVerifyCheckSignature, "none of the keys verify the signature")
+		}
+		return nil, false
+	}
+
+	// the claims are decoded without json.Number, the way the JWT auth
+	// engine decodes them.
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		report.fail(jwtVerifyCheckSignature, "invalid claims: %s", err)
+		return nil, false
+	}
+	report.pass(jwtVerifyCheckSignature, "%s", h.Algorithm)
+
+	return claims, true
+}
+
+// evaluateJWTAuthBackendRole evaluates the claims of a JWT against a role the
+// way the JWT auth engine does at login. The expiry of the JWT and the
+// token_bound_cidrs of the role are not evaluated.
+func evaluateJWTAuthBackendRole(name string, role map[string]interface{}, claims map[string]interface{}) *jwtVerifyRoleResult {
+	result := &jwtVerifyRoleResult{
+		name:     name,
+		metadata: map[string]string{},
+	}
+	if role == nil {
+		result.message = "role not found"
+		return result
+	}
+
+	if roleType, _ := role["role_type"].(string); roleType == "oidc" {
+		result.message = "role with oidc role_type is not allowed"
+		return result
+	}
+
+	if subject, _ := role["bound_subject"].(string); subject != "" {
+		if sub, _ := claims["sub"].(string); sub != subject {
+			result.message = fmt.Sprintf("sub claim %q does not match bound_subject %q", sub, subject)
+			return result
+		}
+	}
+
+	boundAudiences := jwtVerifyStringList(role["bound_audiences"])
+	audiences := jwtVerifyStringList(claims["aud"])
+	if len(boundAudiences) > 0 {
+		found := false
+		for _, aud := range boundAudiences {
+			if slices.Contains(audiences, aud) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.message = "aud claim does not match any bound audience"
+			return result
+		}
+	} else if len(audiences) > 0 {
+		result.message = "audience claim found in JWT but no audiences bound to the role"
+		return result
+	}
+
+	userClaim, _ := role["user_claim"].(string)
+	var userClaimRaw interface{}
+	if pointer, _ := role["user_claim_json_pointer"].(bool); pointer {
+		userClaimRaw = getJWTClaim(claims, userClaim)
+	} else {
+		userClaimRaw = claims[userClaim]
+	}
+	if userClaimRaw == nil {
+		result.message = fmt.Sprintf("claim %q not found in token", userClaim)
+		return result
+	}
+	if _, ok := userClaimRaw.(string); !ok {
+		result.message = fmt.Sprintf("claim %q could not be converted to string", userClaim)
+		return result
+	}
+
+	if mappings, ok := role["claim_mappings"].(map[string]interface{}); ok {
+		for source, target := range mappings {
+			value := getJWTClaim(claims, source)
+			if value == nil {
+				continue
+			}
+			s, ok := value.(string)
+			if !ok {
+				result.message = fmt.Sprintf("error converting claim '%s' to string", source)
+				result.metadata = map[string]string{}
+				return result
+			}
+			result.metadata[fmt.Sprint(target)] = s
+		}
+	}
+	result.metadata["role"] = name
+
+	if groupsClaim, _ := role["groups_claim"].(string); groupsClaim != "" {
+		groupsRaw := getJWTClaim(claims, groupsClaim)
+		if groupsRaw == nil {
+			result.message = fmt.Sprintf("%q claim not found in token", groupsClaim)
+			return result
+		}
+		groups, ok := normalizeJWTClaimList(groupsRaw)
+		if !ok {
+			result.message = fmt.Sprintf("%q claim could not be converted to string list", groupsClaim)
+			return result
+		}
+		for _, g := range groups {
+			if _, ok := g.(string); !ok {
+				result.message = fmt.Sprintf("value %v in groups claim could not be parsed as string", g)
+				return result
+			}
+		}
+	}
+
+	if boundClaims, ok := role["bound_claims"].(map[string]interface{}); ok {
+		boundClaimsType, _ := role["bound_claims_type"].(string)
+		if err := validateJWTBoundClaims(boundClaimsType, boundClaims, claims); err != nil {
+			result.message = fmt.Sprintf("error validating claims: %s", err)
+			return result
+		}
+	}
+
+	result.accepted = true
+	return result
+}
+
+// validateJWTBoundClaims checks that all the bound claims are matched by the
+// claims.
+func validateJWTBoundClaims(boundClaimsType string, boundClaims, claims map[string]interface{}) error {
+	useGlobs := boundClaimsType == "glob"
+
+	for _, claim := range jwtVerifySortedKeys(boundClaims) {
+		actual := getJWTClaim(claims, claim)
+		if actual == nil {
+			return fmt.Errorf("claim %q is missing", claim)
+		}
+
+		actualValues, ok := normalizeJWTClaimList(actual)
+		if !ok {
+			return fmt.Errorf("received claim is not a string or list: %v", actual)
+		}
+
+		expectedValues, ok := normalizeJWTClaimList(boundClaims[claim])
+		if !ok {
+			return fmt.Errorf("bound claim is not a string or list: %v", boundClaims[claim])
+		}
+
+		found := false
+		for _, expected := range expectedValues {
+			for _, actual := range actualValues {
+				if useGlobs {
+					expectedStr, ok := expected.(string)
+					if !ok {
+						return fmt.Errorf("bound claim is not a glob string: %v", expected)
+					}
+					if actualStr, ok := actual.(string); ok && glob.Glob(expectedStr, actualStr) {
+						found = true
+					}
+				} else if actual == expected {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("claim %q does not match any associated bound claim values", claim)
+		}
+	}
+
+	return nil
+}
+
+// getJWTClaim returns the claim, which is a JSON pointer if it starts with a
+// slash.
+func getJWTClaim(claims map[string]interface{}, claim string) interface{} {
+	if !strings.HasPrefix(claim, "/") {
+		return claims[claim]
+	}
+
+	v, err := pointerstructure.Get(claims, claim)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// normalizeJWTClaimList returns a string, bool or list as a list.
+func normalizeJWTClaimList(raw interface{}) ([]interface{}, bool) {
+	switch v := raw.(type) {
+	case []interface{}:
+		return v, true
+	case string, bool:
+		return []interface{}{v}, true
+	default:
+		return nil, false
+	}
+}
+
+func jwtVerifyStringList(raw interface{}) []string {
+	var values []string
+	switch v := raw.(type) {
+	case string:
+		values = append(values, v)
+	case []interface{}:
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func jwtVerifySortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jwtKeySupportsAlg returns whether the key can verify signatures made with
+// the algorithm.
+func jwtKeySupportsAlg(key jose.JSONWebKey, alg string) bool {
+	if key.Algorithm != "" && key.Algorithm != alg {
+		return false
+	}
+
+	switch k := key.Key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		switch alg {
+		case "ES256":
+			return k.Curve == elliptic.P256()
+		case "ES384":
+			return k.Curve == elliptic.P384()
+		case "ES512":
+			return k.Curve == elliptic.P521()
+		}
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+
+	return false
+}
+
+// jwtVerifyHTTPClient returns an HTTP client that trusts the PEM encoded CA
+// certificates, or the system certificates if none are given.
+func jwtVerifyHTTPClient(caPEM string) (*http.Client, error) {
+	client := cleanhttp.DefaultClient()
+	if caPEM == "" {
+		return client, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+		return nil, errors.New("no PEM encoded certificates found")
+	}
+
+	transport := cleanhttp.DefaultTransport()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	client.Transport = transport
+
+	return client, nil
+}
+
+// jwtVerifyFetchJSON fetches the JSON document at url into v.
+func jwtVerifyFetchJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Fetching %q", url)
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error fetching %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error fetching %q: unexpected status %s", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, jwtVerifyMaxBodySize+1))
+	if err != nil {
+		return fmt.Errorf("error fetching %q: %w", url, err)
+	}
+	if len(b) > jwtVerifyMaxBodySize {
+		return fmt.Errorf("error fetching %q: larger than %d bytes", url, jwtVerifyMaxBodySize)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("invalid document at %q: %w", url, err)
+	}
+
+	return nil
+}
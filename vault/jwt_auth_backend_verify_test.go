@@ -0,0 +1,451 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3"
+)
+
+func TestRunJWTAuthBackendVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                issuer,
+			"jwks_uri":                              issuer + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{
+				{Key: &key.PublicKey, KeyID: "key-1", Algorithm: "RS256", Use: "sig"},
+			},
+		})
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	issuer = server.URL
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	pubKeyDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyDER}))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPubKeyDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPubKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecPubKeyDER}))
+
+	sign := func(t *testing.T, key *rsa.PrivateKey, kid, iss string) string {
+		t.Helper()
+
+		signer, err := jose.NewSigner(jose.SigningKey{
+			Algorithm: jose.RS256,
+			Key:       jose.JSONWebKey{Key: key, KeyID: kid},
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payload, err := json.Marshal(map[string]interface{}{
+			"iss": iss,
+			"sub": "user-1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jws, err := signer.Sign(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := jws.CompactSerialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return token
+	}
+
+	tests := []struct {
+		name          string
+		config        *jwtVerifyConfig
+		sampleJWT     string
+		expectedCheck string
+		expectClaims  bool
+	}{
+		{
+			name: "discovery",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: caPEM,
+				boundIssuer:        server.URL,
+			},
+		},
+		{
+			name: "discovery-sample-jwt",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: caPEM,
+				boundIssuer:        server.URL,
+			},
+			sampleJWT:    sign(t, key, "key-1", server.URL),
+			expectClaims: true,
+		},
+		{
+			name: "discovery-untrusted-ca",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL: server.URL,
+			},
+			expectedCheck: oidcCheckDiscovery,
+		},
+		{
+			name: "discovery-invalid-ca",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: "not-a-pem",
+			},
+			expectedCheck: oidcCheckDiscovery,
+		},
+		{
+			name: "discovery-issuer-mismatch",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL + "/",
+				oidcDiscoveryCAPEM: caPEM,
+			},
+			expectedCheck: oidcCheckDiscovery,
+		},
+		{
+			name: "discovery-bound-issuer-mismatch",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: caPEM,
+				boundIssuer:        "https://idp.example.com",
+			},
+			expectedCheck: jwtVerifyCheckBoundIssuer,
+		},
+		{
+			name: "discovery-unsupported-algorithm",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: caPEM,
+				supportedAlgs:      []string{"ES256"},
+			},
+			expectedCheck: jwtVerifyCheckAlgorithms,
+		},
+		{
+			name: "discovery-bad-signature",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: caPEM,
+			},
+			sampleJWT:     sign(t, otherKey, "key-1", server.URL),
+			expectedCheck: jwtVerifyCheckSignature,
+		},
+		{
+			name: "discovery-unknown-key",
+			config: &jwtVerifyConfig{
+				oidcDiscoveryURL:   server.URL,
+				oidcDiscoveryCAPEM: caPEM,
+			},
+			sampleJWT:     sign(t, key, "key-2", server.URL),
+			expectedCheck: jwtVerifyCheckSignature,
+		},
+		{
+			name: "jwks",
+			config: &jwtVerifyConfig{
+				jwksURL:   server.URL + "/keys",
+				jwksCAPEM: caPEM,
+			},
+			sampleJWT:    sign(t, key, "key-1", server.URL),
+			expectClaims: true,
+		},
+		{
+			name: "jwks-not-found",
+			config: &jwtVerifyConfig{
+				jwksURL:   server.URL + "/missing",
+				jwksCAPEM: caPEM,
+			},
+			expectedCheck: oidcCheckJWKS,
+		},
+		{
+			name: "public-keys",
+			config: &jwtVerifyConfig{
+				pubKeys:     []string{ecPubKeyPEM, pubKeyPEM},
+				boundIssuer: "https://idp.example.com",
+			},
+			sampleJWT:    sign(t, key, "", "https://idp.example.com"),
+			expectClaims: true,
+		},
+		{
+			name: "public-keys-invalid",
+			config: &jwtVerifyConfig{
+				pubKeys: []string{"not-a-pem"},
+			},
+			expectedCheck: jwtVerifyCheckPublicKeys,
+		},
+		{
+			name: "public-keys-unsupported-algorithm",
+			config: &jwtVerifyConfig{
+				pubKeys:       []string{ecPubKeyPEM},
+				supportedAlgs: []string{"RS256", "ES384"},
+			},
+			expectedCheck: jwtVerifyCheckAlgorithms,
+		},
+		{
+			name: "public-keys-sample-jwt-algorithm",
+			config: &jwtVerifyConfig{
+				pubKeys: []string{pubKeyPEM},
+				// the key of the sample JWT supports PS256, but it is signed
+				// with RS256
+				supportedAlgs: []string{"PS256"},
+			},
+			sampleJWT:     sign(t, key, "", "https://idp.example.com"),
+			expectedCheck: jwtVerifyCheckSignature,
+		},
+		{
+			name: "public-keys-issuer-mismatch",
+			config: &jwtVerifyConfig{
+				pubKeys:     []string{pubKeyPEM},
+				boundIssuer: "https://idp.example.com",
+			},
+			sampleJWT:     sign(t, key, "", "https://other.example.com"),
+			expectedCheck: jwtVerifyCheckIssuer,
+		},
+		{
+			name:          "no-keys",
+			config:        &jwtVerifyConfig{},
+			expectedCheck: jwtVerifyCheckPublicKeys,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, claims := runJWTAuthBackendVerify(context.Background(), tt.config, tt.sampleJWT)
+
+			if tt.expectedCheck == "" {
+				if !report.passed() {
+					t.Fatalf("expected all checks to pass, got %v", report.checks)
+				}
+				if tt.expectClaims != (claims != nil) {
+					t.Fatalf("expected claims %t, got %v", tt.expectClaims, claims)
+				}
+				if claims != nil && claims["sub"] != "user-1" {
+					t.Errorf("unexpected claims %v", claims)
+				}
+				return
+			}
+
+			if report.passed() {
+				t.Fatalf("expected a check to fail, got checks %v", report.checks)
+			}
+			if claims != nil {
+				t.Errorf("expected no claims, got %v", claims)
+			}
+			last := report.checks[len(report.checks)-1]
+			if last.name != tt.expectedCheck || last.passed {
+				t.Errorf("expected check %q to fail, got %+v", tt.expectedCheck, last)
+			}
+		})
+	}
+}
+
+func TestEvaluateJWTAuthBackendRole(t *testing.T) {
+	claims := map[string]interface{}{
+		"iss":    "https://idp.example.com",
+		"sub":    "user-1",
+		"aud":    []interface{}{"vault", "other"},
+		"email":  "user-1@example.com",
+		"groups": []interface{}{"admins", "dev-team"},
+		"age":    float64(42),
+		"profile": map[string]interface{}{
+			"team": "platform",
+		},
+	}
+
+	role := func(data map[string]interface{}) map[string]interface{} {
+		r := map[string]interface{}{
+			"role_type":       "jwt",
+			"user_claim":      "sub",
+			"bound_audiences": []interface{}{"vault"},
+		}
+		for k, v := range data {
+			r[k] = v
+		}
+		return r
+	}
+
+	tests := []struct {
+		name             string
+		role             map[string]interface{}
+		expectedMessage  string
+		expectedMetadata map[string]string
+	}{
+		{
+			name: "accepted",
+			role: role(map[string]interface{}{
+				"bound_subject": "user-1",
+				"groups_claim":  "groups",
+				"claim_mappings": map[string]interface{}{
+					"email":         "email",
+					"/profile/team": "team",
+					"missing":       "missing",
+				},
+				"bound_claims": map[string]interface{}{
+					"groups":        []interface{}{"admins"},
+					"/profile/team": "platform",
+				},
+			}),
+			expectedMetadata: map[string]string{
+				"email": "user-1@example.com",
+				"team":  "platform",
+				"role":  "test",
+			},
+		},
+		{
+			name: "not-found",
+		},
+		{
+			name:            "oidc-role-type",
+			role:            role(map[string]interface{}{"role_type": "oidc"}),
+			expectedMessage: "role with oidc role_type is not allowed",
+		},
+		{
+			name:            "bound-subject",
+			role:            role(map[string]interface{}{"bound_subject": "user-2"}),
+			expectedMessage: `sub claim "user-1" does not match bound_subject "user-2"`,
+		},
+		{
+			name:            "bound-audiences",
+			role:            role(map[string]interface{}{"bound_audiences": []interface{}{"nomad"}}),
+			expectedMessage: "aud claim does not match any bound audience",
+		},
+		{
+			name:            "unbound-audiences",
+			role:            role(map[string]interface{}{"bound_audiences": []interface{}{}}),
+			expectedMessage: "audience claim found in JWT but no audiences bound to the role",
+		},
+		{
+			name:            "user-claim-missing",
+			role:            role(map[string]interface{}{"user_claim": "name"}),
+			expectedMessage: `claim "name" not found in token`,
+		},
+		{
+			name:            "user-claim-not-string",
+			role:            role(map[string]interface{}{"user_claim": "age"}),
+			expectedMessage: `claim "age" could not be converted to string`,
+		},
+		{
+			name: "user-claim-json-pointer",
+			role: role(map[string]interface{}{
+				"user_claim":              "/profile/team",
+				"user_claim_json_pointer": true,
+			}),
+			expectedMetadata: map[string]string{"role": "test"},
+		},
+		{
+			name: "claim-mappings-not-string",
+			role: role(map[string]interface{}{
+				"claim_mappings": map[string]interface{}{"age": "age"},
+			}),
+			expectedMessage: "error converting claim 'age' to string",
+		},
+		{
+			name:            "groups-claim-missing",
+			role:            role(map[string]interface{}{"groups_claim": "roles"}),
+			expectedMessage: `"roles" claim not found in token`,
+		},
+		{
+			name: "bound-claims-missing",
+			role: role(map[string]interface{}{
+				"bound_claims": map[string]interface{}{"department": "it"},
+			}),
+			expectedMessage: `error validating claims: claim "department" is missing`,
+		},
+		{
+			name: "bound-claims-mismatch",
+			role: role(map[string]interface{}{
+				"bound_claims": map[string]interface{}{"groups": "dev-team,ops"},
+			}),
+			expectedMessage: `error validating claims: claim "groups" does not match any associated bound claim values`,
+		},
+		{
+			name: "bound-claims-glob",
+			role: role(map[string]interface{}{
+				"bound_claims_type": "glob",
+				"bound_claims":      map[string]interface{}{"groups": []interface{}{"dev-*"}},
+			}),
+			expectedMetadata: map[string]string{"role": "test"},
+		},
+		{
+			name: "bound-claims-glob-mismatch",
+			role: role(map[string]interface{}{
+				"bound_claims_type": "glob",
+				"bound_claims":      map[string]interface{}{"email": "*@example.org"},
+			}),
+			expectedMessage: `error validating claims: claim "email" does not match any associated bound claim values`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := evaluateJWTAuthBackendRole("test", tt.role, claims)
+
+			if tt.role == nil {
+				if result.accepted || result.message != "role not found" {
+					t.Fatalf("expected the role to not be found, got %+v", result)
+				}
+				return
+			}
+
+			if tt.expectedMessage != "" {
+				if result.accepted {
+					t.Fatalf("expected the role to reject the JWT, got %+v", result)
+				}
+				if result.message != tt.expectedMessage {
+					t.Errorf("expected message %q, got %q", tt.expectedMessage, result.message)
+				}
+				return
+			}
+
+			if !result.accepted {
+				t.Fatalf("expected the role to accept the JWT, got %q", result.message)
+			}
+			if !reflect.DeepEqual(result.metadata, tt.expectedMetadata) {
+				t.Errorf("expected metadata %v, got %v", tt.expectedMetadata, result.metadata)
+			}
+		})
+	}
+}
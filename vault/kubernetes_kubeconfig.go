@@ -0,0 +1,354 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+// newKubernetesNamespaceClient returns the client used to look up namespaces
+// in a Kubernetes cluster. Tests replace it with a fake.
+var newKubernetesNamespaceClient = func(creds *kubeconfigCredentials) (kubernetesNamespaceClient, error) {
+	return newKubernetesAPIClient(creds)
+}
+
+// kubernetesNamespaceClient looks up namespaces in a Kubernetes cluster.
+type kubernetesNamespaceClient interface {
+	// NamespaceExists returns whether the namespace exists in the cluster.
+	NamespaceExists(ctx context.Context, name string) (bool, error)
+}
+
+// kubeconfigCredentials are the connection details of a kubeconfig context.
+type kubeconfigCredentials struct {
+	Host       string
+	CACert     string
+	Insecure   bool
+	Token      string
+	ClientCert []byte
+	ClientKey  []byte
+}
+
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Exec                  interface{} `yaml:"exec"`
+			AuthProvider          interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+func kubeconfigSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				consts.FieldPath: {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Path to the kubeconfig file.",
+				},
+				consts.FieldContext: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The kubeconfig context to use, defaults to the current context.",
+				},
+			},
+		},
+	}
+}
+
+// getKubeconfigCredentials loads the credentials of the kubeconfig block v.
+// It returns nil if the block is not set.
+func getKubeconfigCredentials(v interface{}) (*kubeconfigCredentials, error) {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	config := l[0].(map[string]interface{})
+
+	path, _ := config[consts.FieldPath].(string)
+	kubeContext, _ := config[consts.FieldContext].(string)
+	return loadKubeconfig(path, kubeContext)
+}
+
+// loadKubeconfig loads the credentials of kubeContext from the kubeconfig file
+// at path, or those of its current context if kubeContext is empty.
+func loadKubeconfig(path, kubeContext string) (*kubeconfigCredentials, error) {
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Loading kubeconfig %q", path)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading kubeconfig: %w", err)
+	}
+
+	var config kubeconfigFile
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("error parsing kubeconfig %q: %w", path, err)
+	}
+
+	if kubeContext == "" {
+		kubeContext = config.CurrentContext
+	}
+	if kubeContext == "" {
+		return nil, fmt.Errorf("kubeconfig %q has no current context, a context must be set", path)
+	}
+
+	var clusterName, userName string
+	found := false
+	for _, c := range config.Contexts {
+		if c.Name == kubeContext {
+			clusterName, userName = c.Context.Cluster, c.Context.User
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("context %q not found in kubeconfig %q", kubeContext, path)
+	}
+
+	// relative file references are relative to the kubeconfig file
+	dir := filepath.Dir(path)
+	readRef := func(data, file string) ([]byte, error) {
+		if data != "" {
+			return base64.StdEncoding.DecodeString(data)
+		}
+		if file == "" {
+			return nil, nil
+		}
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		return os.ReadFile(file)
+	}
+
+	creds := &kubeconfigCredentials{}
+	found = false
+	for _, c := range config.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+
+		found = true
+		creds.Host = c.Cluster.Server
+		creds.Insecure = c.Cluster.InsecureSkipTLSVerify
+		ca, err := readRef(c.Cluster.CertificateAuthorityData, c.Cluster.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the certificate authority of cluster %q: %w", clusterName, err)
+		}
+		if len(ca) > 0 {
+			if block, _ := pem.Decode(ca); block == nil {
+				return nil, fmt.Errorf("the certificate authority of cluster %q is not PEM encoded", clusterName)
+			}
+			creds.CACert = strings.TrimSpace(string(ca))
+		}
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("cluster %q of context %q not found in kubeconfig %q", clusterName, kubeContext, path)
+	}
+	if creds.Host == "" {
+		return nil, fmt.Errorf("cluster %q of context %q has no server", clusterName, kubeContext)
+	}
+
+	if userName == "" {
+		return creds, nil
+	}
+
+	found = false
+	for _, u := range config.Users {
+		if u.Name != userName {
+			continue
+		}
+
+		found = true
+		if u.User.Exec != nil || u.User.AuthProvider != nil {
+			log.Printf("[WARN] Ignoring the exec and auth-provider credentials of kubeconfig user %q, they are not supported", userName)
+		}
+
+		// the token takes precedence over the token file, like in kubectl
+		creds.Token = u.User.Token
+		if creds.Token == "" {
+			token, err := readRef("", u.User.TokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading the token of user %q: %w", userName, err)
+			}
+			creds.Token = strings.TrimSpace(string(token))
+		}
+
+		var err error
+		if creds.ClientCert, err = readRef(u.User.ClientCertificateData, u.User.ClientCertificate); err != nil {
+			return nil, fmt.Errorf("error reading the client certificate of user %q: %w", userName, err)
+		}
+		if creds.ClientKey, err = readRef(u.User.ClientKeyData, u.User.ClientKey); err != nil {
+			return nil, fmt.Errorf("error reading the client key of user %q: %w", userName, err)
+		}
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("user %q of context %q not found in kubeconfig %q", userName, kubeContext, path)
+	}
+
+	return creds, nil
+}
+
+// kubernetesAPIClient is a minimal client of the Kubernetes API.
+type kubernetesAPIClient struct {
+	host   string
+	token  string
+	client *http.Client
+}
+
+func newKubernetesAPIClient(creds *kubeconfigCredentials) (*kubernetesAPIClient, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: creds.Insecure,
+	}
+	if creds.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(creds.CACert)) {
+			return nil, errors.New("no PEM encoded certificates found in the certificate authority")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(creds.ClientCert) > 0 || len(creds.ClientKey) > 0 {
+		cert, err := tls.X509KeyPair(creds.ClientCert, creds.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := cleanhttp.DefaultTransport()
+	transport.TLSClientConfig = tlsConfig
+	client := cleanhttp.DefaultClient()
+	client.Transport = transport
+
+	return &kubernetesAPIClient{
+		host:   strings.TrimSuffix(creds.Host, "/"),
+		token:  creds.Token,
+		client: client,
+	}, nil
+}
+
+// NamespaceExists implements kubernetesNamespaceClient.
+func (c *kubernetesAPIClient) NamespaceExists(ctx context.Context, name string) (bool, error) {
+	u := c.host + "/api/v1/namespaces/" + url.PathEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	log.Printf("[DEBUG] Looking up Kubernetes namespace %q", name)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error looking up Kubernetes namespace %q: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+
+	// the Kubernetes API returns a Status object on errors
+	var status struct {
+		Message string `json:"message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(body, &status); err != nil || status.Message == "" {
+		status.Message = http.StatusText(resp.StatusCode)
+	}
+	return false, fmt.Errorf("error looking up Kubernetes namespace %q: %d %s", name, resp.StatusCode, status.Message)
+}
+
+// validateKubernetesNamespaces checks that the namespaces exist in the
+// cluster of creds. Glob patterns are matched at login and are not checked.
+func validateKubernetesNamespaces(ctx context.Context, creds *kubeconfigCredentials, namespaces []string) error {
+	client, err := newKubernetesNamespaceClient(creds)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, ns := range namespaces {
+		if strings.Contains(ns, "*") {
+			continue
+		}
+
+		exists, err := client.NamespaceExists(ctx, ns)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			missing = append(missing, ns)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("namespaces not found in the Kubernetes cluster %q: %s",
+			creds.Host, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+)
+
+const testKubeconfigCACert = `-----BEGIN CERTIFICATE-----
+MIIBdzCCAR2gAwIBAgIBADAKBggqhkjOPQQDAjAjMSEwHwYDVQQDDBhrM3Mtc2Vy
+-----END CERTIFICATE-----`
+
+func writeTestKubeconfig(t *testing.T, dir string) string {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, "ca.crt"), []byte(testKubeconfigCACert+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com:6443
+    certificate-authority-data: ` + base64.StdEncoding.EncodeToString([]byte(testKubeconfigCACert)) + `
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com:6443
+    certificate-authority: ca.crt
+- name: empty-cluster
+  cluster:
+    insecure-skip-tls-verify: true
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+- name: anonymous
+  context:
+    cluster: dev-cluster
+- name: exec
+  context:
+    cluster: dev-cluster
+    user: exec-user
+- name: no-server
+  context:
+    cluster: empty-cluster
+- name: missing-user
+  context:
+    cluster: dev-cluster
+    user: unknown
+users:
+- name: dev-user
+  user:
+    token: dev-token
+    tokenFile: does-not-exist
+- name: prod-user
+  user:
+    tokenFile: token
+- name: exec-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: kubectl-login
+`
+	path := filepath.Join(dir, "config")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadKubeconfig(t *testing.T) {
+	path := writeTestKubeconfig(t, t.TempDir())
+
+	tests := []struct {
+		name    string
+		context string
+		want    *kubeconfigCredentials
+		wantErr string
+	}{
+		{
+			name: "current-context",
+			want: &kubeconfigCredentials{
+				Host:   "https://dev.example.com:6443",
+				CACert: testKubeconfigCACert,
+				Token:  "dev-token",
+			},
+		},
+		{
+			name:    "files",
+			context: "prod",
+			want: &kubeconfigCredentials{
+				Host:   "https://prod.example.com:6443",
+				CACert: testKubeconfigCACert,
+				Token:  "file-token",
+			},
+		},
+		{
+			name:    "no-user",
+			context: "anonymous",
+			want: &kubeconfigCredentials{
+				Host:   "https://dev.example.com:6443",
+				CACert: testKubeconfigCACert,
+			},
+		},
+		{
+			name:    "exec",
+			context: "exec",
+			want: &kubeconfigCredentials{
+				Host:   "https://dev.example.com:6443",
+				CACert: testKubeconfigCACert,
+			},
+		},
+		{
+			name:    "unknown-context",
+			context: "unknown",
+			wantErr: `context "unknown" not found`,
+		},
+		{
+			name:    "no-server",
+			context: "no-server",
+			wantErr: `cluster "empty-cluster" of context "no-server" has no server`,
+		},
+		{
+			name:    "missing-user",
+			context: "missing-user",
+			wantErr: `user "unknown" of context "missing-user" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadKubeconfig(path, tt.context)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadKubeconfig() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubernetesAPIClient_NamespaceExists(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch req.URL.Path {
+		case "/api/v1/namespaces/default":
+			w.Write([]byte(`{"kind":"Namespace","metadata":{"name":"default"}}`))
+		case "/api/v1/namespaces/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"kind":"Status","message":"namespaces \"forbidden\" is forbidden"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"kind":"Status","reason":"NotFound"}`))
+		}
+	}))
+	defer server.Close()
+
+	caCert := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}))
+	client, err := newKubernetesAPIClient(&kubeconfigCredentials{
+		Host:   server.URL + "/",
+		CACert: caCert,
+		Token:  "test-token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if exists, err := client.NamespaceExists(ctx, "default"); err != nil || !exists {
+		t.Errorf("expected namespace default to exist, got %t, %v", exists, err)
+	}
+	if exists, err := client.NamespaceExists(ctx, "missing"); err != nil || exists {
+		t.Errorf("expected namespace missing to not exist, got %t, %v", exists, err)
+	}
+
+	_, err = client.NamespaceExists(ctx, "forbidden")
+	if err == nil || !strings.Contains(err.Error(), `403 namespaces "forbidden" is forbidden`) {
+		t.Errorf("expected a forbidden error, got %v", err)
+	}
+
+	untrusted, err := newKubernetesAPIClient(&kubeconfigCredentials{
+		Host:  server.URL,
+		Token: "test-token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := untrusted.NamespaceExists(ctx, "default"); err == nil {
+		t.Error("expected an error for an untrusted certificate")
+	}
+}
+
+type fakeKubernetesNamespaceClient struct {
+	namespaces map[string]bool
+	lookups    []string
+}
+
+func (c *fakeKubernetesNamespaceClient) NamespaceExists(_ context.Context, name string) (bool, error) {
+	c.lookups = append(c.lookups, name)
+	return c.namespaces[name], nil
+}
+
+func TestValidateKubernetesNamespaces(t *testing.T) {
+	fake := &fakeKubernetesNamespaceClient{
+		namespaces: map[string]bool{
+			"default": true,
+			"apps":    true,
+		},
+	}
+	orig := newKubernetesNamespaceClient
+	t.Cleanup(func() {
+		newKubernetesNamespaceClient = orig
+	})
+	newKubernetesNamespaceClient = func(*kubeconfigCredentials) (kubernetesNamespaceClient, error) {
+		return fake, nil
+	}
+
+	creds := &kubeconfigCredentials{Host: "https://k8s.example.com"}
+	ctx := context.Background()
+	if err := validateKubernetesNamespaces(ctx, creds, []string{"default", "apps", "team-*"}); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if want := []string{"default", "apps"}; !reflect.DeepEqual(fake.lookups, want) {
+		t.Errorf("expected lookups %v, got %v", want, fake.lookups)
+	}
+
+	err := validateKubernetesNamespaces(ctx, creds, []string{"staging", "default", "dev"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := `namespaces not found in the Kubernetes cluster "https://k8s.example.com": dev, staging`; err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err)
+	}
+}
+
+func TestKubernetesAuthBackendConfigCustomizeDiff_kubeconfig(t *testing.T) {
+	path := writeTestKubeconfig(t, t.TempDir())
+
+	tests := []struct {
+		name       string
+		raw        map[string]interface{}
+		wantHost   string
+		wantCACert string
+	}{
+		{
+			name: "kubeconfig",
+			raw: map[string]interface{}{
+				consts.FieldKubeconfig: []interface{}{
+					map[string]interface{}{
+						consts.FieldPath:    path,
+						consts.FieldContext: "prod",
+					},
+				},
+			},
+			wantHost:   "https://prod.example.com:6443",
+			wantCACert: testKubeconfigCACert,
+		},
+		{
+			name: "explicit",
+			raw: map[string]interface{}{
+				consts.FieldKubernetesHost:   "https://k8s.example.com",
+				consts.FieldKubernetesCACert: "explicit",
+				consts.FieldKubeconfig: []interface{}{
+					map[string]interface{}{
+						consts.FieldPath: path,
+					},
+				},
+			},
+			wantHost:   "https://k8s.example.com",
+			wantCACert: "explicit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := kubernetesAuthBackendConfigResource()
+			diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := diff.Attributes[consts.FieldKubernetesHost].New; got != tt.wantHost {
+				t.Errorf("expected host %q, got %q", tt.wantHost, got)
+			}
+			if got := diff.Attributes[consts.FieldKubernetesCACert].New; got != tt.wantCACert {
+				t.Errorf("expected CA cert %q, got %q", tt.wantCACert, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/group"
+)
+
+// policyTemplate is an identity template placeholder of a policy path, such
+// as {{identity.entity.aliases.<accessor>.name}}.
+type policyTemplate struct {
+	path     string
+	selector string
+	// accessor is the mount accessor of alias templates.
+	accessor string
+	// groupID and groupName are set for group templates.
+	groupID   string
+	groupName string
+	// metadataKey is set for the metadata templates of entities and groups.
+	metadataKey string
+}
+
+func (t *policyTemplate) String() string {
+	return fmt.Sprintf("path %q: {{%s}}", t.path, t.selector)
+}
+
+func policyValidateIdentityTemplatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "Validate the identity templates of the policy paths against Vault: " +
+			"the mount accessors and groups must exist, and the group metadata keys must be set.",
+	}
+}
+
+// parsePolicyTemplates returns the identity template placeholders of the
+// policy path. Unterminated and unsupported placeholders are an error.
+func parsePolicyTemplates(path string) ([]*policyTemplate, error) {
+	var result []*policyTemplate
+	rest := path
+	for {
+		start := strings.Index(rest, "{{")
+		if start == -1 {
+			if strings.Contains(rest, "}}") {
+				return nil, fmt.Errorf("path %q: unexpected \"}}\"", path)
+			}
+			return result, nil
+		}
+
+		end := strings.Index(rest[start:], "}}")
+		if end == -1 {
+			return nil, fmt.Errorf("path %q: unterminated template", path)
+		}
+
+		selector := strings.TrimSpace(rest[start+2 : start+end])
+		if strings.Contains(selector, "{{") {
+			return nil, fmt.Errorf("path %q: unterminated template", path)
+		}
+
+		t, err := parsePolicyTemplateSelector(path, selector)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+
+		rest = rest[start+end+2:]
+	}
+}
+
+func parsePolicyTemplateSelector(path, selector string) (*policyTemplate, error) {
+	t := &policyTemplate{
+		path:     path,
+		selector: selector,
+	}
+
+	invalid := func(reason string) error {
+		return fmt.Errorf("%s: %s", t, reason)
+	}
+
+	switch {
+	case strings.HasPrefix(selector, "identity.entity."):
+		s := strings.TrimPrefix(selector, "identity.entity.")
+		switch {
+		case s == "id" || s == "name":
+		case strings.HasPrefix(s, "metadata."):
+			t.metadataKey = strings.TrimPrefix(s, "metadata.")
+		case strings.HasPrefix(s, "aliases."):
+			accessor, field, ok := strings.Cut(strings.TrimPrefix(s, "aliases."), ".")
+			if !ok || accessor == "" {
+				return nil, invalid("expected identity.entity.aliases.<mount accessor>.<field>")
+			}
+			t.accessor = accessor
+
+			switch {
+			case field == "id" || field == "name":
+			case strings.HasPrefix(field, "metadata.") && field != "metadata.":
+			case strings.HasPrefix(field, "custom_metadata.") && field != "custom_metadata.":
+			default:
+				return nil, invalid(fmt.Sprintf("unsupported alias field %q, "+
+					"expected id, name, metadata.<key> or custom_metadata.<key>", field))
+			}
+		default:
+			return nil, invalid(fmt.Sprintf("unsupported entity field %q, "+
+				"expected id, name, metadata.<key> or aliases.<mount accessor>.<field>", s))
+		}
+	case strings.HasPrefix(selector, "identity.groups."):
+		kind, s, _ := strings.Cut(strings.TrimPrefix(selector, "identity.groups."), ".")
+		key, field, ok := strings.Cut(s, ".")
+		if !ok || key == "" {
+			return nil, invalid("expected identity.groups.ids.<group id>.<field> " +
+				"or identity.groups.names.<group name>.<field>")
+		}
+
+		switch kind {
+		case "ids":
+			t.groupID = key
+		case "names":
+			t.groupName = key
+		default:
+			return nil, invalid(fmt.Sprintf("unsupported groups selector %q, expected ids or names", kind))
+		}
+
+		switch {
+		case field == "id" || field == "name":
+		case strings.HasPrefix(field, "metadata."):
+			t.metadataKey = strings.TrimPrefix(field, "metadata.")
+		default:
+			return nil, invalid(fmt.Sprintf("unsupported group field %q, expected id, name or metadata.<key>", field))
+		}
+	default:
+		return nil, invalid("unsupported template, expected identity.entity.<field> or identity.groups.<field>")
+	}
+
+	if strings.HasSuffix(selector, "metadata.") {
+		return nil, invalid("missing metadata key")
+	}
+
+	return t, nil
+}
+
+// policyDocumentPaths returns the paths of an ACL policy in HCL format,
+// without validating the rest of the policy.
+func policyDocumentPaths(rules string) ([]string, error) {
+	root, err := hcl.Parse(rules)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing policy: %s", err)
+	}
+
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing policy: does not contain a root object")
+	}
+
+	var paths []string
+	for _, item := range list.Filter("path").Items {
+		if len(item.Keys) == 0 {
+			continue
+		}
+		if path, ok := item.Keys[0].Token.Value().(string); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// policyPathsHaveTemplates returns true if any of the paths looks templated,
+// so that Vault is only queried when needed.
+func policyPathsHaveTemplates(paths []string) bool {
+	for _, path := range paths {
+		if strings.Contains(path, "{{") || strings.Contains(path, "}}") {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePolicyTemplates parses the identity templates of the policy paths
+// and resolves them against Vault. All the invalid templates are reported in
+// the returned error.
+func validatePolicyTemplates(ctx context.Context, client *api.Client, paths []string) error {
+	var templates []*policyTemplate
+	var errs []error
+	for _, path := range paths {
+		t, err := parsePolicyTemplates(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		templates = append(templates, t...)
+	}
+
+	if len(errs) == 0 && len(templates) > 0 {
+		r := &policyTemplateResolver{
+			client: client,
+			groups: map[string]map[string]string{},
+		}
+		for _, t := range templates {
+			if err := r.resolve(ctx, t); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid identity templates in policy: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// policyTemplateResolver caches what is read from Vault while resolving the
+// templates of a policy. Entity metadata templates are not resolved: a key
+// that is not set on any entity yet is valid, and checking it would require
+// reading every entity.
+type policyTemplateResolver struct {
+	client *api.Client
+	// accessors maps the mount accessors of the auth methods to their path.
+	accessors map[string]string
+	// groups maps the group selectors to the metadata of the group, nil if
+	// the group does not exist.
+	groups map[string]map[string]string
+}
+
+func (r *policyTemplateResolver) resolve(ctx context.Context, t *policyTemplate) error {
+	switch {
+	case t.accessor != "":
+		if err := r.readAccessors(ctx); err != nil {
+			return err
+		}
+
+		if _, ok := r.accessors[t.accessor]; !ok {
+			known := make([]string, 0, len(r.accessors))
+			for accessor, path := range r.accessors {
+				known = append(known, fmt.Sprintf("%s (%s)", accessor, path))
+			}
+			sort.Strings(known)
+			return fmt.Errorf("%s: unknown mount accessor %q, known accessors are: %s",
+				t, t.accessor, strings.Join(known, ", "))
+		}
+	case t.groupID != "" || t.groupName != "":
+		metadata, err := r.readGroup(ctx, t)
+		if err != nil {
+			return err
+		}
+		if metadata == nil {
+			if t.groupID != "" {
+				return fmt.Errorf("%s: group ID %q not found", t, t.groupID)
+			}
+			return fmt.Errorf("%s: group %q not found", t, t.groupName)
+		}
+
+		if t.metadataKey != "" {
+			if _, ok := metadata[t.metadataKey]; !ok {
+				return fmt.Errorf("%s: metadata key %q is not set on the group", t, t.metadataKey)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *policyTemplateResolver) readAccessors(ctx context.Context) error {
+	if r.accessors != nil {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Reading auth methods to resolve policy templates")
+	auths, err := r.client.Sys().ListAuthWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading auth methods from Vault: %w", err)
+	}
+
+	r.accessors = map[string]string{}
+	for path, auth := range auths {
+		r.accessors[auth.Accessor] = path
+	}
+
+	return nil
+}
+
+func (r *policyTemplateResolver) readGroup(ctx context.Context, t *policyTemplate) (map[string]string, error) {
+	path := group.IdentityGroupIDPath(t.groupID)
+	if t.groupName != "" {
+		path = fmt.Sprintf("identity/group/name/%s", t.groupName)
+	}
+
+	if metadata, ok := r.groups[path]; ok {
+		return metadata, nil
+	}
+
+	log.Printf("[DEBUG] Reading IdentityGroup from %q to resolve policy templates", path)
+	resp, err := r.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading IdentityGroup from %q: %w", path, err)
+	}
+
+	var metadata map[string]string
+	if resp != nil {
+		metadata = policyTemplateMetadata(resp.Data[consts.FieldMetadata])
+	}
+	r.groups[path] = metadata
+
+	return metadata, nil
+}
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestParsePolicyTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		expected  []*policyTemplate
+		expectErr string
+	}{
+		{
+			name: "none",
+			path: "secret/data/*",
+		},
+		{
+			name: "entity",
+			path: "secret/data/{{ identity.entity.name }}/{{identity.entity.metadata.team}}/*",
+			expected: []*policyTemplate{
+				{
+					path:     "secret/data/{{ identity.entity.name }}/{{identity.entity.metadata.team}}/*",
+					selector: "identity.entity.name",
+				},
+				{
+					path:        "secret/data/{{ identity.entity.name }}/{{identity.entity.metadata.team}}/*",
+					selector:    "identity.entity.metadata.team",
+					metadataKey: "team",
+				},
+			},
+		},
+		{
+			name: "alias",
+			path: "users/{{identity.entity.aliases.auth_userpass_1234.custom_metadata.org}}",
+			expected: []*policyTemplate{
+				{
+					path:     "users/{{identity.entity.aliases.auth_userpass_1234.custom_metadata.org}}",
+					selector: "identity.entity.aliases.auth_userpass_1234.custom_metadata.org",
+					accessor: "auth_userpass_1234",
+				},
+			},
+		},
+		{
+			name: "groups",
+			path: "{{identity.groups.ids.1234.name}}/{{identity.groups.names.ops.metadata.region}}",
+			expected: []*policyTemplate{
+				{
+					path:     "{{identity.groups.ids.1234.name}}/{{identity.groups.names.ops.metadata.region}}",
+					selector: "identity.groups.ids.1234.name",
+					groupID:  "1234",
+				},
+				{
+					path:        "{{identity.groups.ids.1234.name}}/{{identity.groups.names.ops.metadata.region}}",
+					selector:    "identity.groups.names.ops.metadata.region",
+					groupName:   "ops",
+					metadataKey: "region",
+				},
+			},
+		},
+		{
+			name:      "unterminated",
+			path:      "secret/{{identity.entity.name",
+			expectErr: "unterminated template",
+		},
+		{
+			name:      "nested",
+			path:      "secret/{{identity.{{entity.name}}",
+			expectErr: "unterminated template",
+		},
+		{
+			name:      "unexpected-close",
+			path:      "secret/identity.entity.name}}",
+			expectErr: `unexpected "}}"`,
+		},
+		{
+			name:      "misspelled-entity-field",
+			path:      "secret/{{identity.entity.nmae}}",
+			expectErr: `unsupported entity field "nmae"`,
+		},
+		{
+			name:      "missing-alias-field",
+			path:      "secret/{{identity.entity.aliases.auth_userpass_1234}}",
+			expectErr: "expected identity.entity.aliases.<mount accessor>.<field>",
+		},
+		{
+			name:      "alias-metadata-map",
+			path:      "secret/{{identity.entity.aliases.auth_userpass_1234.metadata}}",
+			expectErr: `unsupported alias field "metadata"`,
+		},
+		{
+			name:      "missing-metadata-key",
+			path:      "secret/{{identity.entity.metadata.}}",
+			expectErr: "missing metadata key",
+		},
+		{
+			name:      "invalid-groups-selector",
+			path:      "secret/{{identity.groups.id.1234.name}}",
+			expectErr: `unsupported groups selector "id"`,
+		},
+		{
+			name:      "unsupported-root",
+			path:      "secret/{{identity.token.id}}",
+			expectErr: "unsupported template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parsePolicyTemplates(tt.path)
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Errorf("expected %#v, got %#v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPolicyDocumentPaths(t *testing.T) {
+	paths, err := policyDocumentPaths(`
+name = "ignored"
+
+path "secret/{{identity.entity.name}}/*" {
+  capabilities = ["read"]
+  control_group = {}
+}
+
+path "sys/mounts" {
+  policy = "read"
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"secret/{{identity.entity.name}}/*", "sys/mounts"}
+	if !reflect.DeepEqual(expected, paths) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+
+	if _, err := policyDocumentPaths(`path "secret/*" {`); err == nil {
+		t.Error("expected an error for invalid HCL")
+	}
+}
+
+type testPolicyTemplateHandler struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (h *testPolicyTemplateHandler) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		h.mu.Lock()
+		h.requests = append(h.requests, fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+		h.mu.Unlock()
+
+		var data interface{}
+		switch req.URL.Path {
+		case "/v1/sys/auth":
+			data = map[string]interface{}{
+				"userpass/": map[string]interface{}{"type": "userpass", "accessor": "auth_userpass_1234"},
+				"token/":    map[string]interface{}{"type": "token", "accessor": "auth_token_5678"},
+			}
+		case "/v1/identity/group/id/g1":
+			data = map[string]interface{}{"id": "g1", "name": "ops", "metadata": map[string]interface{}{"region": "eu"}}
+		case "/v1/identity/group/name/ops":
+			data = map[string]interface{}{"id": "g1", "name": "ops", "metadata": map[string]interface{}{"region": "eu"}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": data}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestValidatePolicyTemplates(t *testing.T) {
+	tests := []struct {
+		name             string
+		paths            []string
+		expectErrs       []string
+		expectedRequests int
+	}{
+		{
+			name:  "no-templates",
+			paths: []string{"secret/*"},
+		},
+		{
+			name: "valid",
+			paths: []string{
+				"secret/{{identity.entity.name}}/*",
+				"secret/{{identity.entity.aliases.auth_userpass_1234.name}}",
+				"secret/{{identity.entity.aliases.auth_userpass_1234.metadata.org}}",
+				"secret/{{identity.entity.metadata.team}}",
+				"secret/{{identity.groups.ids.g1.metadata.region}}",
+				"secret/{{identity.groups.names.ops.id}}/{{identity.groups.names.ops.metadata.region}}",
+			},
+			// sys/auth and each group are read once, entities are not read
+			expectedRequests: 3,
+		},
+		{
+			name: "invalid",
+			paths: []string{
+				"secret/{{identity.entity.aliases.auth_userpas_1234.name}}",
+				"secret/{{identity.groups.ids.g2.name}}",
+				"secret/{{identity.groups.names.ops.metadata.regoin}}",
+			},
+			expectedRequests: 3,
+			expectErrs: []string{
+				`unknown mount accessor "auth_userpas_1234", known accessors are: auth_token_5678 (token/), auth_userpass_1234 (userpass/)`,
+				`group ID "g2" not found`,
+				`metadata key "regoin" is not set on the group`,
+			},
+		},
+		{
+			// syntax errors are reported without reading from Vault
+			name: "syntax",
+			paths: []string{
+				"secret/{{identity.entity.nmae}}",
+				"secret/{{identity.entity.aliases.auth_unknown.name}}",
+			},
+			expectErrs: []string{
+				`unsupported entity field "nmae"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &testPolicyTemplateHandler{}
+			config, ln := testutil.TestHTTPServer(t, h.handler())
+			defer ln.Close()
+
+			config.Address = fmt.Sprintf("http://%s", ln.Addr())
+			config.MaxRetries = 0
+			client, err := api.NewClient(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validatePolicyTemplates(context.Background(), client, tt.paths)
+			if len(tt.expectErrs) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				for _, expected := range tt.expectErrs {
+					if !strings.Contains(err.Error(), expected) {
+						t.Errorf("expected the error to contain %q, got %s", expected, err)
+					}
+				}
+				if n := strings.Count(err.Error(), "\n") + 1; n != len(tt.expectErrs) {
+					t.Errorf("expected %d errors, got %d: %s", len(tt.expectErrs), n, err)
+				}
+			}
+
+			if len(h.requests) != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d: %v", tt.expectedRequests, len(h.requests), h.requests)
+			}
+		})
+	}
+}
@@ -53,6 +53,14 @@ var (
 			Resource:      UpdateSchemaResource(identityGroupDataSource()),
 			PathInventory: []string{"/identity/lookup/group"},
 		},
+		"vault_identity_entities": {
+			Resource:      UpdateSchemaResource(identityEntitiesDataSource()),
+			PathInventory: []string{"/identity/entity/id", "/identity/entity/id/{id}"},
+		},
+		"vault_identity_groups": {
+			Resource:      UpdateSchemaResource(identityGroupsDataSource()),
+			PathInventory: []string{"/identity/group/id", "/identity/group/id/{id}"},
+		},
 		"vault_kubernetes_auth_backend_config": {
 			Resource:      UpdateSchemaResource(kubernetesAuthBackendConfigDataSource()),
 			PathInventory: []string{"/auth/kubernetes/config"},
@@ -101,6 +109,14 @@ var (
 			Resource:      UpdateSchemaResource(policyDocumentDataSource()),
 			PathInventory: []string{"/sys/policy/{name}"},
 		},
+		"vault_policy_parse": {
+			Resource:      UpdateSchemaResource(policyParseDataSource()),
+			PathInventory: []string{GenericPath},
+		},
+		"vault_policy_check": {
+			Resource:      UpdateSchemaResource(policyCheckDataSource()),
+			PathInventory: []string{"/sys/policy/{name}", "/sys/capabilities"},
+		},
 		"vault_auth_backend": {
 			Resource:      UpdateSchemaResource(authBackendDataSource()),
 			PathInventory: []string{"/sys/auth"},
@@ -133,6 +149,32 @@ var (
 			Resource:      UpdateSchemaResource(identityOIDCOpenIDConfigDataSource()),
 			PathInventory: []string{"/identity/oidc/provider/{name}/.well-known/openid-configuration"},
 		},
+		"vault_identity_oidc_token": {
+			Resource:      UpdateSchemaResource(identityOIDCTokenDataSource()),
+			PathInventory: []string{"/identity/oidc/token/{name}"},
+		},
+		"vault_identity_oidc_introspect": {
+			Resource:      UpdateSchemaResource(identityOIDCIntrospectDataSource()),
+			PathInventory: []string{"/identity/oidc/introspect"},
+		},
+		"vault_auth_login_test": {
+			Resource:      UpdateSchemaResource(authLoginTestDataSource()),
+			PathInventory: []string{"/auth/{mount}/login"},
+		},
+		"vault_jwt_auth_backend_verify": {
+			Resource:      UpdateSchemaResource(jwtAuthBackendVerifyDataSource()),
+			PathInventory: []string{"/auth/jwt/config", "/auth/jwt/role/{name}"},
+		},
+		"vault_identity_oidc_conformance": {
+			Resource: UpdateSchemaResource(identityOIDCConformanceDataSource()),
+			PathInventory: []string{
+				"/identity/oidc/provider/{name}/.well-known/openid-configuration",
+				"/identity/oidc/provider/{name}/.well-known/keys",
+				"/identity/oidc/provider/{name}/authorize",
+				"/identity/oidc/provider/{name}/token",
+				"/identity/oidc/provider/{name}/userinfo",
+			},
+		},
 		"vault_kv_secret": {
 			Resource:      UpdateSchemaResource(kvSecretDataSource()),
 			PathInventory: []string{"/secret/{path}"},
@@ -173,6 +215,14 @@ var (
 			Resource:      UpdateSchemaResource(pkiSecretBackendKeysDataSource()),
 			PathInventory: []string{"/pki/keys"},
 		},
+		"vault_pki_secret_backend_revocation_status": {
+			Resource:      UpdateSchemaResource(pkiSecretBackendRevocationStatusDataSource()),
+			PathInventory: []string{"/pki/crl", "/pki/unified-crl", "/pki/ocsp", "/pki/unified-ocsp"},
+		},
+		"vault_pki_certificate_info": {
+			Resource:      UpdateSchemaResource(pkiCertificateInfoDataSource()),
+			PathInventory: []string{GenericPath},
+		},
 		"vault_transform_encode": {
 			Resource:      UpdateSchemaResource(transformEncodeDataSource()),
 			PathInventory: []string{"/transform/encode/{role_name}"},
@@ -359,6 +409,22 @@ var (
 			Resource:      UpdateSchemaResource(certAuthBackendRoleResource()),
 			PathInventory: []string{"/auth/cert/certs/{name}"},
 		},
+		"vault_cert_auth_backend_config": {
+			Resource:      UpdateSchemaResource(certAuthBackendConfigResource()),
+			PathInventory: []string{"/auth/cert/config"},
+		},
+		"vault_cert_auth_backend_crl": {
+			Resource:      UpdateSchemaResource(certAuthBackendCRLResource()),
+			PathInventory: []string{"/auth/cert/crls/{name}"},
+		},
+		"vault_cf_auth_backend_config": {
+			Resource:      UpdateSchemaResource(cfAuthBackendConfigResource()),
+			PathInventory: []string{"/auth/cf/config"},
+		},
+		"vault_cf_auth_backend_role": {
+			Resource:      UpdateSchemaResource(cfAuthBackendRoleResource()),
+			PathInventory: []string{"/auth/cf/roles/{role}"},
+		},
 		"vault_generic_endpoint": {
 			Resource:      UpdateSchemaResource(genericEndpointResource("vault_generic_endpoint")),
 			PathInventory: []string{GenericPath},
@@ -403,10 +469,38 @@ var (
 			Resource:      UpdateSchemaResource(ldapAuthBackendUserResource()),
 			PathInventory: []string{"/auth/ldap/users/{name}"},
 		},
+		"vault_userpass_auth_backend_user": {
+			Resource:      UpdateSchemaResource(userpassAuthBackendUserResource()),
+			PathInventory: []string{"/auth/userpass/users/{username}"},
+		},
 		"vault_ldap_auth_backend_group": {
 			Resource:      UpdateSchemaResource(ldapAuthBackendGroupResource()),
 			PathInventory: []string{"/auth/ldap/groups/{name}"},
 		},
+		"vault_kerberos_auth_backend_config": {
+			Resource:      UpdateSchemaResource(kerberosAuthBackendConfigResource()),
+			PathInventory: []string{"/auth/kerberos/config", "/auth/kerberos/config/ldap"},
+		},
+		"vault_kerberos_auth_backend_group": {
+			Resource:      UpdateSchemaResource(kerberosAuthBackendGroupResource()),
+			PathInventory: []string{"/auth/kerberos/groups/{name}"},
+		},
+		"vault_radius_auth_backend_config": {
+			Resource:      UpdateSchemaResource(radiusAuthBackendConfigResource()),
+			PathInventory: []string{"/auth/radius/config"},
+		},
+		"vault_radius_auth_backend_user": {
+			Resource:      UpdateSchemaResource(radiusAuthBackendUserResource()),
+			PathInventory: []string{"/auth/radius/users/{name}"},
+		},
+		"vault_oci_auth_backend_config": {
+			Resource:      UpdateSchemaResource(ociAuthBackendConfigResource()),
+			PathInventory: []string{"/auth/oci/config"},
+		},
+		"vault_oci_auth_backend_role": {
+			Resource:      UpdateSchemaResource(ociAuthBackendRoleResource()),
+			PathInventory: []string{"/auth/oci/role/{role}"},
+		},
 		"vault_ldap_secret_backend": {
 			Resource:      UpdateSchemaResource(ldapSecretBackendResource()),
 			PathInventory: []string{"/ldap/config"},
@@ -506,6 +600,18 @@ var (
 			Resource:      UpdateSchemaResource(identityEntityPoliciesResource()),
 			PathInventory: []string{"/identity/lookup/entity"},
 		},
+		"vault_identity_entity_merge": {
+			Resource:      UpdateSchemaResource(identityEntityMergeResource()),
+			PathInventory: []string{"/identity/entity/merge"},
+		},
+		"vault_identity_entity_cleanup": {
+			Resource: UpdateSchemaResource(identityEntityCleanupResource()),
+			PathInventory: []string{
+				"/identity/entity/id",
+				"/identity/entity/id/{id}",
+				"/identity/entity/batch-delete",
+			},
+		},
 		"vault_identity_group": {
 			Resource:      UpdateSchemaResource(identityGroupResource()),
 			PathInventory: []string{"/identity/group"},
@@ -518,6 +624,15 @@ var (
 			Resource:      UpdateSchemaResource(identityGroupMemberEntityIdsResource()),
 			PathInventory: []string{"/identity/group/id/{id}"},
 		},
+		"vault_identity_group_member_aliases": {
+			Resource: UpdateSchemaResource(identityGroupMemberAliasesResource()),
+			PathInventory: []string{
+				"/identity/group/id/{id}",
+				"/identity/lookup/entity",
+				"/identity/entity",
+				"/identity/entity-alias",
+			},
+		},
 		"vault_identity_group_member_group_ids": {
 			Resource:      UpdateSchemaResource(identityGroupMemberGroupIdsResource()),
 			PathInventory: []string{"/identity/group/id/{id}"},
@@ -581,6 +696,10 @@ var (
 			Resource:      UpdateSchemaResource(pkiSecretBackendIntermediateSetSignedResource()),
 			PathInventory: []string{"/pki/intermediate/set-signed"},
 		},
+		"vault_pki_secret_backend_intermediate_external_sign": {
+			Resource:      UpdateSchemaResource(pkiSecretBackendIntermediateExternalSignResource()),
+			PathInventory: []string{"/pki/intermediate/set-signed"},
+		},
 		"vault_pki_secret_backend_role": {
 			Resource:      UpdateSchemaResource(pkiSecretBackendRoleResource()),
 			PathInventory: []string{"/pki/roles/{name}"},
@@ -593,6 +712,20 @@ var (
 			Resource:      UpdateSchemaResource(pkiSecretBackendRootSignIntermediateResource()),
 			PathInventory: []string{"/pki/root/sign-intermediate"},
 		},
+		"vault_pki_hierarchy": {
+			Resource: UpdateSchemaResource(pkiHierarchyResource()),
+			PathInventory: []string{
+				"/sys/mounts/{path}",
+				"/pki/issuers/generate/root/{exported}",
+				"/pki/issuers/generate/intermediate/{exported}",
+				"/pki/root/sign-intermediate",
+				"/pki/intermediate/set-signed",
+				"/pki/issuer/{issuer_ref}",
+				"/pki/config/issuers",
+				"/pki/config/urls",
+				"/pki/config/crl",
+			},
+		},
 		"vault_pki_secret_backend_sign": {
 			Resource:      UpdateSchemaResource(pkiSecretBackendSignResource()),
 			PathInventory: []string{"/pki/sign/{role}"},
@@ -152,6 +152,10 @@ func authBackendRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if err := authMountTuneRead(d, client, "auth/"+path); err != nil {
+		return err
+	}
+
 	return nil
 }
 
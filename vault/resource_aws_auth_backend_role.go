@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 )
@@ -30,11 +31,12 @@ func awsAuthBackendRoleResource() *schema.Resource {
 			ForceNew:    true,
 		},
 		"auth_type": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "iam",
-			Description: "The auth type permitted for this role.",
-			ForceNew:    true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "iam",
+			Description:  "The auth type permitted for this role.",
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"iam", "ec2"}, false),
 		},
 		"bound_ami_ids": {
 			Type:        schema.TypeSet,
@@ -114,6 +116,15 @@ func awsAuthBackendRoleResource() *schema.Resource {
 			Type:        schema.TypeSet,
 			Optional:    true,
 			Description: "The IAM principal that must be authenticated using the iam auth method.",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validateAWSAuthWildcardARN("bound_iam_principal_arns"),
+			},
+		},
+		"bound_iam_principal_ids": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The unique IDs that Vault resolved the bound_iam_principal_arns without a wildcard to.",
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
@@ -185,6 +196,75 @@ func resourceVaultAwsAuthBackendRoleCustomizeDiff(_ context.Context, diff *schem
 			}
 		}
 	}
+
+	if err := validateAWSAuthBackendRoleARNs(diff); err != nil {
+		return err
+	}
+
+	if diff.Id() != "" && diff.HasChanges("bound_iam_principal_arns", "resolve_aws_unique_ids") {
+		if err := diff.SetNewComputed("bound_iam_principal_ids"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateAWSAuthBackendRoleARNs checks that the ARN fields are supported by
+// the auth type of the role, and that their ARNs are valid.
+func validateAWSAuthBackendRoleARNs(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown("auth_type") || !diff.NewValueKnown("inferred_entity_type") {
+		return nil
+	}
+
+	authType := diff.Get("auth_type").(string)
+	inferred := diff.Get("inferred_entity_type").(string)
+
+	fields := []struct {
+		name          string
+		resourceTypes []string
+		supported     bool
+		authTypes     string
+	}{
+		{
+			name:          "bound_iam_principal_arns",
+			resourceTypes: []string{"user", "role"},
+			supported:     authType == "iam",
+			authTypes:     `auth_type is "iam"`,
+		},
+		{
+			name:          "bound_iam_role_arns",
+			resourceTypes: []string{"role"},
+			supported:     isEc2(authType, inferred),
+			authTypes:     `auth_type is "ec2", or "iam" with inferred_entity_type "ec2_instance"`,
+		},
+		{
+			name:          "bound_iam_instance_profile_arns",
+			resourceTypes: []string{"instance-profile"},
+			supported:     isEc2(authType, inferred),
+			authTypes:     `auth_type is "ec2", or "iam" with inferred_entity_type "ec2_instance"`,
+		},
+	}
+	for _, f := range fields {
+		if !diff.NewValueKnown(f.name) {
+			continue
+		}
+
+		arns := diff.Get(f.name).(*schema.Set).List()
+		if len(arns) == 0 {
+			continue
+		}
+		if !f.supported {
+			return fmt.Errorf("%q can only be set when %s", f.name, f.authTypes)
+		}
+
+		for _, arn := range arns {
+			if err := validateAWSAuthIAMARN(arn.(string), f.resourceTypes...); err != nil {
+				return fmt.Errorf("invalid ARN %q in %q: %w", arn, f.name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -397,6 +477,12 @@ func awsAuthBackendRoleRead(_ context.Context, d *schema.ResourceData, meta inte
 		d.Set("bound_iam_principal_arns", resp.Data["bound_iam_principal_arn"])
 	}
 
+	principalARNs := awsAuthBackendRoleStrings(resp.Data["bound_iam_principal_arn"])
+	principalIDs := awsAuthBackendRoleStrings(resp.Data["bound_iam_principal_id"])
+	if err := d.Set("bound_iam_principal_ids", awsAuthResolvedPrincipalIDs(principalARNs, principalIDs)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.Set("role_tag", resp.Data["role_tag"])
 	d.Set("role_id", resp.Data["role_id"])
 	d.Set("inferred_entity_type", resp.Data["inferred_entity_type"])
@@ -405,9 +491,26 @@ func awsAuthBackendRoleRead(_ context.Context, d *schema.ResourceData, meta inte
 	d.Set("allow_instance_migration", resp.Data["allow_instance_migration"])
 	d.Set("disallow_reauthentication", resp.Data["disallow_reauthentication"])
 
-	diags := checkCIDRs(d, TokenFieldBoundCIDRs)
+	return checkCIDRs(d, TokenFieldBoundCIDRs)
+}
 
-	return diags
+// awsAuthBackendRoleStrings returns a string or list of strings from a Vault
+// response as a list.
+func awsAuthBackendRoleStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		var result []string
+		for _, s := range v {
+			result = append(result, s.(string))
+		}
+		return result
+	}
+	return nil
 }
 
 func awsAuthBackendRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+var certAuthBackendConfigRegex = regexp.MustCompile("^auth/(.+)/config$")
+
+var (
+	certAuthBackendConfigBoolFields = []string{
+		consts.FieldDisableBinding,
+		consts.FieldEnableIdentityAliasMetadata,
+	}
+	certAuthBackendConfigIntFields = []string{
+		consts.FieldOCSPCacheSize,
+		consts.FieldRoleCacheSize,
+	}
+)
+
+func certAuthBackendConfigResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: certAuthBackendConfigWrite,
+		UpdateContext: certAuthBackendConfigWrite,
+		ReadContext:   provider.ReadContextWrapper(certAuthBackendConfigRead),
+		DeleteContext: certAuthBackendConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeCert,
+				Description:  "Path where the cert auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldDisableBinding: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "If set, during renewal, skips the matching of presented client identity " +
+					"with the client identity used during login.",
+			},
+			consts.FieldEnableIdentityAliasMetadata: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "If set, metadata of the certificate including the metadata corresponding " +
+					"to allowed_metadata_extensions will be stored in the alias.",
+			},
+			consts.FieldOCSPCacheSize: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The size of the in-memory OCSP response cache, shared by all configured certs.",
+			},
+			consts.FieldRoleCacheSize: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The size of the in-memory role cache. Use -1 to disable the cache.",
+			},
+		},
+	}
+}
+
+func certAuthBackendConfigPath(mount string) string {
+	return fmt.Sprintf("auth/%s/config", mount)
+}
+
+func certAuthBackendConfigWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := certAuthBackendConfigPath(d.Get(consts.FieldMount).(string))
+
+	data := map[string]interface{}{}
+	for _, k := range certAuthBackendConfigBoolFields {
+		data[k] = d.Get(k)
+	}
+	// the cache sizes are only sent when configured, older versions of
+	// Vault do not support them
+	for _, k := range certAuthBackendConfigIntFields {
+		if v, ok := d.GetOk(k); ok {
+			data[k] = v
+		}
+	}
+
+	log.Printf("[DEBUG] Writing cert auth config to %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing cert auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote cert auth config to %q", path)
+
+	d.SetId(path)
+
+	return certAuthBackendConfigRead(ctx, d, meta)
+}
+
+func certAuthBackendConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := certAuthBackendConfigRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for cert auth config, expected auth/<mount>/config", path)
+	}
+
+	log.Printf("[DEBUG] Reading cert auth config from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading cert auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read cert auth config from %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] Cert auth config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, k := range append(certAuthBackendConfigBoolFields, certAuthBackendConfigIntFields...) {
+		if v, ok := resp.Data[k]; ok {
+			if err := d.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func certAuthBackendConfigDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing cert auth config %q from state, it cannot be deleted from Vault", d.Id())
+	return nil
+}
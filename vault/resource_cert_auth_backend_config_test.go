@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccCertAuthBackendConfig(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-cert")
+	resourceName := "vault_cert_auth_backend_config.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck: func() {
+			testutil.TestAccPreCheck(t)
+			SkipIfAPIVersionLT(t, testProvider.Meta(), provider.VaultVersion114)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertAuthBackendConfigConfig(mount, false, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/config"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldDisableBinding, "false"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldEnableIdentityAliasMetadata, "true"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldOCSPCacheSize, "100"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldRoleCacheSize, "500"),
+				),
+			},
+			{
+				Config: testAccCertAuthBackendConfigConfig(mount, true, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldDisableBinding, "true"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldOCSPCacheSize, "1000"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil),
+		},
+	})
+}
+
+func testAccCertAuthBackendConfigConfig(mount string, disableBinding bool, ocspCacheSize int) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "cert"
+  path = "%s"
+}
+
+resource "vault_cert_auth_backend_config" "test" {
+  mount                          = vault_auth_backend.test.path
+  disable_binding                = %t
+  enable_identity_alias_metadata = true
+  ocsp_cache_size                = %d
+  role_cache_size                = 500
+}
+`, mount, disableBinding, ocspCacheSize)
+}
@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const (
+	certAuthCRLPEMType = "X509 CRL"
+	// certAuthCRLMaxSize bounds the size of the CRLs fetched from a URL.
+	certAuthCRLMaxSize = 64 << 20
+)
+
+var certAuthBackendCRLRegex = regexp.MustCompile("^auth/(.+)/crls/([^/]+)$")
+
+func certAuthBackendCRLResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: certAuthBackendCRLWrite,
+		UpdateContext: certAuthBackendCRLWrite,
+		ReadContext:   provider.ReadContextWrapper(certAuthBackendCRLRead),
+		DeleteContext: certAuthBackendCRLDelete,
+		CustomizeDiff: certAuthBackendCRLCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeCert,
+				Description:  "Path where the cert auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldName: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the CRL.",
+			},
+			consts.FieldCRL: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The PEM encoded CRL.",
+				ExactlyOneOf: []string{consts.FieldCRL, consts.FieldURL},
+			},
+			consts.FieldURL: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "URL of the CRL, fetched at apply time. The CRL is fetched again " +
+					"once its next update time has passed.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				ExactlyOneOf: []string{consts.FieldCRL, consts.FieldURL},
+			},
+			consts.FieldThisUpdate: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The issue time of the CRL, in RFC3339 format.",
+			},
+			consts.FieldNextUpdate: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time by which the next CRL will be issued, in RFC3339 format.",
+			},
+			consts.FieldCRLNumber: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CRL number of the CRL.",
+			},
+			consts.FieldEntryCount: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of revoked serial numbers loaded in Vault.",
+			},
+		},
+	}
+}
+
+func certAuthBackendCRLPath(mount, name string) string {
+	return fmt.Sprintf("auth/%s/crls/%s", mount, name)
+}
+
+// parseCertAuthCRL parses a PEM or DER encoded CRL, returning it PEM encoded.
+func parseCertAuthCRL(b []byte) (*x509.RevocationList, string, error) {
+	der := b
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type != certAuthCRLPEMType {
+			return nil, "", fmt.Errorf("unexpected PEM block type %q, expected %q", block.Type, certAuthCRLPEMType)
+		}
+		der = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing CRL: %w", err)
+	}
+
+	return crl, string(pem.EncodeToMemory(&pem.Block{Type: certAuthCRLPEMType, Bytes: der})), nil
+}
+
+// certAuthCRLFields returns the computed fields describing the CRL.
+func certAuthCRLFields(crl *x509.RevocationList) map[string]interface{} {
+	var crlNumber string
+	if crl.Number != nil {
+		crlNumber = crl.Number.String()
+	}
+
+	var nextUpdate string
+	if !crl.NextUpdate.IsZero() {
+		nextUpdate = crl.NextUpdate.UTC().Format(time.RFC3339)
+	}
+
+	return map[string]interface{}{
+		consts.FieldThisUpdate: crl.ThisUpdate.UTC().Format(time.RFC3339),
+		consts.FieldNextUpdate: nextUpdate,
+		consts.FieldCRLNumber:  crlNumber,
+	}
+}
+
+// fetchCertAuthCRL fetches the CRL from the URL.
+func fetchCertAuthCRL(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Fetching CRL from %q", url)
+	resp, err := cleanhttp.DefaultClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching CRL from %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching CRL from %q: unexpected status %s", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, certAuthCRLMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching CRL from %q: %w", url, err)
+	}
+	if len(b) > certAuthCRLMaxSize {
+		return nil, fmt.Errorf("error fetching CRL from %q: larger than %d bytes", url, certAuthCRLMaxSize)
+	}
+
+	return b, nil
+}
+
+// certAuthBackendCRLCustomizeDiff validates the PEM encoded CRL at plan time,
+// and plans to fetch the CRL again from its URL once its next update time has
+// passed.
+func certAuthBackendCRLCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.HasChange(consts.FieldCRL) {
+		if !diff.NewValueKnown(consts.FieldCRL) || diff.Get(consts.FieldCRL).(string) == "" {
+			return certAuthBackendCRLSetNewComputed(diff)
+		}
+
+		crl, _, err := parseCertAuthCRL([]byte(diff.Get(consts.FieldCRL).(string)))
+		if err != nil {
+			return err
+		}
+
+		for k, v := range certAuthCRLFields(crl) {
+			if err := diff.SetNew(k, v); err != nil {
+				return err
+			}
+		}
+		return diff.SetNewComputed(consts.FieldEntryCount)
+	}
+
+	if diff.Id() == "" || diff.Get(consts.FieldURL).(string) == "" {
+		return nil
+	}
+
+	if diff.HasChange(consts.FieldURL) {
+		return certAuthBackendCRLSetNewComputed(diff)
+	}
+
+	nextUpdate := diff.Get(consts.FieldNextUpdate).(string)
+	if nextUpdate == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, nextUpdate)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", consts.FieldNextUpdate, nextUpdate, err)
+	}
+
+	if time.Now().Before(t) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] CRL %q is past its next update time, it will be fetched again", diff.Id())
+	return certAuthBackendCRLSetNewComputed(diff)
+}
+
+func certAuthBackendCRLSetNewComputed(diff *schema.ResourceDiff) error {
+	for _, k := range []string{
+		consts.FieldThisUpdate,
+		consts.FieldNextUpdate,
+		consts.FieldCRLNumber,
+		consts.FieldEntryCount,
+	} {
+		if err := diff.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func certAuthBackendCRLWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := certAuthBackendCRLPath(d.Get(consts.FieldMount).(string), d.Get(consts.FieldName).(string))
+
+	raw := []byte(d.Get(consts.FieldCRL).(string))
+	if url := d.Get(consts.FieldURL).(string); url != "" {
+		var err error
+		raw, err = fetchCertAuthCRL(ctx, url)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	crl, crlPEM, err := parseCertAuthCRL(raw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Writing cert auth CRL to %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+		consts.FieldCRL: crlPEM,
+	}); err != nil {
+		return diag.Errorf("error writing cert auth CRL %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote cert auth CRL to %q", path)
+
+	d.SetId(path)
+
+	for k, v := range certAuthCRLFields(crl) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return certAuthBackendCRLRead(ctx, d, meta)
+}
+
+func certAuthBackendCRLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := certAuthBackendCRLRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for cert auth CRL, expected auth/<mount>/crls/<name>", path)
+	}
+
+	log.Printf("[DEBUG] Reading cert auth CRL from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading cert auth CRL %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read cert auth CRL from %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] Cert auth CRL %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldName, m[2]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	serials, _ := resp.Data["serials"].(map[string]interface{})
+	if err := d.Set(consts.FieldEntryCount, len(serials)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func certAuthBackendCRLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting cert auth CRL %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil {
+		return diag.Errorf("error deleting cert auth CRL %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted cert auth CRL %q", path)
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccCertAuthBackendCRL(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-cert")
+	resourceName := "vault_cert_auth_backend_crl.test"
+
+	nextUpdate := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	crl := testCertAuthCRL(t, 1, nextUpdate, 1, 2)
+	updated := testCertAuthCRL(t, 2, nextUpdate, 1, 2, 3)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := pem.Decode([]byte(updated))
+		w.Write(b.Bytes)
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertAuthBackendCRLConfig(mount, fmt.Sprintf("crl = <<EOT\n%sEOT", crl)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/crls/test"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldCRLNumber, "1"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldNextUpdate, nextUpdate.Format(time.RFC3339)),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldEntryCount, "2"),
+				),
+			},
+			{
+				Config: testAccCertAuthBackendCRLConfig(mount, fmt.Sprintf("url = %q", server.URL)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldCRL, ""),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldCRLNumber, "2"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldEntryCount, "3"),
+				),
+			},
+			{
+				Config:      testAccCertAuthBackendCRLConfig(mount, `crl = "invalid"`),
+				ExpectError: regexp.MustCompile("error parsing CRL"),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil,
+				consts.FieldURL, consts.FieldThisUpdate, consts.FieldNextUpdate, consts.FieldCRLNumber),
+		},
+	})
+}
+
+func testAccCertAuthBackendCRLConfig(mount, source string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "cert"
+  path = "%s"
+}
+
+resource "vault_cert_auth_backend_crl" "test" {
+  mount = vault_auth_backend.test.path
+  name  = "test"
+  %s
+}
+`, mount, source)
+}
+
+// testCertAuthCRL returns a PEM encoded CRL revoking the serials.
+func testCertAuthCRL(t *testing.T, number int64, nextUpdate time.Time, serials ...int64) string {
+	t.Helper()
+
+	issuer, signer := testPKIRevocationIssuer(t)
+
+	var entries []x509.RevocationListEntry
+	for _, s := range serials {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(s),
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(number),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, issuer, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: certAuthCRLPEMType, Bytes: der}))
+}
+
+func TestParseCertAuthCRL(t *testing.T) {
+	nextUpdate := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	crlPEM := testCertAuthCRL(t, 7, nextUpdate, 1)
+	block, _ := pem.Decode([]byte(crlPEM))
+
+	for name, input := range map[string][]byte{
+		"pem": []byte(crlPEM),
+		"der": block.Bytes,
+	} {
+		t.Run(name, func(t *testing.T) {
+			crl, actualPEM, err := parseCertAuthCRL(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actualPEM != crlPEM {
+				t.Errorf("expected %q, got %q", crlPEM, actualPEM)
+			}
+
+			fields := certAuthCRLFields(crl)
+			if fields[consts.FieldCRLNumber] != "7" {
+				t.Errorf("expected CRL number 7, got %v", fields[consts.FieldCRLNumber])
+			}
+			if fields[consts.FieldNextUpdate] != nextUpdate.Format(time.RFC3339) {
+				t.Errorf("expected next update %s, got %v", nextUpdate.Format(time.RFC3339), fields[consts.FieldNextUpdate])
+			}
+		})
+	}
+
+	cert, _, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := parseCertAuthCRL(cert); err == nil || !strings.Contains(err.Error(), "unexpected PEM block type") {
+		t.Errorf("expected an error for a certificate, got %v", err)
+	}
+
+	if _, _, err := parseCertAuthCRL([]byte("invalid")); err == nil {
+		t.Error("expected an error for an invalid CRL")
+	}
+}
+
+func TestFetchCertAuthCRL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/crl" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("crl"))
+	}))
+	defer server.Close()
+
+	b, err := fetchCertAuthCRL(context.Background(), server.URL+"/crl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "crl" {
+		t.Errorf("expected %q, got %q", "crl", b)
+	}
+
+	if _, err := fetchCertAuthCRL(context.Background(), server.URL+"/missing"); err == nil ||
+		!strings.Contains(err.Error(), "unexpected status 404") {
+		t.Errorf("expected an error for a missing CRL, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var cfAuthBackendConfigRegex = regexp.MustCompile("^auth/(.+)/config$")
+
+var (
+	// cfAuthBackendConfigFields are returned by Vault, the credentials are
+	// write-only.
+	cfAuthBackendConfigFields = []string{
+		consts.FieldIdentityCACertificates,
+		consts.FieldCFAPIAddr,
+		consts.FieldCFUsername,
+		consts.FieldCFClientID,
+		consts.FieldCFAPITrustedCertificates,
+		consts.FieldCFAPIMutualTLSCertificate,
+		consts.FieldLoginMaxSecondsNotBefore,
+		consts.FieldLoginMaxSecondsNotAfter,
+	}
+	cfAuthBackendConfigSensitiveFields = []string{
+		consts.FieldCFPassword,
+		consts.FieldCFClientSecret,
+		consts.FieldCFAPIMutualTLSKey,
+	}
+)
+
+func cfAuthBackendConfigResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: cfAuthBackendConfigWrite,
+		UpdateContext: cfAuthBackendConfigWrite,
+		ReadContext:   provider.ReadContextWrapper(cfAuthBackendConfigRead),
+		DeleteContext: cfAuthBackendConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeCF,
+				Description:  "Path where the CF auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldIdentityCACertificates: {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "PEM encoded root CA certificates used to verify the CF instance identity certificates.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldCFAPIAddr: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The address of the CF API.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			consts.FieldCFUsername: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The username of the CF API user.",
+				RequiredWith: []string{consts.FieldCFPassword},
+			},
+			consts.FieldCFPassword: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The password of the CF API user. It cannot be read back from Vault.",
+				RequiredWith: []string{consts.FieldCFUsername},
+			},
+			consts.FieldCFClientID: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The client ID used to authenticate to the CF API.",
+				RequiredWith: []string{consts.FieldCFClientSecret},
+			},
+			consts.FieldCFClientSecret: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The client secret used to authenticate to the CF API. It cannot be read back from Vault.",
+				RequiredWith: []string{consts.FieldCFClientID},
+			},
+			consts.FieldCFAPITrustedCertificates: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "PEM encoded certificates used to verify the CF API certificate.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldCFAPIMutualTLSCertificate: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "PEM encoded client certificate used to authenticate to the CF API.",
+				RequiredWith: []string{consts.FieldCFAPIMutualTLSKey},
+			},
+			consts.FieldCFAPIMutualTLSKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "PEM encoded key of the client certificate. It cannot be read back from Vault.",
+				RequiredWith: []string{consts.FieldCFAPIMutualTLSCertificate},
+			},
+			consts.FieldLoginMaxSecondsNotBefore: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				Description:  "Maximum number of seconds in the past when a login signature could have been created.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			consts.FieldLoginMaxSecondsNotAfter: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				Description:  "Maximum number of seconds in the future when a login signature could have been created.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			consts.FieldCFTimeout: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Timeout of the requests to the CF API, in seconds.",
+			},
+		},
+	}
+}
+
+func cfAuthBackendConfigPath(mount string) string {
+	return fmt.Sprintf("auth/%s/config", mount)
+}
+
+func cfAuthBackendConfigWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := cfAuthBackendConfigPath(d.Get(consts.FieldMount).(string))
+
+	data := map[string]interface{}{}
+	for _, k := range append(cfAuthBackendConfigFields, cfAuthBackendConfigSensitiveFields...) {
+		data[k] = d.Get(k)
+	}
+	// the timeout is only sent when configured, older versions of Vault do
+	// not support it
+	if v, ok := d.GetOk(consts.FieldCFTimeout); ok {
+		data[consts.FieldCFTimeout] = v
+	}
+
+	log.Printf("[DEBUG] Writing CF auth config to %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing CF auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote CF auth config to %q", path)
+
+	d.SetId(path)
+
+	return cfAuthBackendConfigRead(ctx, d, meta)
+}
+
+func cfAuthBackendConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := cfAuthBackendConfigRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for CF auth config, expected auth/<mount>/config", path)
+	}
+
+	log.Printf("[DEBUG] Reading CF auth config from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading CF auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read CF auth config from %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] CF auth config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, k := range append(cfAuthBackendConfigFields, consts.FieldCFTimeout) {
+		if v, ok := resp.Data[k]; ok {
+			if err := d.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func cfAuthBackendConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting CF auth config %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting CF auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted CF auth config %q", path)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+func TestAccCFAuthBackendConfig(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-cf")
+	resourceName := "vault_cf_auth_backend_config.test"
+
+	caCert, _, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccCFAuthBackendConfigCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCFAuthBackendConfigConfig(mount, string(caCert), 300),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/config"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldIdentityCACertificates+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldCFAPIAddr, "https://api.cf.example.com"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldCFUsername, "vault"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldLoginMaxSecondsNotBefore, "300"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldLoginMaxSecondsNotAfter, "60"),
+				),
+			},
+			{
+				Config: testAccCFAuthBackendConfigConfig(mount, string(caCert), 120),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldLoginMaxSecondsNotBefore, "120"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil, consts.FieldCFPassword),
+		},
+	})
+}
+
+func testAccCFAuthBackendConfigCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_cf_auth_backend_config" {
+			continue
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil && !util.Is404(err) {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("CF auth config %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCFAuthBackendConfigConfig(mount, caCert string, notBefore int) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "cf"
+  path = "%s"
+}
+
+resource "vault_cf_auth_backend_config" "test" {
+  mount                        = vault_auth_backend.test.path
+  identity_ca_certificates     = [%q]
+  cf_api_addr                  = "https://api.cf.example.com"
+  cf_username                  = "vault"
+  cf_password                  = "s3cr3t"
+  login_max_seconds_not_before = %d
+}
+`, mount, caCert, notBefore)
+}
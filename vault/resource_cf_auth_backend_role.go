@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var cfAuthBackendRoleRegex = regexp.MustCompile("^auth/(.+)/roles/([^/]+)$")
+
+var cfAuthBackendRoleBoundFields = []string{
+	consts.FieldBoundApplicationIDs,
+	consts.FieldBoundSpaceIDs,
+	consts.FieldBoundOrganizationIDs,
+	consts.FieldBoundInstanceIDs,
+}
+
+func cfAuthBackendRoleResource() *schema.Resource {
+	fields := map[string]*schema.Schema{
+		consts.FieldMount: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      consts.MountTypeCF,
+			Description:  "Path where the CF auth method is mounted.",
+			ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+		},
+		consts.FieldName: {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the role.",
+		},
+		consts.FieldBoundApplicationIDs: {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Application IDs allowed to log in with the role.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldBoundSpaceIDs: {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Space IDs allowed to log in with the role.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldBoundOrganizationIDs: {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Organization IDs allowed to log in with the role.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldBoundInstanceIDs: {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Instance IDs allowed to log in with the role.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldDisableIPMatching: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Disable the matching of the IP address of the login request with the IP " +
+				"addresses of the instance certificate.",
+		},
+	}
+
+	addTokenFields(fields, &addTokenFieldsConfig{})
+
+	return &schema.Resource{
+		CreateContext: cfAuthBackendRoleWrite,
+		UpdateContext: cfAuthBackendRoleWrite,
+		ReadContext:   provider.ReadContextWrapper(cfAuthBackendRoleRead),
+		DeleteContext: cfAuthBackendRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: fields,
+	}
+}
+
+func cfAuthBackendRolePath(mount, name string) string {
+	return fmt.Sprintf("auth/%s/roles/%s", mount, name)
+}
+
+func cfAuthBackendRoleWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := cfAuthBackendRolePath(d.Get(consts.FieldMount).(string), d.Get(consts.FieldName).(string))
+
+	data := map[string]interface{}{
+		consts.FieldDisableIPMatching: d.Get(consts.FieldDisableIPMatching),
+	}
+	for _, k := range cfAuthBackendRoleBoundFields {
+		data[k] = d.Get(k).(*schema.Set).List()
+	}
+	updateTokenFields(d, data, d.IsNewResource())
+
+	log.Printf("[DEBUG] Writing CF auth role %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing CF auth role %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote CF auth role %q", path)
+
+	d.SetId(path)
+
+	return cfAuthBackendRoleRead(ctx, d, meta)
+}
+
+func cfAuthBackendRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := cfAuthBackendRoleRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for CF auth role, expected auth/<mount>/roles/<name>", path)
+	}
+
+	log.Printf("[DEBUG] Reading CF auth role %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading CF auth role %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read CF auth role %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] CF auth role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldName, m[2]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, k := range append(cfAuthBackendRoleBoundFields, consts.FieldDisableIPMatching) {
+		if err := d.Set(k, resp.Data[k]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := readTokenFields(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func cfAuthBackendRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting CF auth role %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting CF auth role %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted CF auth role %q", path)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccCFAuthBackendRole(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-cf")
+	resourceName := "vault_cf_auth_backend_role.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccCFAuthBackendRoleCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCFAuthBackendRoleConfig(mount, `["app-1"]`, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/roles/payments"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldName, "payments"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBoundApplicationIDs+".#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldBoundApplicationIDs+".*", "app-1"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBoundSpaceIDs+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBoundOrganizationIDs+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBoundInstanceIDs+".#", "0"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldDisableIPMatching, "false"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldPolicies+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldTTL, "3600"),
+				),
+			},
+			{
+				Config: testAccCFAuthBackendRoleConfig(mount, `["app-1", "app-2"]`, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBoundApplicationIDs+".#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldBoundApplicationIDs+".*", "app-2"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldDisableIPMatching, "true"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil),
+		},
+	})
+}
+
+func testAccCFAuthBackendRoleCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_cf_auth_backend_role" {
+			continue
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("CF auth role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCFAuthBackendRoleConfig(mount, applicationIDs string, disableIPMatching bool) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "cf"
+  path = "%s"
+}
+
+resource "vault_cf_auth_backend_role" "test" {
+  mount                  = vault_auth_backend.test.path
+  name                   = "payments"
+  bound_application_ids  = %s
+  bound_space_ids        = ["space-1"]
+  bound_organization_ids = ["org-1"]
+  disable_ip_matching    = %t
+  token_policies         = ["payments"]
+  token_ttl              = 3600
+}
+`, mount, applicationIDs, disableIPMatching)
+}
@@ -6,6 +6,7 @@ package vault
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 )
 
@@ -13,10 +14,11 @@ var egpPolicyAttributes = []string{"enforcement_level", "paths", "policy"}
 
 func egpPolicyResource() *schema.Resource {
 	return &schema.Resource{
-		Create: egpPolicyWrite,
-		Update: egpPolicyWrite,
-		Delete: egpPolicyDelete,
-		Read:   provider.ReadWrapper(egpPolicyRead),
+		Create:        egpPolicyWrite,
+		Update:        egpPolicyWrite,
+		Delete:        egpPolicyDelete,
+		Read:          provider.ReadWrapper(egpPolicyRead),
+		CustomizeDiff: sentinelPolicyCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -50,6 +52,8 @@ func egpPolicyResource() *schema.Resource {
 				Required:    true,
 				Description: "The policy document",
 			},
+
+			consts.FieldTest: sentinelPolicyTestSchema(),
 		},
 	}
 }
@@ -227,15 +227,8 @@ func gcpAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
 		data["custom_endpoint"] = endpoints
 	}
 
-	if d.HasChange("tune") {
-		log.Printf("[INFO] %s Auth %q tune configuration changed", gcpAuthType, gcpAuthPath)
-		if raw, ok := d.GetOk("tune"); ok {
-			log.Printf("[DEBUG] Writing %s auth tune to %q", gcpAuthType, gcpAuthPath)
-			err := authMountTune(client, gcpAuthPath, raw)
-			if err != nil {
-				return nil
-			}
-		}
+	if err := authMountTuneUpdate(d, client, gcpAuthPath); err != nil {
+		return err
 	}
 
 	if d.HasChange("description") {
@@ -316,14 +309,7 @@ func gcpAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
 		d.SetId("")
 		return nil
 	}
-	log.Printf("[DEBUG] Reading %s auth tune from '%s/tune'", gcpAuthType, gcpAuthPath)
-	rawTune, err := authMountTuneGet(client, gcpAuthPath)
-	if err != nil {
-		return fmt.Errorf("error reading tune information from Vault: %w", err)
-	}
-	data := map[string]interface{}{}
-	data["tune"] = []map[string]interface{}{rawTune}
-	if err := util.SetResourceData(d, data); err != nil {
+	if err := authMountTuneRead(d, client, gcpAuthPath); err != nil {
 		return err
 	}
 
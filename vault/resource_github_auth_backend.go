@@ -142,18 +142,8 @@ func githubAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 	log.Printf("[INFO] Github auth config successfully written to '%q'", configPath)
 
-	if d.HasChange("tune") {
-		log.Printf("[INFO] Github Auth '%q' tune configuration changed", d.Id())
-		if raw, ok := d.GetOk("tune"); ok {
-			log.Printf("[DEBUG] Writing github auth tune to '%q'", path)
-
-			err := authMountTune(client, path, raw)
-			if err != nil {
-				return nil
-			}
-
-			log.Printf("[INFO] Written github auth tune to '%q'", path)
-		}
+	if err := authMountTuneUpdate(d, client, path); err != nil {
+		return err
 	}
 
 	if d.HasChange("description") {
@@ -199,19 +189,12 @@ func githubAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	log.Printf("[DEBUG] Reading github auth tune from '%q/tune'", path)
-	rawTune, err := authMountTuneGet(client, path)
-	if err != nil {
-		return fmt.Errorf("error reading tune information from Vault: %w", err)
-	}
-
 	data := getCommonTokenFieldMap(resp)
 	data["path"] = d.Id()
 	data["organization"] = resp.Data["organization"]
 	data["base_url"] = resp.Data["base_url"]
 	data["description"] = mount.Description
 	data["accessor"] = mount.Accessor
-	data["tune"] = []map[string]interface{}{rawTune}
 
 	if orgID, ok := resp.Data["organization_id"]; ok {
 		data["organization_id"] = orgID
@@ -221,6 +204,10 @@ func githubAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if err := authMountTuneRead(d, client, path); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -17,6 +17,12 @@ import (
 	"github.com/hashicorp/terraform-provider-vault/util"
 )
 
+// identityEntityManagedMetadataKey is set in the metadata of the entities
+// written by vault_identity_entity, so that other resources can detect the
+// entities that are still managed by Terraform. It is hidden from the metadata
+// of the resource.
+const identityEntityManagedMetadataKey = "terraform_managed"
+
 func identityEntityResource() *schema.Resource {
 	return &schema.Resource{
 		Create: identityEntityCreate,
@@ -85,9 +91,7 @@ func identityEntityUpdateFields(d *schema.ResourceData, data map[string]interfac
 			}
 		}
 
-		if metadata, ok := d.GetOk(consts.FieldMetadata); ok {
-			data["metadata"] = metadata
-		}
+		data["metadata"] = identityEntityManagedMetadata(d.Get(consts.FieldMetadata))
 
 		if disabled, ok := d.GetOk("disabled"); ok {
 			data["disabled"] = disabled
@@ -95,7 +99,7 @@ func identityEntityUpdateFields(d *schema.ResourceData, data map[string]interfac
 	} else {
 		if d.HasChanges("name", "external_policies", "policies", "metadata", "disabled") {
 			data["name"] = d.Get("name")
-			data["metadata"] = d.Get("metadata")
+			data["metadata"] = identityEntityManagedMetadata(d.Get("metadata"))
 			data["disabled"] = d.Get("disabled")
 			data["policies"] = d.Get("policies").(*schema.Set).List()
 
@@ -199,6 +203,10 @@ func identityEntityRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error reading IdentityEntity %q: %w", id, err)
 	}
 
+	if metadata, ok := resp.Data["metadata"].(map[string]interface{}); ok {
+		delete(metadata, identityEntityManagedMetadataKey)
+	}
+
 	for _, k := range []string{"name", "metadata", "disabled", "policies"} {
 		if err := d.Set(k, resp.Data[k]); err != nil {
 			return fmt.Errorf("error setting state key \"%s\" on IdentityEntity %q: %s", k, id, err)
@@ -257,6 +265,32 @@ func identityEntityExists(d *schema.ResourceData, meta interface{}) (bool, error
 	return resp != nil, nil
 }
 
+// identityEntityManagedMetadata returns a copy of the metadata with the
+// identityEntityManagedMetadataKey set.
+func identityEntityManagedMetadata(raw interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	if m, ok := raw.(map[string]interface{}); ok {
+		for k, v := range m {
+			metadata[k] = v
+		}
+	}
+	metadata[identityEntityManagedMetadataKey] = "true"
+
+	return metadata
+}
+
+// isIdentityEntityManaged returns whether the entity metadata read from Vault
+// carries the identityEntityManagedMetadataKey.
+func isIdentityEntityManaged(metadata interface{}) bool {
+	m, ok := metadata.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	_, ok = m[identityEntityManagedMetadataKey]
+	return ok
+}
+
 func identityEntityNamePath(name string) string {
 	return fmt.Sprintf("%s/name/%s", entity.RootEntityPath, name)
 }
@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/vault/api"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const identityEntityBatchDeletePath = "identity/entity/batch-delete"
+
+var identityEntityCleanupRuleFields = []string{
+	consts.FieldNoAliases,
+	consts.FieldInactiveDays,
+	consts.FieldNamePattern,
+}
+
+func identityEntityCleanupResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: identityEntityCleanupRun,
+		UpdateContext: identityEntityCleanupRun,
+		ReadContext:   provider.ReadContextWrapper(identityEntityCleanupRead),
+		DeleteContext: identityEntityCleanupDelete,
+		CustomizeDiff: identityEntityCleanupCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldNoAliases: {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Description:  "Match entities that have no aliases. Requires inactive_days or name_pattern.",
+				AtLeastOneOf: identityEntityCleanupRuleFields,
+			},
+			consts.FieldInactiveDays: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Match entities whose last_update_time is older than this many days.",
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: identityEntityCleanupRuleFields,
+			},
+			consts.FieldNamePattern: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Match entities whose name matches this regular expression.",
+				ValidateFunc: validation.StringIsValidRegExp,
+				AtLeastOneOf: identityEntityCleanupRuleFields,
+			},
+			consts.FieldExcludeEntityIDs: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "IDs of entities that are never deleted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldExcludeNames: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Names of entities that are never deleted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldDryRun: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Only report the matching entities without deleting them.",
+			},
+			consts.FieldBatchSize: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "Number of entities deleted per request.",
+				ValidateFunc: validation.IntBetween(1, 1000),
+			},
+			consts.FieldBatchInterval: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1s",
+				Description:  "Time to wait between two delete batches.",
+				ValidateFunc: provider.ValidateDurationSecond,
+			},
+			consts.FieldMaxParallelReads: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultIdentityMaxParallelReads,
+				Description:  "Maximum number of entities read from Vault in parallel.",
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			consts.FieldMatchedEntityIDs: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the entities that matched the rules on the last run.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldDeletedEntityIDs: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the entities deleted on the last run.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// identityEntityCleanupCustomizeDiff requires no_aliases to be combined with
+// another rule, entities created through the API, such as the ones managed by
+// vault_identity_entity, legitimately have no aliases.
+func identityEntityCleanupCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.Get(consts.FieldNoAliases).(bool) {
+		return nil
+	}
+
+	for _, k := range []string{consts.FieldInactiveDays, consts.FieldNamePattern} {
+		if !diff.NewValueKnown(k) {
+			return nil
+		}
+		if _, ok := diff.GetOk(k); ok {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s requires %s or %s to be set",
+		consts.FieldNoAliases, consts.FieldInactiveDays, consts.FieldNamePattern)
+}
+
+// identityEntityCleanupRules selects the entities to delete, an entity must
+// match every rule that is set and must not be excluded. The entities managed
+// by vault_identity_entity are always excluded.
+type identityEntityCleanupRules struct {
+	noAliases    bool
+	inactiveDays int
+	namePattern  *regexp.Regexp
+	excludeIDs   map[string]bool
+	excludeNames map[string]bool
+}
+
+func newIdentityEntityCleanupRules(d *schema.ResourceData) (*identityEntityCleanupRules, error) {
+	r := &identityEntityCleanupRules{
+		noAliases:    d.Get(consts.FieldNoAliases).(bool),
+		inactiveDays: d.Get(consts.FieldInactiveDays).(int),
+		excludeIDs:   map[string]bool{},
+		excludeNames: map[string]bool{},
+	}
+
+	if v, ok := d.GetOk(consts.FieldNamePattern); ok {
+		re, err := regexp.Compile(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", consts.FieldNamePattern, err)
+		}
+		r.namePattern = re
+	}
+
+	for _, v := range d.Get(consts.FieldExcludeEntityIDs).(*schema.Set).List() {
+		r.excludeIDs[v.(string)] = true
+	}
+	for _, v := range d.Get(consts.FieldExcludeNames).(*schema.Set).List() {
+		r.excludeNames[v.(string)] = true
+	}
+
+	return r, nil
+}
+
+func (r *identityEntityCleanupRules) match(ent *entity.Entity, now time.Time) bool {
+	if r.excludeIDs[ent.ID] || r.excludeNames[ent.Name] {
+		return false
+	}
+
+	if isIdentityEntityManaged(ent.Metadata) {
+		return false
+	}
+
+	if r.noAliases && len(ent.Aliases) > 0 {
+		return false
+	}
+
+	if r.inactiveDays > 0 {
+		updated, err := time.Parse(time.RFC3339Nano, ent.LastUpdateTime)
+		if err != nil {
+			log.Printf("[WARN] Skipping IdentityEntity %q with invalid last_update_time %q: %s",
+				ent.ID, ent.LastUpdateTime, err)
+			return false
+		}
+
+		if now.Sub(updated) < time.Duration(r.inactiveDays)*24*time.Hour {
+			return false
+		}
+	}
+
+	if r.namePattern != nil && !r.namePattern.MatchString(ent.Name) {
+		return false
+	}
+
+	return true
+}
+
+func identityEntityCleanupRun(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	rules, err := newIdentityEntityCleanupRules(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Listing IdentityEntities from %q", entity.RootEntityIDPath)
+	resp, err := entity.ReadAll(ctx, client, entity.RootEntityIDPath, d.Get(consts.FieldMaxParallelReads).(int))
+	if err != nil {
+		return diag.Errorf("error reading IdentityEntities: %s", err)
+	}
+
+	now := time.Now()
+	var matched []string
+	for _, data := range resp {
+		var ent entity.Entity
+		if err := mapstructure.Decode(data, &ent); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if rules.match(&ent, now) {
+			matched = append(matched, ent.ID)
+		}
+	}
+	sort.Strings(matched)
+	log.Printf("[DEBUG] %d of %d IdentityEntities match the cleanup rules", len(matched), len(resp))
+
+	d.SetId(entity.RootEntityIDPath)
+
+	if err := d.Set(consts.FieldMatchedEntityIDs, matched); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var deleted []string
+	if d.Get(consts.FieldDryRun).(bool) {
+		log.Printf("[INFO] Dry run, not deleting IdentityEntities %v", matched)
+	} else {
+		interval, err := parseutil.ParseDurationSecond(d.Get(consts.FieldBatchInterval))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		deleted, err = deleteIdentityEntitiesInBatches(ctx, client, matched, d.Get(consts.FieldBatchSize).(int), interval)
+		if err != nil {
+			// record the partial progress before failing
+			if e := d.Set(consts.FieldDeletedEntityIDs, deleted); e != nil {
+				log.Printf("[WARN] Failed to set %s: %s", consts.FieldDeletedEntityIDs, e)
+			}
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set(consts.FieldDeletedEntityIDs, deleted); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// deleteIdentityEntitiesInBatches deletes the entities batchSize at a time,
+// waiting interval between two batches. It returns the IDs of the entities
+// that were deleted, also on error.
+func deleteIdentityEntitiesInBatches(ctx context.Context, client *api.Client, ids []string, batchSize int, interval time.Duration) ([]string, error) {
+	var deleted []string
+	for start := 0; start < len(ids); start += batchSize {
+		if start > 0 && interval > 0 {
+			select {
+			case <-ctx.Done():
+				return deleted, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		log.Printf("[DEBUG] Deleting IdentityEntities %v", batch)
+		if _, err := client.Logical().WriteWithContext(ctx, identityEntityBatchDeletePath, map[string]interface{}{
+			"entity_ids": batch,
+		}); err != nil {
+			return deleted, fmt.Errorf("error deleting IdentityEntities %v: %w", batch, err)
+		}
+		deleted = append(deleted, batch...)
+	}
+
+	return deleted, nil
+}
+
+func identityEntityCleanupRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// the results of the last run are kept as they are, the cleanup is
+	// repeated on every change to the rules.
+	return nil
+}
+
+func identityEntityCleanupDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing IdentityEntity cleanup %q from state only", d.Id())
+	return nil
+}
@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccIdentityEntityCleanup(t *testing.T) {
+	testutil.SkipTestAcc(t)
+	testutil.TestAccPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-cleanup")
+	resourceName := "vault_identity_entity_cleanup.test"
+
+	client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+	ids := map[string]string{}
+	for _, suffix := range []string{"a", "b", "keep"} {
+		resp, err := client.Logical().Write(entity.RootEntityPath, map[string]interface{}{
+			consts.FieldName: fmt.Sprintf("%s-%s", name, suffix),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := resp.Data[consts.FieldID].(string)
+		ids[suffix] = id
+
+		t.Cleanup(func() {
+			client.Logical().Delete(entity.JoinEntityID(id))
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityEntityCleanupConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "matched_entity_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "matched_entity_ids.*", ids["a"]),
+					resource.TestCheckTypeSetElemAttr(resourceName, "matched_entity_ids.*", ids["b"]),
+					resource.TestCheckResourceAttr(resourceName, "deleted_entity_ids.#", "0"),
+					testAccIdentityEntityCleanupCheckExists(ids["a"], true),
+				),
+			},
+			{
+				Config: testAccIdentityEntityCleanupConfig(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "matched_entity_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "deleted_entity_ids.#", "2"),
+					testAccIdentityEntityCleanupCheckExists(ids["a"], false),
+					testAccIdentityEntityCleanupCheckExists(ids["b"], false),
+					testAccIdentityEntityCleanupCheckExists(ids["keep"], true),
+				),
+			},
+		},
+	})
+}
+
+func testAccIdentityEntityCleanupCheckExists(id string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+
+		resp, err := client.Logical().Read(entity.JoinEntityID(id))
+		if err != nil {
+			return err
+		}
+
+		if actual := resp != nil; actual != expected {
+			return fmt.Errorf("expected entity %q to exist=%t, got %t", id, expected, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccIdentityEntityCleanupConfig(name string, dryRun bool) string {
+	return fmt.Sprintf(`
+resource "vault_identity_entity_cleanup" "test" {
+  no_aliases     = true
+  name_pattern   = "^%[1]s-"
+  exclude_names  = ["%[1]s-keep"]
+  dry_run        = %[2]t
+  batch_size     = 1
+  batch_interval = "100ms"
+}
+`, name, dryRun)
+}
+
+func TestIdentityEntityCleanupRules(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	recent := now.Add(-24 * time.Hour).Format(time.RFC3339Nano)
+	old := now.Add(-100 * 24 * time.Hour).Format(time.RFC3339Nano)
+
+	tests := []struct {
+		name   string
+		raw    map[string]interface{}
+		entity *entity.Entity
+		want   bool
+	}{
+		{
+			name: "no-aliases",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases: true,
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob"},
+			want:   true,
+		},
+		{
+			name: "has-aliases",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases: true,
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob", Aliases: []*entity.Alias{{ID: "a"}}},
+			want:   false,
+		},
+		{
+			name: "inactive",
+			raw: map[string]interface{}{
+				consts.FieldInactiveDays: 90,
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob", LastUpdateTime: old},
+			want:   true,
+		},
+		{
+			name: "active",
+			raw: map[string]interface{}{
+				consts.FieldInactiveDays: 90,
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob", LastUpdateTime: recent},
+			want:   false,
+		},
+		{
+			name: "invalid-last-update-time",
+			raw: map[string]interface{}{
+				consts.FieldInactiveDays: 90,
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob", LastUpdateTime: "yesterday"},
+			want:   false,
+		},
+		{
+			name: "name-pattern",
+			raw: map[string]interface{}{
+				consts.FieldNamePattern: "^entity_",
+			},
+			entity: &entity.Entity{ID: "1", Name: "entity_5d2f"},
+			want:   true,
+		},
+		{
+			name: "name-pattern-mismatch",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases:   true,
+				consts.FieldNamePattern: "^entity_",
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob"},
+			want:   false,
+		},
+		{
+			name: "managed",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases:   true,
+				consts.FieldNamePattern: "^bob",
+			},
+			entity: &entity.Entity{
+				ID:       "1",
+				Name:     "bob",
+				Metadata: map[string]interface{}{identityEntityManagedMetadataKey: "true"},
+			},
+			want: false,
+		},
+		{
+			name: "excluded-id",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases:        true,
+				consts.FieldExcludeEntityIDs: []interface{}{"1"},
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob"},
+			want:   false,
+		},
+		{
+			name: "excluded-name",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases:    true,
+				consts.FieldExcludeNames: []interface{}{"bob"},
+			},
+			entity: &entity.Entity{ID: "1", Name: "bob"},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, identityEntityCleanupResource().Schema, tt.raw)
+			rules, err := newIdentityEntityCleanupRules(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rules.match(tt.entity, now); got != tt.want {
+				t.Errorf("match() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteIdentityEntitiesInBatches(t *testing.T) {
+	var batches [][]string
+	config, ln := testutil.TestHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/v1/"+identityEntityBatchDeletePath {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+
+		var data struct {
+			EntityIDs []string `json:"entity_ids"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&data); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// fail on the third batch
+		if len(batches) == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		batches = append(batches, data.EntityIDs)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ln.Close()
+
+	config.MaxRetries = 0
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := deleteIdentityEntitiesInBatches(context.Background(), client,
+		[]string{"1", "2", "3", "4", "5"}, 2, time.Millisecond)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if expected := [][]string{{"1", "2"}, {"3", "4"}}; !reflect.DeepEqual(expected, batches) {
+		t.Errorf("expected batches %v, got %v", expected, batches)
+	}
+	if expected := []string{"1", "2", "3", "4"}; !reflect.DeepEqual(expected, deleted) {
+		t.Errorf("expected deleted %v, got %v", expected, deleted)
+	}
+}
+
+func TestIdentityEntityCleanupCustomizeDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "no-aliases-inactive",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases:    true,
+				consts.FieldInactiveDays: 90,
+			},
+		},
+		{
+			name: "no-aliases-name-pattern",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases:   true,
+				consts.FieldNamePattern: "^entity_",
+			},
+		},
+		{
+			name: "inactive",
+			raw: map[string]interface{}{
+				consts.FieldInactiveDays: 90,
+			},
+		},
+		{
+			name: "no-aliases",
+			raw: map[string]interface{}{
+				consts.FieldNoAliases: true,
+			},
+			wantErr: "no_aliases requires inactive_days or name_pattern to be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := identityEntityCleanupResource()
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+const identityEntityMergePath = "identity/entity/merge"
+
+func identityEntityMergeResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: identityEntityMergeCreate,
+		ReadContext:   provider.ReadContextWrapper(identityEntityMergeRead),
+		DeleteContext: identityEntityMergeDelete,
+		CustomizeDiff: identityEntityMergeCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldToEntityID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the entity the source entities are merged into.",
+			},
+			consts.FieldFromEntityIDs: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "IDs of the entities to merge into the target entity. They are deleted by the merge.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldForce: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Description: "Merge the entities even if they have conflicting MFA secrets. " +
+					"The MFA secrets of the target entity are kept.",
+			},
+			consts.FieldConflictingAliasIDsToKeep: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Description: "IDs of the aliases to keep when the entities have aliases on the same mount. " +
+					"Requires Vault 1.12+.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldMergedEntityIDs: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "IDs of all the entities merged into the target entity.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldAliasIDs: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "IDs of the aliases of the target entity after the merge.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// identityEntityMergeCustomizeDiff refuses to plan a merge of entities that are
+// still managed by a vault_identity_entity. The source entity IDs must be known
+// at plan time, which rules out the entities created in the same configuration,
+// and the entities written by a vault_identity_entity carry the
+// identityEntityManagedMetadataKey.
+func identityEntityMergeCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" {
+		return nil
+	}
+
+	if !diff.NewValueKnown(consts.FieldFromEntityIDs) {
+		return fmt.Errorf("%s must be known at plan time, entities created in the same "+
+			"configuration cannot be merged", consts.FieldFromEntityIDs)
+	}
+
+	client, err := provider.GetClient(diff, meta)
+	if err != nil {
+		return err
+	}
+
+	return validateIdentityEntityMerge(ctx, client,
+		diff.Get(consts.FieldToEntityID).(string),
+		diff.Get(consts.FieldFromEntityIDs).(*schema.Set).List(),
+	)
+}
+
+// validateIdentityEntityMerge checks that the source entities exist, are not
+// the target entity, and are not managed by a vault_identity_entity.
+func validateIdentityEntityMerge(ctx context.Context, client *api.Client, to string, from []interface{}) error {
+	var errs []error
+	for _, v := range from {
+		id := v.(string)
+		if id == "" {
+			// not known yet
+			continue
+		}
+
+		if id == to {
+			errs = append(errs, fmt.Errorf("entity %q cannot be merged into itself", id))
+			continue
+		}
+
+		resp, err := client.Logical().ReadWithContext(ctx, entity.JoinEntityID(id))
+		if err != nil {
+			return fmt.Errorf("error reading IdentityEntity %q: %w", id, err)
+		}
+		if resp == nil {
+			errs = append(errs, fmt.Errorf("entity %q not found", id))
+			continue
+		}
+		if isIdentityEntityManaged(resp.Data["metadata"]) {
+			errs = append(errs, fmt.Errorf("entity %q is managed by a vault_identity_entity, "+
+				"remove it from the configuration before merging it", id))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func identityEntityMergeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	to := d.Get(consts.FieldToEntityID).(string)
+
+	data := map[string]interface{}{
+		consts.FieldToEntityID:    to,
+		consts.FieldFromEntityIDs: d.Get(consts.FieldFromEntityIDs).(*schema.Set).List(),
+		consts.FieldForce:         d.Get(consts.FieldForce),
+	}
+
+	if v, ok := d.GetOk(consts.FieldConflictingAliasIDsToKeep); ok {
+		if !provider.IsAPISupported(meta, provider.VaultVersion112) {
+			return diag.Errorf("%s is not supported by this version of Vault, requires Vault 1.12+",
+				consts.FieldConflictingAliasIDsToKeep)
+		}
+		data[consts.FieldConflictingAliasIDsToKeep] = v.(*schema.Set).List()
+	}
+
+	path := entity.JoinEntityID(to)
+	provider.VaultMutexKV.Lock(path)
+	defer provider.VaultMutexKV.Unlock(path)
+
+	log.Printf("[DEBUG] Merging entities %v into IdentityEntity %q", data[consts.FieldFromEntityIDs], to)
+	if _, err := client.Logical().WriteWithContext(ctx, identityEntityMergePath, data); err != nil {
+		return diag.Errorf("error merging entities into IdentityEntity %q: %s", to, err)
+	}
+	log.Printf("[DEBUG] Merged entities into IdentityEntity %q", to)
+
+	d.SetId(to)
+
+	return identityEntityMergeRead(ctx, d, meta)
+}
+
+func identityEntityMergeRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	id := d.Id()
+	resp, err := readIdentityEntity(client, id, d.IsNewResource())
+	if err != nil {
+		if errors.Is(err, entity.ErrEntityNotFound) {
+			log.Printf("[WARN] IdentityEntity %q not found, removing merge from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error reading IdentityEntity %q: %s", id, err)
+	}
+
+	var ent entity.Entity
+	if err := mapstructure.Decode(resp.Data, &ent); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var aliasIDs []string
+	for _, a := range ent.Aliases {
+		aliasIDs = append(aliasIDs, a.ID)
+	}
+
+	if err := d.Set(consts.FieldToEntityID, id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldMergedEntityIDs, ent.MergedEntityIds); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldAliasIDs, aliasIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func identityEntityMergeDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[WARN] Entity merges cannot be undone, removing the merge into IdentityEntity %q from state only", d.Id())
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccIdentityEntityMerge(t *testing.T) {
+	testutil.SkipTestAcc(t)
+	testutil.TestAccPreCheck(t)
+
+	name := acctest.RandomWithPrefix("test-entity")
+	resourceName := "vault_identity_entity_merge.test"
+
+	// the source entity must not be managed in the same configuration
+	client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+	var ids []string
+	for _, suffix := range []string{"target", "source"} {
+		resp, err := client.Logical().Write(entity.RootEntityPath, map[string]interface{}{
+			consts.FieldName: fmt.Sprintf("%s-%s", name, suffix),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := resp.Data[consts.FieldID].(string)
+		ids = append(ids, id)
+
+		t.Cleanup(func() {
+			if _, err := client.Logical().Delete(entity.JoinEntityID(id)); err != nil {
+				t.Logf("failed to delete entity %q: %s", id, err)
+			}
+		})
+	}
+	target, source := ids[0], ids[1]
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIdentityEntityMergeConfigManaged(name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`from_entity_ids must be known at plan time`),
+			},
+			{
+				Config: testAccIdentityEntityMergeConfigEntities(name),
+			},
+			{
+				// the entities exist, but are still managed by vault_identity_entity
+				Config:      testAccIdentityEntityMergeConfigManaged(name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is managed by a vault_identity_entity`),
+			},
+			{
+				Config: testAccIdentityEntityMergeConfig(target, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", target),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldToEntityID, target),
+					resource.TestCheckResourceAttr(resourceName, "from_entity_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "merged_entity_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "merged_entity_ids.*", source),
+					resource.TestCheckResourceAttr(resourceName, "alias_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIdentityEntityMergeConfigEntities(name string) string {
+	return fmt.Sprintf(`
+resource "vault_identity_entity" "target" {
+  name = "%[1]s-managed-target"
+}
+
+resource "vault_identity_entity" "source" {
+  name = "%[1]s-managed-source"
+}
+`, name)
+}
+
+func testAccIdentityEntityMergeConfigManaged(name string) string {
+	return testAccIdentityEntityMergeConfigEntities(name) + `
+resource "vault_identity_entity_merge" "test" {
+  to_entity_id    = vault_identity_entity.target.id
+  from_entity_ids = [vault_identity_entity.source.id]
+}
+`
+}
+
+func testAccIdentityEntityMergeConfig(target, source string) string {
+	return fmt.Sprintf(`
+resource "vault_identity_entity_merge" "test" {
+  to_entity_id    = "%s"
+  from_entity_ids = ["%s"]
+  force           = true
+}
+`, target, source)
+}
+
+func TestValidateIdentityEntityMerge(t *testing.T) {
+	entities := map[string]map[string]interface{}{
+		"source-1": {"metadata": map[string]interface{}{"team": "dev"}},
+		"source-2": {"metadata": nil},
+		"managed":  {"metadata": map[string]interface{}{identityEntityManagedMetadataKey: "true"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, ok := entities[strings.TrimPrefix(req.URL.Path, "/v1/identity/entity/id/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"data": data}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	config := api.DefaultConfig()
+	config.Address = server.URL
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		to      string
+		from    []interface{}
+		wantErr string
+	}{
+		{
+			name: "valid",
+			to:   "target",
+			from: []interface{}{"source-1", "source-2"},
+		},
+		{
+			name: "unknown",
+			to:   "target",
+			from: []interface{}{""},
+		},
+		{
+			name:    "self",
+			to:      "target",
+			from:    []interface{}{"source-1", "target"},
+			wantErr: `entity "target" cannot be merged into itself`,
+		},
+		{
+			name:    "managed",
+			to:      "target",
+			from:    []interface{}{"source-1", "managed"},
+			wantErr: `entity "managed" is managed by a vault_identity_entity`,
+		},
+		{
+			name:    "not-found",
+			to:      "target",
+			from:    []interface{}{"missing"},
+			wantErr: `entity "missing" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateIdentityEntityMerge(context.Background(), client, tt.to, tt.from)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateIdentityEntityMerge() unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateIdentityEntityMerge() expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/group"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+func identityGroupMemberAliasesResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: identityGroupMemberAliasesWrite,
+		UpdateContext: identityGroupMemberAliasesWrite,
+		ReadContext:   provider.ReadContextWrapper(identityGroupMemberAliasesRead),
+		DeleteContext: identityGroupMemberAliasesDelete,
+		CustomizeDiff: identityGroupMemberAliasesCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldGroupID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the internal group.",
+			},
+			consts.FieldMember: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Members of the group, identified by one of their entity aliases.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldAliasName: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the entity alias.",
+						},
+						consts.FieldMountAccessor: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Accessor of the auth mount the alias belongs to.",
+						},
+					},
+				},
+			},
+			consts.FieldCreateMissingEntities: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Create an entity and alias for members whose alias does not exist yet. " +
+					"If false, a missing alias is an error. The created entities are deleted when the " +
+					"member is removed or the resource is destroyed.",
+			},
+			consts.FieldCreatedEntityIDs: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "IDs of the entities created for the members whose alias did not exist.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			consts.FieldMemberEntityIDs: {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "IDs of the entities that are members of the group.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// identityGroupMemberAliasesCustomizeDiff resolves the member aliases at plan
+// time, so that entities added to or removed from the group outside of
+// Terraform show up as a diff on member_entity_ids.
+func identityGroupMemberAliasesCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.NewValueKnown(consts.FieldMember) {
+		return diff.SetNewComputed(consts.FieldMemberEntityIDs)
+	}
+
+	// removed members may have their created entities deleted
+	if diff.HasChange(consts.FieldMember) {
+		if err := diff.SetNewComputed(consts.FieldCreatedEntityIDs); err != nil {
+			return err
+		}
+	}
+
+	client, err := provider.GetClient(diff, meta)
+	if err != nil {
+		return err
+	}
+
+	ids, missing, err := lookupIdentityGroupMemberAliases(client, diff.Get(consts.FieldMember).(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	if len(missing) > 0 {
+		if !diff.Get(consts.FieldCreateMissingEntities).(bool) {
+			return identityGroupMemberAliasesMissingError(missing)
+		}
+		if err := diff.SetNewComputed(consts.FieldCreatedEntityIDs); err != nil {
+			return err
+		}
+		return diff.SetNewComputed(consts.FieldMemberEntityIDs)
+	}
+
+	current := diff.Get(consts.FieldMemberEntityIDs).(*schema.Set)
+	if current.Equal(schema.NewSet(schema.HashString, flattenStringSlice(ids))) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Identity Group %q members differ from their aliases, current=%v, desired=%v",
+		diff.Id(), current.List(), ids)
+	return diff.SetNew(consts.FieldMemberEntityIDs, ids)
+}
+
+func identityGroupMemberAliasesWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	gid := d.Get(consts.FieldGroupID).(string)
+
+	path := group.IdentityGroupIDPath(gid)
+	provider.VaultMutexKV.Lock(path)
+	defer provider.VaultMutexKV.Unlock(path)
+
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	ids, missing, err := lookupIdentityGroupMemberAliases(client, d.Get(consts.FieldMember).(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the entities created earlier are kept as long as they are members
+	created, removed := identityGroupMemberAliasesCreatedEntities(
+		util.ToStringArray(d.Get(consts.FieldCreatedEntityIDs).(*schema.Set).List()), ids)
+
+	if len(missing) > 0 {
+		if !d.Get(consts.FieldCreateMissingEntities).(bool) {
+			return diag.FromErr(identityGroupMemberAliasesMissingError(missing))
+		}
+
+		for _, params := range missing {
+			id, err := createIdentityEntityWithAlias(client, params)
+			if err != nil {
+				// record the entities created so far before failing
+				if e := d.Set(consts.FieldCreatedEntityIDs, created); e != nil {
+					log.Printf("[WARN] Failed to set %s: %s", consts.FieldCreatedEntityIDs, e)
+				}
+				return diag.FromErr(err)
+			}
+			ids = append(ids, id)
+			created = append(created, id)
+		}
+	}
+	sort.Strings(ids)
+	sort.Strings(created)
+
+	log.Printf("[DEBUG] Setting members of Identity Group %q to %v", gid, ids)
+	if _, err := client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+		consts.FieldMemberEntityIDs: ids,
+	}); err != nil {
+		return diag.Errorf("error updating field %q on Identity Group %q: %s", consts.FieldMemberEntityIDs, gid, err)
+	}
+	log.Printf("[DEBUG] Set members of Identity Group %q", gid)
+
+	d.SetId(gid)
+
+	if err := d.Set(consts.FieldCreatedEntityIDs, append(created, removed...)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := deleteIdentityEntities(ctx, client, removed); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldCreatedEntityIDs, created); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return identityGroupMemberAliasesRead(ctx, d, meta)
+}
+
+func identityGroupMemberAliasesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	id := d.Id()
+	log.Printf("[DEBUG] Reading Identity Group %q members", id)
+	resp, err := group.ReadIdentityGroup(client, id, d.IsNewResource())
+	if err != nil {
+		if group.IsIdentityNotFoundError(err) {
+			log.Printf("[WARN] Identity Group %q not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set(consts.FieldGroupID, id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldMemberEntityIDs, resp.Data[consts.FieldMemberEntityIDs]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func identityGroupMemberAliasesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	gid := d.Get(consts.FieldGroupID).(string)
+
+	path := group.IdentityGroupIDPath(gid)
+	provider.VaultMutexKV.Lock(path)
+	defer provider.VaultMutexKV.Unlock(path)
+
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	if _, err := group.ReadIdentityGroup(client, gid, false); err != nil {
+		if group.IsIdentityNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Removing all members from Identity Group %q", gid)
+	if _, err := client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+		consts.FieldMemberEntityIDs: []string{},
+	}); err != nil {
+		return diag.Errorf("error removing members from Identity Group %q: %s", gid, err)
+	}
+	log.Printf("[DEBUG] Removed all members from Identity Group %q", gid)
+
+	created := util.ToStringArray(d.Get(consts.FieldCreatedEntityIDs).(*schema.Set).List())
+	if err := deleteIdentityEntities(ctx, client, created); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// identityGroupMemberAliasesCreatedEntities splits the entities created by
+// the resource into the ones that are still members of the group and the ones
+// that are not.
+func identityGroupMemberAliasesCreatedEntities(created, members []string) ([]string, []string) {
+	isMember := map[string]bool{}
+	for _, id := range members {
+		isMember[id] = true
+	}
+
+	var kept, removed []string
+	for _, id := range created {
+		if isMember[id] {
+			kept = append(kept, id)
+		} else {
+			removed = append(removed, id)
+		}
+	}
+
+	return kept, removed
+}
+
+// deleteIdentityEntities deletes the entities, together with their aliases.
+func deleteIdentityEntities(ctx context.Context, client *api.Client, ids []string) error {
+	for _, id := range ids {
+		log.Printf("[DEBUG] Deleting IdentityEntity %q", id)
+		if _, err := client.Logical().DeleteWithContext(ctx, entity.JoinEntityID(id)); err != nil {
+			return fmt.Errorf("error deleting IdentityEntity %q: %w", id, err)
+		}
+		log.Printf("[DEBUG] Deleted IdentityEntity %q", id)
+	}
+
+	return nil
+}
+
+// lookupIdentityGroupMemberAliases returns the sorted canonical entity IDs of
+// the member aliases that exist, and the lookup parameters of those that do
+// not.
+func lookupIdentityGroupMemberAliases(client *api.Client, members []interface{}) ([]string, []*entity.FindAliasParams, error) {
+	seen := map[string]bool{}
+	var ids []string
+	var missing []*entity.FindAliasParams
+	for _, m := range members {
+		v := m.(map[string]interface{})
+		params := &entity.FindAliasParams{
+			Name:          v[consts.FieldAliasName].(string),
+			MountAccessor: v[consts.FieldMountAccessor].(string),
+		}
+
+		alias, err := entity.LookupEntityAlias(client, params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error looking up entity alias %q on mount %q: %w",
+				params.Name, params.MountAccessor, err)
+		}
+
+		if alias == nil {
+			missing = append(missing, params)
+			continue
+		}
+
+		// several aliases may belong to the same entity
+		if !seen[alias.CanonicalId] {
+			seen[alias.CanonicalId] = true
+			ids = append(ids, alias.CanonicalId)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, missing, nil
+}
+
+// createIdentityEntityWithAlias creates a new entity with a single alias and
+// returns its ID. The entity is removed again if the alias cannot be created.
+func createIdentityEntityWithAlias(client *api.Client, params *entity.FindAliasParams) (string, error) {
+	log.Printf("[DEBUG] Creating IdentityEntity for alias %q on mount %q", params.Name, params.MountAccessor)
+	resp, err := client.Logical().Write(entity.RootEntityPath, map[string]interface{}{})
+	if err != nil {
+		return "", fmt.Errorf("error creating entity for alias %q: %w", params.Name, err)
+	}
+	if resp == nil {
+		return "", fmt.Errorf("unexpected empty response creating entity for alias %q", params.Name)
+	}
+	id := resp.Data[consts.FieldID].(string)
+
+	if _, err := client.Logical().Write(entity.RootAliasPath, map[string]interface{}{
+		consts.FieldName:          params.Name,
+		consts.FieldMountAccessor: params.MountAccessor,
+		"canonical_id":            id,
+	}); err != nil {
+		if _, e := client.Logical().Delete(entity.JoinEntityID(id)); e != nil {
+			log.Printf("[WARN] Failed to remove IdentityEntity %q: %s", id, e)
+		}
+		return "", fmt.Errorf("error creating entity alias %q on mount %q: %w", params.Name, params.MountAccessor, err)
+	}
+	log.Printf("[DEBUG] Created IdentityEntity %q for alias %q on mount %q", id, params.Name, params.MountAccessor)
+
+	return id, nil
+}
+
+func identityGroupMemberAliasesMissingError(missing []*entity.FindAliasParams) error {
+	var aliases []string
+	for _, params := range missing {
+		aliases = append(aliases, fmt.Sprintf("%s (%s)", params.Name, params.MountAccessor))
+	}
+
+	return fmt.Errorf("entity aliases %v do not exist and %s is false", aliases, consts.FieldCreateMissingEntities)
+}
@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/entity"
+	"github.com/hashicorp/terraform-provider-vault/internal/identity/group"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccIdentityGroupMemberAliases(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-group")
+	resourceName := "vault_identity_group_member_aliases.test"
+
+	var bobID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				// alice already has an entity, bob's is created by the resource
+				Config: testAccIdentityGroupMemberAliasesConfig(name, "alice", "bob"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, consts.FieldGroupID, "vault_identity_group.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "member.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "member_entity_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "member_entity_ids.*", "vault_identity_entity.alice", "id"),
+					resource.TestCheckResourceAttr(resourceName, "created_entity_ids.#", "1"),
+					func(s *terraform.State) error {
+						rs, err := testutil.GetResourceFromRootModule(s, resourceName)
+						if err != nil {
+							return err
+						}
+						for k, v := range rs.Primary.Attributes {
+							if strings.HasPrefix(k, "created_entity_ids.") && k != "created_entity_ids.#" {
+								bobID = v
+							}
+						}
+						return testAccIdentityEntityCleanupCheckExists(bobID, true)(s)
+					},
+					testAccIdentityGroupMemberAliasesCheckGroup(resourceName, 2),
+				),
+			},
+			{
+				// the entity created for bob is deleted with the member
+				Config: testAccIdentityGroupMemberAliasesConfig(name, "alice"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "member.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "member_entity_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "created_entity_ids.#", "0"),
+					func(s *terraform.State) error {
+						return testAccIdentityEntityCleanupCheckExists(bobID, false)(s)
+					},
+					testAccIdentityGroupMemberAliasesCheckGroup(resourceName, 1),
+				),
+			},
+			{
+				// membership added outside of Terraform is removed again
+				PreConfig: func() {
+					client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+					resp, err := client.Logical().Write(entity.RootEntityPath, map[string]interface{}{
+						consts.FieldName: name + "-intruder",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					id := resp.Data[consts.FieldID].(string)
+					t.Cleanup(func() {
+						client.Logical().Delete(entity.JoinEntityID(id))
+					})
+
+					resp, err = client.Logical().Read("identity/entity/name/" + name + "-alice")
+					if err != nil {
+						t.Fatal(err)
+					}
+					alice := resp.Data[consts.FieldID].(string)
+
+					resp, err = client.Logical().Read("identity/group/name/" + name)
+					if err != nil {
+						t.Fatal(err)
+					}
+					gid := resp.Data[consts.FieldID].(string)
+
+					if _, err := client.Logical().Write(group.IdentityGroupIDPath(gid), map[string]interface{}{
+						consts.FieldMemberEntityIDs: []string{alice, id},
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccIdentityGroupMemberAliasesConfig(name, "alice"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "member_entity_ids.#", "1"),
+					testAccIdentityGroupMemberAliasesCheckGroup(resourceName, 1),
+				),
+			},
+			{
+				Config:      testAccIdentityGroupMemberAliasesConfigNoCreate(name),
+				ExpectError: regexp.MustCompile(`do not exist and create_missing_entities is false`),
+			},
+		},
+	})
+}
+
+func testAccIdentityGroupMemberAliasesCheckGroup(resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testutil.GetResourceFromRootModule(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := group.ReadIdentityGroup(client, rs.Primary.ID, false)
+		if err != nil {
+			return err
+		}
+
+		members, _ := resp.Data[consts.FieldMemberEntityIDs].([]interface{})
+		if len(members) != expected {
+			return fmt.Errorf("expected %d group members, got %v", expected, members)
+		}
+
+		return nil
+	}
+}
+
+func testAccIdentityGroupMemberAliasesBaseConfig(name string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%[1]s"
+}
+
+resource "vault_identity_group" "test" {
+  name                       = "%[1]s"
+  type                       = "internal"
+  external_member_entity_ids = true
+}
+
+resource "vault_identity_entity" "alice" {
+  name = "%[1]s-alice"
+}
+
+resource "vault_identity_entity_alias" "alice" {
+  name           = "alice"
+  mount_accessor = vault_auth_backend.test.accessor
+  canonical_id   = vault_identity_entity.alice.id
+}
+`, name)
+}
+
+func testAccIdentityGroupMemberAliasesConfig(name string, members ...string) string {
+	return testAccIdentityGroupMemberAliasesBaseConfig(name) + fmt.Sprintf(`
+locals {
+  members = ["%s"]
+}
+
+resource "vault_identity_group_member_aliases" "test" {
+  group_id = vault_identity_group.test.id
+
+  dynamic "member" {
+    for_each = toset(local.members)
+    content {
+      alias_name     = member.value
+      mount_accessor = vault_auth_backend.test.accessor
+    }
+  }
+
+  depends_on = [vault_identity_entity_alias.alice]
+}
+`, strings.Join(members, `", "`))
+}
+
+func testAccIdentityGroupMemberAliasesConfigNoCreate(name string) string {
+	return testAccIdentityGroupMemberAliasesBaseConfig(name) + `
+resource "vault_identity_group_member_aliases" "test" {
+  group_id                = vault_identity_group.test.id
+  create_missing_entities = false
+
+  member {
+    alias_name     = "carol"
+    mount_accessor = vault_auth_backend.test.accessor
+  }
+
+  depends_on = [vault_identity_entity_alias.alice]
+}
+`
+}
+
+func TestLookupIdentityGroupMemberAliases(t *testing.T) {
+	// canonical IDs keyed by alias name and mount accessor
+	aliases := map[string]string{
+		"alice/auth_userpass_1": "entity-alice",
+		"alice/auth_ldap_1":     "entity-alice",
+		"bob/auth_userpass_1":   "entity-bob",
+	}
+
+	config, ln := testutil.TestHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/v1/"+entity.LookupPath {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+
+		var params map[string]string
+		if err := json.NewDecoder(req.Body).Decode(&params); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		name, accessor := params["alias_name"], params["alias_mount_accessor"]
+		id, ok := aliases[name+"/"+accessor]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		b, err := json.Marshal(&api.Secret{
+			Data: map[string]interface{}{
+				consts.FieldID: id,
+				"aliases": []interface{}{
+					map[string]interface{}{
+						consts.FieldName:          name,
+						consts.FieldMountAccessor: accessor,
+						"canonical_id":            id,
+					},
+				},
+			},
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(b)
+	}))
+	defer ln.Close()
+
+	client, err := api.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member := func(name, accessor string) interface{} {
+		return map[string]interface{}{
+			consts.FieldAliasName:     name,
+			consts.FieldMountAccessor: accessor,
+		}
+	}
+
+	ids, missing, err := lookupIdentityGroupMemberAliases(client, []interface{}{
+		member("bob", "auth_userpass_1"),
+		member("alice", "auth_userpass_1"),
+		member("alice", "auth_ldap_1"),
+		member("carol", "auth_userpass_1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"entity-alice", "entity-bob"}; !reflect.DeepEqual(expected, ids) {
+		t.Errorf("expected IDs %v, got %v", expected, ids)
+	}
+
+	expectedMissing := []*entity.FindAliasParams{
+		{
+			Name:          "carol",
+			MountAccessor: "auth_userpass_1",
+		},
+	}
+	if !reflect.DeepEqual(expectedMissing, missing) {
+		t.Errorf("expected missing aliases %v, got %v", expectedMissing, missing)
+	}
+
+	err = identityGroupMemberAliasesMissingError(missing)
+	if expected := "entity aliases [carol (auth_userpass_1)] do not exist and create_missing_entities is false"; err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
+
+func TestIdentityGroupMemberAliasesCreatedEntities(t *testing.T) {
+	kept, removed := identityGroupMemberAliasesCreatedEntities(
+		[]string{"created-1", "created-2", "created-3"},
+		[]string{"alice", "created-1", "created-3"},
+	)
+
+	if expected := []string{"created-1", "created-3"}; !reflect.DeepEqual(expected, kept) {
+		t.Errorf("expected kept %v, got %v", expected, kept)
+	}
+	if expected := []string{"created-2"}; !reflect.DeepEqual(expected, removed) {
+		t.Errorf("expected removed %v, got %v", expected, removed)
+	}
+}
@@ -316,13 +316,7 @@ func jwtAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set(configOption, config.Data[configOption])
 	}
 
-	log.Printf("[DEBUG] Reading jwt auth tune from %q", path+"/tune")
-	rawTune, err := authMountTuneGet(client, "auth/"+path)
-	if err != nil {
-		return fmt.Errorf("error reading tune information from Vault: %s", err)
-	}
-	if err := d.Set("tune", []map[string]interface{}{rawTune}); err != nil {
-		log.Printf("[ERROR] Error when setting tune config from path %q to state: %s", path+"/tune", err)
+	if err := authMountTuneRead(d, client, "auth/"+path); err != nil {
 		return err
 	}
 
@@ -390,19 +384,8 @@ func jwtAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error updating configuration to Vault for path %s: %s", path, err)
 	}
 
-	if d.HasChange("tune") {
-		log.Printf("[INFO] JWT/OIDC Auth '%q' tune configuration changed", d.Id())
-		if raw, ok := d.GetOk("tune"); ok {
-			backendType := d.Get("type")
-			log.Printf("[DEBUG] Writing %s auth tune to '%q'", backendType, path)
-
-			err := authMountTune(client, "auth/"+path, raw)
-			if err != nil {
-				return nil
-			}
-
-			log.Printf("[INFO] Written %s auth tune to %q", backendType, path)
-		}
+	if err := authMountTuneUpdate(d, client, "auth/"+path); err != nil {
+		return err
 	}
 
 	return jwtAuthBackendRead(d, meta)
@@ -0,0 +1,346 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+var kerberosAuthBackendConfigRegex = regexp.MustCompile("^auth/(.+)/config$")
+
+var (
+	kerberosAuthBackendConfigFields = []string{
+		consts.FieldServiceAccount,
+		consts.FieldRemoveInstanceName,
+		consts.FieldAddGroupAliases,
+	}
+	kerberosAuthBackendLDAPStringFields = []string{
+		consts.FieldURL,
+		consts.FieldBindDN,
+		consts.FieldUserDN,
+		consts.FieldUserAttr,
+		consts.FieldUPNDomain,
+		consts.FieldGroupDN,
+		consts.FieldGroupFilter,
+		consts.FieldGroupAttr,
+		consts.FieldCertificate,
+	}
+	kerberosAuthBackendLDAPBoolFields = []string{
+		consts.FieldStartTLS,
+		consts.FieldInsecureTLS,
+		consts.FieldDiscoverDN,
+		consts.FieldDenyNullBind,
+		consts.FieldCaseSensitiveNames,
+		consts.FieldUsernameAsAlias,
+	}
+)
+
+func kerberosAuthBackendConfigResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: kerberosAuthBackendConfigWrite,
+		UpdateContext: kerberosAuthBackendConfigWrite,
+		ReadContext:   provider.ReadContextWrapper(kerberosAuthBackendConfigRead),
+		DeleteContext: kerberosAuthBackendConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeKerberos,
+				Description:  "Path where the Kerberos auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldKeytab: {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+				Description: "Base64 encoded keytab of the service account. " +
+					"The keytab cannot be read back from Vault.",
+				ValidateFunc: validation.StringIsBase64,
+			},
+			consts.FieldServiceAccount: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service account associated with the keytab.",
+			},
+			consts.FieldRemoveInstanceName: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Remove the instance name, the part after the slash, " +
+					"from the usernames of the service principals.",
+			},
+			consts.FieldAddGroupAliases: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Add group aliases for the LDAP groups of the user on login.",
+			},
+			consts.FieldLDAP: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Description: "Configuration of the LDAP server used to map the users to groups. " +
+					"Removing the block leaves the LDAP configuration in Vault untouched.",
+				Elem: &schema.Resource{
+					Schema: kerberosAuthBackendLDAPSchema(),
+				},
+			},
+		},
+	}
+}
+
+func kerberosAuthBackendLDAPSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		consts.FieldURL: {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The URL of the LDAP server.",
+		},
+		consts.FieldBindDN: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Distinguished name of the object to bind when performing user and group search.",
+		},
+		consts.FieldBindPass: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Password to use along with binddn. It cannot be read back from Vault.",
+		},
+		consts.FieldUserDN: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Base DN under which to perform user search.",
+		},
+		consts.FieldUserAttr: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Attribute on the user object matching the username passed when authenticating.",
+		},
+		consts.FieldUPNDomain: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The userPrincipalDomain used to construct the UPN string for the authenticating user.",
+		},
+		consts.FieldGroupDN: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Base DN under which to perform group search.",
+		},
+		consts.FieldGroupFilter: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Go template used to construct a LDAP group search filter.",
+		},
+		consts.FieldGroupAttr: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "LDAP attribute to follow on objects returned by groupfilter to enumerate user group membership.",
+		},
+		consts.FieldCertificate: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "CA certificate to use when verifying the LDAP server certificate, PEM encoded.",
+		},
+		consts.FieldStartTLS: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Issue a StartTLS command after establishing an unencrypted connection.",
+		},
+		consts.FieldInsecureTLS: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Skip the verification of the LDAP server SSL certificate.",
+		},
+		consts.FieldDiscoverDN: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Use anonymous bind to discover the bind DN of a user.",
+		},
+		consts.FieldDenyNullBind: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Prevent users from bypassing authentication when providing an empty password.",
+		},
+		consts.FieldCaseSensitiveNames: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Treat the user and group names as case sensitive.",
+		},
+		consts.FieldUsernameAsAlias: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Use the username passed by the user as the alias name.",
+		},
+	}
+}
+
+func kerberosAuthBackendConfigPath(mount string) string {
+	return fmt.Sprintf("auth/%s/config", mount)
+}
+
+func kerberosAuthBackendLDAPConfigPath(mount string) string {
+	return fmt.Sprintf("auth/%s/config/ldap", mount)
+}
+
+// kerberosAuthBackendLDAPRequest returns the request data of the LDAP
+// configuration block.
+func kerberosAuthBackendLDAPRequest(ldap map[string]interface{}) map[string]interface{} {
+	data := map[string]interface{}{}
+	for _, k := range kerberosAuthBackendLDAPStringFields {
+		if v := ldap[k].(string); v != "" {
+			data[k] = v
+		}
+	}
+	if v := ldap[consts.FieldBindPass].(string); v != "" {
+		data[consts.FieldBindPass] = v
+	}
+	for _, k := range kerberosAuthBackendLDAPBoolFields {
+		data[k] = ldap[k]
+	}
+
+	return data
+}
+
+// kerberosAuthBackendLDAPState returns the LDAP configuration block from the
+// response data. The bind password is never returned by Vault, so it is kept
+// from the prior state.
+func kerberosAuthBackendLDAPState(data map[string]interface{}, bindPass string) map[string]interface{} {
+	ldap := map[string]interface{}{
+		consts.FieldBindPass: bindPass,
+	}
+	for _, k := range append(kerberosAuthBackendLDAPStringFields, kerberosAuthBackendLDAPBoolFields...) {
+		if v, ok := data[k]; ok {
+			ldap[k] = v
+		}
+	}
+
+	return ldap
+}
+
+func kerberosAuthBackendConfigWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	mount := d.Get(consts.FieldMount).(string)
+	path := kerberosAuthBackendConfigPath(mount)
+
+	if d.IsNewResource() || d.HasChanges(consts.FieldKeytab, consts.FieldServiceAccount,
+		consts.FieldRemoveInstanceName, consts.FieldAddGroupAliases) {
+		data := map[string]interface{}{
+			consts.FieldKeytab: d.Get(consts.FieldKeytab),
+		}
+		for _, k := range kerberosAuthBackendConfigFields {
+			data[k] = d.Get(k)
+		}
+
+		log.Printf("[DEBUG] Writing Kerberos auth config to %q", path)
+		if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+			return diag.Errorf("error writing Kerberos auth config %q: %s", path, err)
+		}
+		log.Printf("[DEBUG] Wrote Kerberos auth config to %q", path)
+	}
+
+	if v, ok := d.GetOk(consts.FieldLDAP); ok && d.HasChange(consts.FieldLDAP) {
+		ldapPath := kerberosAuthBackendLDAPConfigPath(mount)
+		data := kerberosAuthBackendLDAPRequest(v.([]interface{})[0].(map[string]interface{}))
+
+		log.Printf("[DEBUG] Writing Kerberos auth LDAP config to %q", ldapPath)
+		if _, err := client.Logical().WriteWithContext(ctx, ldapPath, data); err != nil {
+			return diag.Errorf("error writing Kerberos auth LDAP config %q: %s", ldapPath, err)
+		}
+		log.Printf("[DEBUG] Wrote Kerberos auth LDAP config to %q", ldapPath)
+	}
+
+	d.SetId(path)
+
+	return kerberosAuthBackendConfigRead(ctx, d, meta)
+}
+
+func kerberosAuthBackendConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := kerberosAuthBackendConfigRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for Kerberos auth config, expected auth/<mount>/config", path)
+	}
+	mount := m[1]
+
+	log.Printf("[DEBUG] Reading Kerberos auth config from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading Kerberos auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read Kerberos auth config from %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] Kerberos auth config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, mount); err != nil {
+		return diag.FromErr(err)
+	}
+	for _, k := range kerberosAuthBackendConfigFields {
+		if v, ok := resp.Data[k]; ok {
+			if err := d.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	ldapPath := kerberosAuthBackendLDAPConfigPath(mount)
+	log.Printf("[DEBUG] Reading Kerberos auth LDAP config from %q", ldapPath)
+	ldapResp, err := client.Logical().ReadWithContext(ctx, ldapPath)
+	if err != nil {
+		return diag.Errorf("error reading Kerberos auth LDAP config %q: %s", ldapPath, err)
+	}
+	log.Printf("[DEBUG] Read Kerberos auth LDAP config from %q", ldapPath)
+
+	var ldap []interface{}
+	if ldapResp != nil {
+		bindPass, _ := d.Get(fmt.Sprintf("%s.0.%s", consts.FieldLDAP, consts.FieldBindPass)).(string)
+		ldap = append(ldap, kerberosAuthBackendLDAPState(ldapResp.Data, bindPass))
+	}
+	if err := d.Set(consts.FieldLDAP, ldap); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func kerberosAuthBackendConfigDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing Kerberos auth config %q from state, it cannot be deleted from Vault", d.Id())
+	return nil
+}
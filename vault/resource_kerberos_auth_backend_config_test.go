@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccKerberosAuthBackendConfig(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-kerberos")
+	resourceName := "vault_kerberos_auth_backend_config.test"
+	kt := testKerberosKeytab(t, "vault_svc", "EXAMPLE.COM")
+
+	ldapPrefix := consts.FieldLDAP + ".0."
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKerberosAuthBackendConfigConfig(mount, kt, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/config"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldServiceAccount, "vault_svc"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldRemoveInstanceName, "false"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldAddGroupAliases, "false"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldLDAP+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, ldapPrefix+consts.FieldURL, "ldaps://ldap.example.com"),
+					resource.TestCheckResourceAttr(resourceName, ldapPrefix+consts.FieldBindDN, "cn=vault,dc=example,dc=com"),
+					resource.TestCheckResourceAttr(resourceName, ldapPrefix+consts.FieldGroupDN, "ou=groups,dc=example,dc=com"),
+					resource.TestCheckResourceAttr(resourceName, ldapPrefix+consts.FieldDenyNullBind, "true"),
+				),
+			},
+			{
+				Config: testAccKerberosAuthBackendConfigConfig(mount, kt, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldRemoveInstanceName, "true"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldAddGroupAliases, "true"),
+					resource.TestCheckResourceAttr(resourceName, ldapPrefix+consts.FieldGroupAttr, "memberOf"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil,
+				consts.FieldKeytab, ldapPrefix+consts.FieldBindPass),
+		},
+	})
+}
+
+// testKerberosKeytab returns a base64 encoded keytab for the principal.
+func testKerberosKeytab(t *testing.T, principal, realm string) string {
+	t.Helper()
+
+	kt := keytab.New()
+	if err := kt.AddEntry(principal, realm, "s3cr3t", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := kt.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func testAccKerberosAuthBackendConfigConfig(mount, kt string, groupAliases bool) string {
+	groupAttr := ""
+	if groupAliases {
+		groupAttr = `groupattr = "memberOf"`
+	}
+
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "kerberos"
+  path = "%s"
+}
+
+resource "vault_kerberos_auth_backend_config" "test" {
+  mount                = vault_auth_backend.test.path
+  keytab               = "%s"
+  service_account      = "vault_svc"
+  remove_instance_name = %[3]t
+  add_group_aliases    = %[3]t
+
+  ldap {
+    url      = "ldaps://ldap.example.com"
+    binddn   = "cn=vault,dc=example,dc=com"
+    bindpass = "s3cr3t"
+    userdn   = "ou=users,dc=example,dc=com"
+    groupdn  = "ou=groups,dc=example,dc=com"
+    %[4]s
+  }
+}
+`, mount, kt, groupAliases, groupAttr)
+}
+
+func TestKerberosAuthBackendLDAPRequest(t *testing.T) {
+	ldap := map[string]interface{}{
+		consts.FieldURL:                "ldaps://ldap.example.com",
+		consts.FieldBindDN:             "cn=vault,dc=example,dc=com",
+		consts.FieldBindPass:           "s3cr3t",
+		consts.FieldUserDN:             "",
+		consts.FieldUserAttr:           "",
+		consts.FieldUPNDomain:          "",
+		consts.FieldGroupDN:            "ou=groups,dc=example,dc=com",
+		consts.FieldGroupFilter:        "",
+		consts.FieldGroupAttr:          "",
+		consts.FieldCertificate:        "",
+		consts.FieldStartTLS:           false,
+		consts.FieldInsecureTLS:        false,
+		consts.FieldDiscoverDN:         false,
+		consts.FieldDenyNullBind:       true,
+		consts.FieldCaseSensitiveNames: false,
+		consts.FieldUsernameAsAlias:    true,
+	}
+
+	expected := map[string]interface{}{
+		consts.FieldURL:                "ldaps://ldap.example.com",
+		consts.FieldBindDN:             "cn=vault,dc=example,dc=com",
+		consts.FieldBindPass:           "s3cr3t",
+		consts.FieldGroupDN:            "ou=groups,dc=example,dc=com",
+		consts.FieldStartTLS:           false,
+		consts.FieldInsecureTLS:        false,
+		consts.FieldDiscoverDN:         false,
+		consts.FieldDenyNullBind:       true,
+		consts.FieldCaseSensitiveNames: false,
+		consts.FieldUsernameAsAlias:    true,
+	}
+
+	if actual := kerberosAuthBackendLDAPRequest(ldap); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestKerberosAuthBackendLDAPState(t *testing.T) {
+	data := map[string]interface{}{
+		consts.FieldURL:          "ldaps://ldap.example.com",
+		consts.FieldGroupDN:      "ou=groups,dc=example,dc=com",
+		consts.FieldDenyNullBind: true,
+		"token_ttl":              3600,
+	}
+
+	expected := map[string]interface{}{
+		consts.FieldURL:          "ldaps://ldap.example.com",
+		consts.FieldGroupDN:      "ou=groups,dc=example,dc=com",
+		consts.FieldDenyNullBind: true,
+		consts.FieldBindPass:     "s3cr3t",
+	}
+
+	if actual := kerberosAuthBackendLDAPState(data, "s3cr3t"); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %#v, got %#v", expected, actual)
+	}
+}
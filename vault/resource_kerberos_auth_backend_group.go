@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var kerberosAuthBackendGroupRegex = regexp.MustCompile("^auth/(.+)/groups/([^/]+)$")
+
+func kerberosAuthBackendGroupResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: kerberosAuthBackendGroupWrite,
+		UpdateContext: kerberosAuthBackendGroupWrite,
+		ReadContext:   provider.ReadContextWrapper(kerberosAuthBackendGroupRead),
+		DeleteContext: kerberosAuthBackendGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeKerberos,
+				Description:  "Path where the Kerberos auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldName: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the LDAP group.",
+			},
+			consts.FieldPolicies: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Policies granted to the members of the LDAP group.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func kerberosAuthBackendGroupPath(mount, name string) string {
+	return fmt.Sprintf("auth/%s/groups/%s", mount, name)
+}
+
+func kerberosAuthBackendGroupWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := kerberosAuthBackendGroupPath(d.Get(consts.FieldMount).(string), d.Get(consts.FieldName).(string))
+
+	data := map[string]interface{}{
+		consts.FieldPolicies: d.Get(consts.FieldPolicies).(*schema.Set).List(),
+	}
+
+	log.Printf("[DEBUG] Writing Kerberos auth group %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing Kerberos auth group %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote Kerberos auth group %q", path)
+
+	d.SetId(path)
+
+	return kerberosAuthBackendGroupRead(ctx, d, meta)
+}
+
+func kerberosAuthBackendGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := kerberosAuthBackendGroupRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for Kerberos auth group, expected auth/<mount>/groups/<name>", path)
+	}
+
+	log.Printf("[DEBUG] Reading Kerberos auth group %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading Kerberos auth group %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read Kerberos auth group %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] Kerberos auth group %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldName, m[2]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldPolicies, resp.Data[consts.FieldPolicies]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func kerberosAuthBackendGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting Kerberos auth group %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting Kerberos auth group %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted Kerberos auth group %q", path)
+
+	return nil
+}
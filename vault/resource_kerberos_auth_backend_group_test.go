@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccKerberosAuthBackendGroup(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-kerberos")
+	resourceName := "vault_kerberos_auth_backend_group.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccKerberosAuthBackendGroupCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKerberosAuthBackendGroupConfig(mount, `["dev"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/groups/engineering"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldName, "engineering"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPolicies+".#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldPolicies+".*", "dev"),
+				),
+			},
+			{
+				Config: testAccKerberosAuthBackendGroupConfig(mount, `["dev", "ops"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPolicies+".#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldPolicies+".*", "ops"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil),
+		},
+	})
+}
+
+func testAccKerberosAuthBackendGroupCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_kerberos_auth_backend_group" {
+			continue
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("Kerberos auth group %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccKerberosAuthBackendGroupConfig(mount, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "kerberos"
+  path = "%s"
+}
+
+resource "vault_kerberos_auth_backend_group" "test" {
+  mount    = vault_auth_backend.test.path
+  name     = "engineering"
+  policies = %s
+}
+`, mount, policies)
+}
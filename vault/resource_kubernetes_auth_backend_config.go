@@ -31,9 +31,11 @@ var (
 func kubernetesAuthBackendConfigResource() *schema.Resource {
 	s := map[string]*schema.Schema{
 		consts.FieldKubernetesHost: {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Host must be a host string, a host:port pair, or a URL to the base of the Kubernetes API server.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			AtLeastOneOf: []string{consts.FieldKubernetesHost, consts.FieldKubeconfig},
+			Description:  "Host must be a host string, a host:port pair, or a URL to the base of the Kubernetes API server.",
 		},
 		consts.FieldKubernetesCACert: {
 			Type:        schema.TypeString,
@@ -82,7 +84,10 @@ func kubernetesAuthBackendConfigResource() *schema.Resource {
 			Optional:    true,
 			Description: "Optional disable defaulting to the local CA cert and service account JWT when running in a Kubernetes pod.",
 		},
+		consts.FieldKubeconfig: kubeconfigSchema("Source the Kubernetes host, CA cert and token reviewer JWT " +
+			"from a kubeconfig file, unless they are set explicitly."),
 	}
+	s[consts.FieldKubeconfig].AtLeastOneOf = []string{consts.FieldKubernetesHost, consts.FieldKubeconfig}
 	return &schema.Resource{
 		Create: kubernetesAuthBackendConfigCreate,
 		Read:   provider.ReadWrapper(kubernetesAuthBackendConfigRead),
@@ -93,7 +98,12 @@ func kubernetesAuthBackendConfigResource() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
-			if !diff.Get(consts.FieldDisableLocalCAJWT).(bool) && diff.Id() != "" {
+			caFromKubeconfig, err := kubernetesAuthBackendConfigDiffKubeconfig(diff)
+			if err != nil {
+				return err
+			}
+
+			if !caFromKubeconfig && !diff.Get(consts.FieldDisableLocalCAJWT).(bool) && diff.Id() != "" {
 				// on Vault 1.9.3+ the K8S CA certificate is no longer stored in the Vault
 				// configuration when Vault is running in K8s and FieldDisableLocalCAJWT is
 				// false. Unfortunately, the change did not consider the Vault schema upgrade
@@ -136,6 +146,71 @@ func kubernetesAuthBackendConfigPath(backend string) string {
 	return "auth/" + strings.Trim(backend, "/") + "/config"
 }
 
+// kubernetesAuthBackendConfigDiffKubeconfig plans the host and CA cert from
+// the kubeconfig block when they are not set in the configuration. It returns
+// whether the CA cert is sourced from the kubeconfig.
+func kubernetesAuthBackendConfigDiffKubeconfig(diff *schema.ResourceDiff) (bool, error) {
+	if !diff.NewValueKnown(consts.FieldKubeconfig) {
+		return false, nil
+	}
+
+	creds, err := getKubeconfigCredentials(diff.Get(consts.FieldKubeconfig))
+	if err != nil || creds == nil {
+		return false, err
+	}
+
+	configured := func(k string) bool {
+		rawConfig := diff.GetRawConfig()
+		if rawConfig.IsNull() {
+			// the raw config is not available in legacy diffs
+			_, ok := diff.GetOk(k)
+			return ok
+		}
+		val, ok := rawConfig.AsValueMap()[k]
+		return ok && !val.IsNull()
+	}
+
+	if !configured(consts.FieldKubernetesHost) {
+		if diff.Get(consts.FieldKubernetesHost).(string) != creds.Host {
+			if err := diff.SetNew(consts.FieldKubernetesHost, creds.Host); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if creds.CACert == "" || configured(consts.FieldKubernetesCACert) {
+		return false, nil
+	}
+	if diff.Get(consts.FieldKubernetesCACert).(string) != creds.CACert {
+		if err := diff.SetNew(consts.FieldKubernetesCACert, creds.CACert); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// kubernetesAuthBackendConfigKubeconfigData sources the host, CA cert and token
+// reviewer JWT of data from the kubeconfig block, unless they are set.
+func kubernetesAuthBackendConfigKubeconfigData(d *schema.ResourceData, data map[string]interface{}) error {
+	creds, err := getKubeconfigCredentials(d.Get(consts.FieldKubeconfig))
+	if err != nil || creds == nil {
+		return err
+	}
+
+	if v, _ := data[consts.FieldKubernetesHost].(string); v == "" {
+		data[consts.FieldKubernetesHost] = creds.Host
+	}
+	if _, ok := data[consts.FieldKubernetesCACert]; !ok && creds.CACert != "" {
+		data[consts.FieldKubernetesCACert] = creds.CACert
+	}
+	if _, ok := data[consts.FieldTokenReviewerJWT]; !ok && creds.Token != "" {
+		data[consts.FieldTokenReviewerJWT] = creds.Token
+	}
+
+	return nil
+}
+
 func kubernetesAuthBackendConfigCreate(d *schema.ResourceData, meta interface{}) error {
 	client, e := provider.GetClient(d, meta)
 	if e != nil {
@@ -177,6 +252,11 @@ func kubernetesAuthBackendConfigCreate(d *schema.ResourceData, meta interface{})
 	if v, ok := d.GetOk(consts.FieldDisableLocalCAJWT); ok {
 		data[consts.FieldDisableLocalCAJWT] = v
 	}
+
+	if err := kubernetesAuthBackendConfigKubeconfigData(d, data); err != nil {
+		return err
+	}
+
 	_, err := client.Logical().Write(path, data)
 	if err != nil {
 		return fmt.Errorf("error writing Kubernetes auth backend config %q: %s", path, err)
@@ -185,8 +265,8 @@ func kubernetesAuthBackendConfigCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(path)
 	// NOTE: Since reading the auth/<backend>/config does
 	// not return the `token_reviewer_jwt`,
-	// set it from data after successfully storing it in Vault.
-	if err := d.Set("token_reviewer_jwt", data["token_reviewer_jwt"]); err != nil {
+	// set it from the configuration after successfully storing it in Vault.
+	if err := d.Set("token_reviewer_jwt", d.Get("token_reviewer_jwt")); err != nil {
 		return err
 	}
 
@@ -302,6 +382,10 @@ func kubernetesAuthBackendConfigUpdate(d *schema.ResourceData, meta interface{})
 		setData(consts.FieldDisableLocalCAJWT, v)
 	}
 
+	if err := kubernetesAuthBackendConfigKubeconfigData(d, data); err != nil {
+		return err
+	}
+
 	_, err := client.Logical().Write(path, data)
 	if err != nil {
 		return fmt.Errorf("error updating Kubernetes auth backend config %q: %s", path, err)
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 	"github.com/hashicorp/terraform-provider-vault/util"
 )
@@ -65,6 +66,8 @@ func kubernetesAuthBackendRoleResource() *schema.Resource {
 			Computed:    true,
 			Description: "Configures how identity aliases are generated. Valid choices are: serviceaccount_uid, serviceaccount_name",
 		},
+		consts.FieldNamespaceValidation: kubeconfigSchema("Validate that the bound service account namespaces " +
+			"exist in the Kubernetes cluster of a kubeconfig file before writing the role."),
 	}
 
 	addTokenFields(fields, &addTokenFieldsConfig{})
@@ -111,6 +114,26 @@ func kubernetesAuthBackendRoleUpdateFields(d *schema.ResourceData, data map[stri
 	}
 }
 
+// kubernetesAuthBackendRoleValidateNamespaces checks that the bound service
+// account namespaces exist in the cluster of the namespace_validation block.
+func kubernetesAuthBackendRoleValidateNamespaces(ctx context.Context, d *schema.ResourceData) error {
+	if d.Id() != "" && !d.HasChanges(consts.FieldBoundServiceAccountNamespaces, consts.FieldNamespaceValidation) {
+		return nil
+	}
+
+	creds, err := getKubeconfigCredentials(d.Get(consts.FieldNamespaceValidation))
+	if err != nil || creds == nil {
+		return err
+	}
+
+	var namespaces []string
+	for _, ns := range d.Get(consts.FieldBoundServiceAccountNamespaces).(*schema.Set).List() {
+		namespaces = append(namespaces, ns.(string))
+	}
+
+	return validateKubernetesNamespaces(ctx, creds, namespaces)
+}
+
 func kubernetesAuthBackendRoleNameFromPath(path string) (string, error) {
 	if !kubernetesAuthBackendRoleNameFromPathRegex.MatchString(path) {
 		return "", fmt.Errorf("no role found")
@@ -146,6 +169,10 @@ func kubernetesAuthBackendRoleCreate(ctx context.Context, d *schema.ResourceData
 
 	log.Printf("[DEBUG] Writing Kubernetes auth backend role %q", path)
 
+	if err := kubernetesAuthBackendRoleValidateNamespaces(ctx, d); err != nil {
+		return diag.Errorf("error validating Kubernetes auth backend role %q: %s", path, err)
+	}
+
 	data := map[string]interface{}{}
 	kubernetesAuthBackendRoleUpdateFields(d, data, true)
 
@@ -222,6 +249,10 @@ func kubernetesAuthBackendRoleUpdate(ctx context.Context, d *schema.ResourceData
 
 	log.Printf("[DEBUG] Updating Kubernetes auth backend role %q", path)
 
+	if err := kubernetesAuthBackendRoleValidateNamespaces(ctx, d); err != nil {
+		return diag.Errorf("error validating Kubernetes auth backend role %q: %s", path, err)
+	}
+
 	data := map[string]interface{}{}
 	kubernetesAuthBackendRoleUpdateFields(d, data, false)
 
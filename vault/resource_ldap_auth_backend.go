@@ -169,6 +169,7 @@ func ldapAuthBackendResource() *schema.Resource {
 			Computed:  true,
 			Sensitive: true,
 		},
+		"tune": authMountTuneSchema(),
 	}
 
 	addTokenFields(fields, &addTokenFieldsConfig{})
@@ -230,7 +231,7 @@ func ldapAuthBackendUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(e)
 	}
 
-	path := ldapAuthBackendConfigPath(d.Id())
+	mount := d.Id()
 
 	if !d.IsNewResource() {
 		newMount, err := util.Remount(d, client, consts.FieldPath, true)
@@ -238,9 +239,11 @@ func ldapAuthBackendUpdate(ctx context.Context, d *schema.ResourceData, meta int
 			return diag.FromErr(err)
 		}
 
-		path = ldapAuthBackendConfigPath(newMount)
+		mount = newMount
 	}
 
+	path := ldapAuthBackendConfigPath(mount)
+
 	data := map[string]interface{}{}
 
 	if v, ok := d.GetOk("url"); ok {
@@ -345,6 +348,10 @@ func ldapAuthBackendUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	log.Printf("[DEBUG] Wrote LDAP config %q", path)
 
+	if err := authMountTuneUpdate(d, client, "auth/"+strings.Trim(mount, "/")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return ldapAuthBackendRead(ctx, d, meta)
 }
 
@@ -371,6 +378,10 @@ func ldapAuthBackendRead(_ context.Context, d *schema.ResourceData, meta interfa
 	d.Set("accessor", authMount.Accessor)
 	d.Set("local", authMount.Local)
 
+	if err := authMountTuneRead(d, client, "auth/"+strings.Trim(path, "/")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	path = ldapAuthBackendConfigPath(path)
 
 	log.Printf("[DEBUG] Reading LDAP auth backend config %q", path)
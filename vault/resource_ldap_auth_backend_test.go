@@ -51,6 +51,33 @@ func TestLDAPAuthBackend_basic(t *testing.T) {
 	})
 }
 
+func TestLDAPAuthBackend_tune(t *testing.T) {
+	t.Parallel()
+	path := acctest.RandomWithPrefix("tf-test-ldap-tune-path")
+
+	resourceName := "vault_ldap_auth_backend.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testLDAPAuthBackendDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testLDAPAuthBackendConfig_tune(path, "10m", "hidden", "batch", `"key1", "key2"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "10m", "hidden", "batch", 2),
+			},
+			{
+				Config: testLDAPAuthBackendConfig_tune(path, "1h", "unauth", "default-service", `"key1"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "1h", "unauth", "default-service", 1),
+			},
+			testAccAuthMountTuneDriftStep(t, path, testLDAPAuthBackendConfig_tune(path, "1h", "unauth", "default-service", `"key1"`)),
+			{
+				Config: testLDAPAuthBackendConfig_tune(path, "1h", "unauth", "default-service", `"key1"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "1h", "unauth", "default-service", 1),
+			},
+		},
+	})
+}
+
 func TestLDAPAuthBackend_tls(t *testing.T) {
 	t.Parallel()
 	path := acctest.RandomWithPrefix("tf-test-ldap-tls-path")
@@ -280,6 +307,21 @@ resource "vault_ldap_auth_backend" "test" {
 `, path, local, use_token_groups)
 }
 
+func testLDAPAuthBackendConfig_tune(path, ttl, visibility, tokenType, keys string) string {
+	return fmt.Sprintf(`
+resource "vault_ldap_auth_backend" "test" {
+  path = "%s"
+  url  = "ldaps://example.org"
+  tune {
+    default_lease_ttl            = "%s"
+    listing_visibility           = "%s"
+    token_type                   = "%s"
+    audit_non_hmac_request_keys  = [%s]
+  }
+}
+`, path, ttl, visibility, tokenType, keys)
+}
+
 func testLDAPAuthBackendConfig_tls(path, use_token_groups string, local string) string {
 	return fmt.Sprintf(`
 resource "vault_ldap_auth_backend" "test" {
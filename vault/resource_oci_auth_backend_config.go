@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var ociAuthBackendConfigRegex = regexp.MustCompile("^auth/(.+)/config$")
+
+func ociAuthBackendConfigResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ociAuthBackendConfigWrite,
+		UpdateContext: ociAuthBackendConfigWrite,
+		ReadContext:   provider.ReadContextWrapper(ociAuthBackendConfigRead),
+		DeleteContext: ociAuthBackendConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeOCI,
+				Description:  "Path where the OCI auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldHomeTenancyID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The OCID of the tenancy of the Vault servers.",
+			},
+		},
+	}
+}
+
+func ociAuthBackendConfigPath(mount string) string {
+	return fmt.Sprintf("auth/%s/config", mount)
+}
+
+func ociAuthBackendConfigWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := ociAuthBackendConfigPath(d.Get(consts.FieldMount).(string))
+
+	data := map[string]interface{}{
+		consts.FieldHomeTenancyID: d.Get(consts.FieldHomeTenancyID),
+	}
+
+	log.Printf("[DEBUG] Writing OCI auth config to %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing OCI auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote OCI auth config to %q", path)
+
+	d.SetId(path)
+
+	return ociAuthBackendConfigRead(ctx, d, meta)
+}
+
+func ociAuthBackendConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := ociAuthBackendConfigRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for OCI auth config, expected auth/<mount>/config", path)
+	}
+
+	log.Printf("[DEBUG] Reading OCI auth config from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading OCI auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read OCI auth config from %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] OCI auth config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldHomeTenancyID, resp.Data[consts.FieldHomeTenancyID]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ociAuthBackendConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting OCI auth config %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting OCI auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted OCI auth config %q", path)
+
+	return nil
+}
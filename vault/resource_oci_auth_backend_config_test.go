@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+func TestAccOCIAuthBackendConfig(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-oci")
+	resourceName := "vault_oci_auth_backend_config.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccOCIAuthBackendConfigCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOCIAuthBackendConfigConfig(mount, "ocid1.tenancy.oc1..aaaaaaaa"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/config"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldHomeTenancyID, "ocid1.tenancy.oc1..aaaaaaaa"),
+				),
+			},
+			{
+				Config: testAccOCIAuthBackendConfigConfig(mount, "ocid1.tenancy.oc1..bbbbbbbb"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldHomeTenancyID, "ocid1.tenancy.oc1..bbbbbbbb"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil),
+		},
+	})
+}
+
+func testAccOCIAuthBackendConfigCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_oci_auth_backend_config" {
+			continue
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil && !util.Is404(err) {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("OCI auth config %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccOCIAuthBackendConfigConfig(mount, tenancy string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "oci"
+  path = "%s"
+}
+
+resource "vault_oci_auth_backend_config" "test" {
+  mount           = vault_auth_backend.test.path
+  home_tenancy_id = "%s"
+}
+`, mount, tenancy)
+}
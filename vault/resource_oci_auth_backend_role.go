@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var ociAuthBackendRoleRegex = regexp.MustCompile("^auth/(.+)/role/([^/]+)$")
+
+func ociAuthBackendRoleResource() *schema.Resource {
+	fields := map[string]*schema.Schema{
+		consts.FieldMount: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      consts.MountTypeOCI,
+			Description:  "Path where the OCI auth method is mounted.",
+			ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+		},
+		consts.FieldName: {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the role.",
+		},
+		consts.FieldOCIDList: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Description: "OCIDs of the dynamic groups, users and compartments " +
+				"allowed to log in with the role.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+
+	addTokenFields(fields, &addTokenFieldsConfig{})
+
+	return &schema.Resource{
+		CreateContext: ociAuthBackendRoleWrite,
+		UpdateContext: ociAuthBackendRoleWrite,
+		ReadContext:   provider.ReadContextWrapper(ociAuthBackendRoleRead),
+		DeleteContext: ociAuthBackendRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: fields,
+	}
+}
+
+func ociAuthBackendRolePath(mount, name string) string {
+	return fmt.Sprintf("auth/%s/role/%s", mount, name)
+}
+
+func ociAuthBackendRoleWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := ociAuthBackendRolePath(d.Get(consts.FieldMount).(string), d.Get(consts.FieldName).(string))
+
+	data := map[string]interface{}{
+		consts.FieldOCIDList: d.Get(consts.FieldOCIDList).(*schema.Set).List(),
+	}
+	updateTokenFields(d, data, d.IsNewResource())
+
+	log.Printf("[DEBUG] Writing OCI auth role %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing OCI auth role %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote OCI auth role %q", path)
+
+	d.SetId(path)
+
+	return ociAuthBackendRoleRead(ctx, d, meta)
+}
+
+func ociAuthBackendRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := ociAuthBackendRoleRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for OCI auth role, expected auth/<mount>/role/<name>", path)
+	}
+
+	log.Printf("[DEBUG] Reading OCI auth role %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading OCI auth role %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read OCI auth role %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] OCI auth role %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldName, m[2]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldOCIDList, resp.Data[consts.FieldOCIDList]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := readTokenFields(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func ociAuthBackendRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting OCI auth role %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting OCI auth role %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted OCI auth role %q", path)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccOCIAuthBackendRole(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-oci")
+	resourceName := "vault_oci_auth_backend_role.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccOCIAuthBackendRoleCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOCIAuthBackendRoleConfig(mount, `["ocid1.dynamicgroup.oc1..aaaaaaaa"]`, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/role/instances"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldName, "instances"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldOCIDList+".#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldOCIDList+".*", "ocid1.dynamicgroup.oc1..aaaaaaaa"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldTTL, "3600"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldPolicies+".#", "1"),
+				),
+			},
+			{
+				Config: testAccOCIAuthBackendRoleConfig(mount, `["ocid1.dynamicgroup.oc1..aaaaaaaa", "ocid1.group.oc1..bbbbbbbb"]`, 7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldOCIDList+".#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldOCIDList+".*", "ocid1.group.oc1..bbbbbbbb"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldTTL, "7200"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil),
+		},
+	})
+}
+
+func testAccOCIAuthBackendRoleCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_oci_auth_backend_role" {
+			continue
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("OCI auth role %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccOCIAuthBackendRoleConfig(mount, ocids string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "oci"
+  path = "%s"
+}
+
+resource "vault_oci_auth_backend_role" "test" {
+  mount          = vault_auth_backend.test.path
+  name           = "instances"
+  ocid_list      = %s
+  token_policies = ["instances"]
+  token_ttl      = %d
+}
+`, mount, ocids, ttl)
+}
@@ -214,6 +214,8 @@ func oktaAuthBackendResource() *schema.Resource {
 				Computed:    true,
 				Description: "The mount accessor related to the auth mount.",
 			},
+
+			"tune": authMountTuneSchema(),
 		},
 	}, false)
 }
@@ -347,6 +349,10 @@ func oktaAuthBackendRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if err := authMountTuneRead(d, client, "auth/"+path); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -444,6 +450,10 @@ func oktaAuthBackendUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if err := authMountTuneUpdate(d, client, "auth/"+path); err != nil {
+		return err
+	}
+
 	return oktaAuthBackendRead(d, meta)
 }
 
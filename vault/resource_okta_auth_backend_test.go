@@ -48,6 +48,34 @@ func TestAccOktaAuthBackend_basic(t *testing.T) {
 	})
 }
 
+func TestAccOktaAuthBackend_tune(t *testing.T) {
+	t.Parallel()
+	organization := "example"
+	path := resource.PrefixedUniqueId("okta-tune-")
+	resourceName := "vault_okta_auth_backend.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccOktaAuthBackend_Destroyed(path),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOktaAuthConfig_tune(path, organization, "10m", "hidden", "batch", `"key1", "key2"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "10m", "hidden", "batch", 2),
+			},
+			{
+				Config: testAccOktaAuthConfig_tune(path, organization, "1h", "unauth", "default-service", `"key1"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "1h", "unauth", "default-service", 1),
+			},
+			testAccAuthMountTuneDriftStep(t, path, testAccOktaAuthConfig_tune(path, organization, "1h", "unauth", "default-service", `"key1"`)),
+			{
+				Config: testAccOktaAuthConfig_tune(path, organization, "1h", "unauth", "default-service", `"key1"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "1h", "unauth", "default-service", 1),
+			},
+		},
+	})
+}
+
 func TestAccOktaAuthBackend_import(t *testing.T) {
 	t.Parallel()
 	organization := "example"
@@ -221,6 +249,21 @@ resource "vault_okta_auth_backend" "test" {
 `, path, organization)
 }
 
+func testAccOktaAuthConfig_tune(path, organization, ttl, visibility, tokenType, keys string) string {
+	return fmt.Sprintf(`
+resource "vault_okta_auth_backend" "test" {
+  path         = "%s"
+  organization = "%s"
+  tune {
+    default_lease_ttl            = "%s"
+    listing_visibility           = "%s"
+    token_type                   = "%s"
+    audit_non_hmac_request_keys  = [%s]
+  }
+}
+`, path, organization, ttl, visibility, tokenType, keys)
+}
+
 func testAccOktaAuthConfig_invalid_ttl(path string, organization string) string {
 	return fmt.Sprintf(`
 resource "vault_okta_auth_backend" "test" {
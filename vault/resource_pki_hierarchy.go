@@ -0,0 +1,606 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+// pkiHierarchyCA holds the spec and the generated issuer of
+// a single mount of a vault_pki_hierarchy.
+type pkiHierarchyCA struct {
+	spec     map[string]interface{}
+	path     string
+	parent   *pkiHierarchyCA
+	issuerID string
+	keyID    string
+	chain    []string
+}
+
+func pkiHierarchyCASchema(isRoot bool) *schema.Resource {
+	defaultTTL := "43800h"
+	if isRoot {
+		defaultTTL = "87600h"
+	}
+
+	s := map[string]*schema.Schema{
+		consts.FieldPath: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Description:      "Path where the PKI secret backend is mounted.",
+			ValidateDiagFunc: provider.ValidateDiagPath,
+		},
+		consts.FieldCommonName: {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "CN of the CA certificate.",
+		},
+		consts.FieldTTL: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     defaultTTL,
+			Description: "Time to live of the CA certificate.",
+		},
+		consts.FieldMaxLeaseTTL: {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     315360000,
+			Description: "Maximum lease TTL of the mount in seconds, must be at least the ttl of the CA certificate.",
+		},
+		consts.FieldKeyType: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     "rsa",
+			Description: "The desired key type.",
+		},
+		consts.FieldKeyBits: {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     2048,
+			Description: "The number of bits to use.",
+		},
+		consts.FieldMaxPathLength: {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     -1,
+			Description: "The maximum path length to encode in the CA certificate.",
+		},
+		consts.FieldIssuerName: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Name of the issuer created on the mount.",
+		},
+		consts.FieldOrganization: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The organization.",
+		},
+		consts.FieldOu: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The organization unit.",
+		},
+	}
+
+	if !isRoot {
+		s[consts.FieldParent] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Description: "Path of the mount that signs this intermediate, " +
+				"either the root or a preceding intermediate. Defaults to the root.",
+			ValidateDiagFunc: provider.ValidateDiagPath,
+		}
+	}
+
+	return &schema.Resource{Schema: s}
+}
+
+func pkiHierarchyResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: provider.MountCreateContextWrapper(pkiHierarchyCreate, provider.VaultVersion111),
+		ReadContext:   provider.ReadContextWrapper(pkiHierarchyRead),
+		UpdateContext: pkiHierarchyUpdate,
+		DeleteContext: pkiHierarchyDelete,
+		CustomizeDiff: pkiHierarchyCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldRoot: {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "The root CA.",
+				Elem:        pkiHierarchyCASchema(true),
+			},
+			consts.FieldIntermediate: {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The intermediate CAs, in signing order.",
+				Elem:        pkiHierarchyCASchema(false),
+			},
+			consts.FieldURLBase: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Base URL of the Vault API, e.g. https://vault.example.com:8200/v1. " +
+					"Used to configure the issuing certificate, CRL distribution point and OCSP URLs of every mount.",
+			},
+			consts.FieldCRLExpiry: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "72h",
+				Description: "Time until the CRLs of every mount expire.",
+			},
+			consts.FieldCRLAutoRebuild: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable automatic rebuilding of the CRLs of every mount.",
+			},
+			consts.FieldIssuers: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The issuers of the hierarchy, the root first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldPath: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Path of the mount.",
+						},
+						consts.FieldIssuerID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the default issuer of the mount.",
+						},
+						consts.FieldIssuerName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the default issuer of the mount.",
+						},
+						consts.FieldKeyID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the key of the issuer.",
+						},
+						consts.FieldCertificate: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The CA certificate.",
+						},
+						consts.FieldSerialNumber: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The serial number of the CA certificate.",
+						},
+						consts.FieldCAChain: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The chain of the CA certificate up to the root, the certificate itself first.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getPKIHierarchyCAs returns the CAs of the hierarchy in signing order,
+// with every intermediate linked to its parent.
+func getPKIHierarchyCAs(d *schema.ResourceData) ([]*pkiHierarchyCA, error) {
+	root := &pkiHierarchyCA{
+		spec: d.Get(consts.FieldRoot).([]interface{})[0].(map[string]interface{}),
+	}
+	root.path = strings.Trim(root.spec[consts.FieldPath].(string), "/")
+
+	cas := []*pkiHierarchyCA{root}
+	byPath := map[string]*pkiHierarchyCA{root.path: root}
+	for _, v := range d.Get(consts.FieldIntermediate).([]interface{}) {
+		spec := v.(map[string]interface{})
+		ca := &pkiHierarchyCA{
+			spec:   spec,
+			path:   strings.Trim(spec[consts.FieldPath].(string), "/"),
+			parent: root,
+		}
+
+		if _, ok := byPath[ca.path]; ok {
+			return nil, fmt.Errorf("duplicate mount path %q", ca.path)
+		}
+
+		if parent := strings.Trim(spec[consts.FieldParent].(string), "/"); parent != "" {
+			p, ok := byPath[parent]
+			if !ok {
+				return nil, fmt.Errorf("parent %q of intermediate %q must be the root or a preceding intermediate",
+					parent, ca.path)
+			}
+			ca.parent = p
+		}
+
+		cas = append(cas, ca)
+		byPath[ca.path] = ca
+	}
+
+	return cas, nil
+}
+
+func pkiHierarchyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	cas, err := getPKIHierarchyCAs(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var mounted []*pkiHierarchyCA
+	if err := pkiHierarchyBootstrap(ctx, client, cas, &mounted); err != nil {
+		// no state is recorded for a failed create, so remove the
+		// mounts that were created in order not to orphan them.
+		if rbErr := pkiHierarchyUnmount(ctx, client, mounted); rbErr != nil {
+			log.Printf("[WARN] Failed to roll back PKI hierarchy mounts: %s", rbErr)
+		}
+		return diag.FromErr(err)
+	}
+
+	if err := pkiHierarchyConfigure(ctx, d, client, cas); err != nil {
+		if rbErr := pkiHierarchyUnmount(ctx, client, cas); rbErr != nil {
+			log.Printf("[WARN] Failed to roll back PKI hierarchy mounts: %s", rbErr)
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId(cas[0].path)
+
+	if err := d.Set(consts.FieldIssuers, pkiHierarchyIssuers(cas)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return pkiHierarchyRead(ctx, d, meta)
+}
+
+// pkiHierarchyBootstrap mounts every CA, generates its issuer and makes it
+// the default issuer of the mount, recording every successfully created mount in mounted.
+func pkiHierarchyBootstrap(ctx context.Context, client *api.Client, cas []*pkiHierarchyCA, mounted *[]*pkiHierarchyCA) error {
+	for _, ca := range cas {
+		log.Printf("[DEBUG] Mounting PKI secret backend %q", ca.path)
+		if err := client.Sys().MountWithContext(ctx, ca.path, &api.MountInput{
+			Type: consts.MountTypePKI,
+			Config: api.MountConfigInput{
+				MaxLeaseTTL: fmt.Sprintf("%ds", ca.spec[consts.FieldMaxLeaseTTL]),
+			},
+		}); err != nil {
+			return fmt.Errorf("error mounting PKI secret backend %q: %w", ca.path, err)
+		}
+		*mounted = append(*mounted, ca)
+
+		if ca.parent == nil {
+			if err := pkiHierarchyGenerateRoot(ctx, client, ca); err != nil {
+				return err
+			}
+		} else if err := pkiHierarchyGenerateIntermediate(ctx, client, ca); err != nil {
+			return err
+		}
+
+		path := fmt.Sprintf("%s/config/issuers", ca.path)
+		if _, err := client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+			consts.FieldDefault: ca.issuerID,
+		}); err != nil {
+			return fmt.Errorf("error writing data to %q, err=%w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// pkiHierarchyCAFields reads the fields of the spec of a CA, so that the
+// requests are built the same way as for the PKI secret backend resources.
+type pkiHierarchyCAFields map[string]interface{}
+
+func (f pkiHierarchyCAFields) Get(k string) interface{} {
+	return f[k]
+}
+
+func (f pkiHierarchyCAFields) GetOk(k string) (interface{}, bool) {
+	v, ok := f[k]
+	if !ok || v == nil {
+		return nil, false
+	}
+
+	return v, !reflect.ValueOf(v).IsZero()
+}
+
+func pkiHierarchyGenerateRoot(ctx context.Context, client *api.Client, ca *pkiHierarchyCA) error {
+	path := pkiSecretBackendGenerateRootPath(ca.path, "internal", true)
+	data := pkiSecretBackendRootCertRequestData(pkiHierarchyCAFields(ca.spec), "internal", true)
+
+	log.Printf("[DEBUG] Creating root cert on PKI secret backend %q", ca.path)
+	resp, err := client.Logical().WriteWithContext(ctx, path, data)
+	if err != nil {
+		return fmt.Errorf("error creating root cert for PKI secret backend %q: %w", ca.path, err)
+	}
+	if resp == nil {
+		return fmt.Errorf("empty response creating root cert for PKI secret backend %q", ca.path)
+	}
+	log.Printf("[DEBUG] Created root cert on PKI secret backend %q", ca.path)
+
+	ca.issuerID = resp.Data[consts.FieldIssuerID].(string)
+	ca.keyID = resp.Data[consts.FieldKeyID].(string)
+	ca.chain = []string{resp.Data[consts.FieldCertificate].(string)}
+
+	return nil
+}
+
+func pkiHierarchyGenerateIntermediate(ctx context.Context, client *api.Client, ca *pkiHierarchyCA) error {
+	path := pkiSecretBackendIntermediateGeneratePath(ca.path, "internal", true)
+	data := pkiSecretBackendIntermediateCertRequestData(pkiHierarchyCAFields(ca.spec), "internal", true)
+
+	log.Printf("[DEBUG] Creating intermediate cert request on PKI secret backend %q", ca.path)
+	resp, err := client.Logical().WriteWithContext(ctx, path, data)
+	if err != nil {
+		return fmt.Errorf("error creating intermediate cert request for PKI secret backend %q: %w", ca.path, err)
+	}
+	if resp == nil {
+		return fmt.Errorf("empty response creating intermediate cert request for PKI secret backend %q", ca.path)
+	}
+	ca.keyID = resp.Data[consts.FieldKeyID].(string)
+
+	signFields := pkiHierarchyCAFields{
+		consts.FieldCSR:       resp.Data[consts.FieldCSR],
+		consts.FieldIssuerRef: ca.parent.issuerID,
+	}
+	for k, v := range ca.spec {
+		signFields[k] = v
+	}
+	signData := pkiSecretBackendRootSignIntermediateRequestData(signFields, true)
+
+	log.Printf("[DEBUG] Signing intermediate of %q on PKI secret backend %q", ca.path, ca.parent.path)
+	resp, err = client.Logical().WriteWithContext(ctx, pkiSecretBackendRootSignIntermediateCreatePath(ca.parent.path), signData)
+	if err != nil {
+		return fmt.Errorf("error signing intermediate of %q on PKI secret backend %q: %w", ca.path, ca.parent.path, err)
+	}
+	if resp == nil {
+		return fmt.Errorf("empty response signing intermediate of %q on PKI secret backend %q", ca.path, ca.parent.path)
+	}
+	ca.chain = append([]string{resp.Data[consts.FieldCertificate].(string)}, ca.parent.chain...)
+
+	log.Printf("[DEBUG] Setting signed intermediate on PKI secret backend %q", ca.path)
+	resp, err = client.Logical().WriteWithContext(ctx, pkiSecretBackendIntermediateSetSignedCreatePath(ca.path),
+		map[string]interface{}{
+			consts.FieldCertificate: strings.Join(ca.chain, "\n"),
+		})
+	if err != nil {
+		return fmt.Errorf("error setting signed intermediate on PKI secret backend %q: %w", ca.path, err)
+	}
+	if resp == nil {
+		return fmt.Errorf("empty response setting signed intermediate on PKI secret backend %q", ca.path)
+	}
+
+	ca.issuerID, err = pkiHierarchyImportedIssuer(resp.Data, ca.keyID)
+	if err != nil {
+		return fmt.Errorf("error setting signed intermediate on PKI secret backend %q: %w", ca.path, err)
+	}
+
+	if name, ok := ca.spec[consts.FieldIssuerName]; ok && name != "" {
+		issuerPath := fmt.Sprintf("%s/issuer/%s", ca.path, ca.issuerID)
+		if _, err := client.Logical().JSONMergePatch(ctx, issuerPath, map[string]interface{}{
+			consts.FieldIssuerName: name,
+		}); err != nil {
+			return fmt.Errorf("error naming issuer %q: %w", issuerPath, err)
+		}
+	}
+
+	return nil
+}
+
+// pkiHierarchyImportedIssuer returns the issuer created by set-signed for the given key.
+// The parent CAs of the chain are imported as well, so the key mapping is used to tell them apart.
+func pkiHierarchyImportedIssuer(data map[string]interface{}, keyID string) (string, error) {
+	if m, ok := data["mapping"].(map[string]interface{}); ok {
+		for issuerID, k := range m {
+			if k == keyID {
+				return issuerID, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no issuer was imported for key %q", keyID)
+}
+
+// pkiHierarchyConfigure applies the settings shared by every mount of the hierarchy.
+func pkiHierarchyConfigure(ctx context.Context, d *schema.ResourceData, client *api.Client, cas []*pkiHierarchyCA) error {
+	urlBase := strings.TrimRight(d.Get(consts.FieldURLBase).(string), "/")
+	for _, ca := range cas {
+		urls := map[string]interface{}{
+			consts.FieldIssuingCertificates:   []string{},
+			consts.FieldCRLDistributionPoints: []string{},
+			consts.FieldOCSPServers:           []string{},
+		}
+		if urlBase != "" {
+			urls[consts.FieldIssuingCertificates] = []string{fmt.Sprintf("%s/%s/ca", urlBase, ca.path)}
+			urls[consts.FieldCRLDistributionPoints] = []string{fmt.Sprintf("%s/%s/crl", urlBase, ca.path)}
+			urls[consts.FieldOCSPServers] = []string{fmt.Sprintf("%s/%s/ocsp", urlBase, ca.path)}
+		}
+		if _, err := client.Logical().WriteWithContext(ctx, pkiSecretBackendConfigUrlsPath(ca.path), urls); err != nil {
+			return fmt.Errorf("error writing PKI URL config to %q: %w", ca.path, err)
+		}
+
+		if _, err := client.Logical().WriteWithContext(ctx, pkiSecretBackendCrlConfigPath(ca.path), map[string]interface{}{
+			consts.FieldExpiry:      d.Get(consts.FieldCRLExpiry),
+			consts.FieldAutoRebuild: d.Get(consts.FieldCRLAutoRebuild),
+		}); err != nil {
+			return fmt.Errorf("error writing CRL config to PKI secret backend %q: %w", ca.path, err)
+		}
+	}
+
+	return nil
+}
+
+func pkiHierarchyIssuers(cas []*pkiHierarchyCA) []interface{} {
+	var issuers []interface{}
+	for _, ca := range cas {
+		issuers = append(issuers, map[string]interface{}{
+			consts.FieldPath:     ca.path,
+			consts.FieldIssuerID: ca.issuerID,
+			consts.FieldKeyID:    ca.keyID,
+		})
+	}
+
+	return issuers
+}
+
+func pkiHierarchyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	var issuers []interface{}
+	for i, v := range d.Get(consts.FieldIssuers).([]interface{}) {
+		issuer := v.(map[string]interface{})
+		path := fmt.Sprintf("%s/issuer/%s", issuer[consts.FieldPath], issuer[consts.FieldIssuerID])
+
+		var resp *api.Secret
+		if issuer[consts.FieldIssuerID] != "" {
+			log.Printf("[DEBUG] Reading PKI issuer %q", path)
+			var err error
+			resp, err = client.Logical().ReadWithContext(ctx, path)
+			if err != nil && !util.Is404(err) {
+				return diag.Errorf("error reading PKI issuer %q: %s", path, err)
+			}
+		}
+		if resp == nil {
+			if i == 0 {
+				log.Printf("[WARN] Root issuer %q not found, removing PKI hierarchy from state", path)
+				d.SetId("")
+				return nil
+			}
+			// keep the intermediate with an empty issuer_id, so that
+			// pkiHierarchyCustomizeDiff replaces the hierarchy.
+			log.Printf("[WARN] Issuer %q of the PKI hierarchy not found", path)
+			issuers = append(issuers, map[string]interface{}{
+				consts.FieldPath: issuer[consts.FieldPath],
+			})
+			continue
+		}
+
+		certificate := resp.Data[consts.FieldCertificate].(string)
+		certs, err := pki.ParseCertificates(certificate)
+		if err != nil {
+			return diag.Errorf("error parsing certificate of PKI issuer %q: %s", path, err)
+		}
+
+		issuer[consts.FieldCertificate] = certificate
+		issuer[consts.FieldIssuerName] = resp.Data[consts.FieldIssuerName]
+		issuer[consts.FieldKeyID] = resp.Data[consts.FieldKeyID]
+		issuer[consts.FieldSerialNumber] = pki.SerialNumber(certs[0])
+		issuer[consts.FieldCAChain] = resp.Data[consts.FieldCAChain]
+		issuers = append(issuers, issuer)
+	}
+
+	if err := d.Set(consts.FieldIssuers, issuers); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// pkiHierarchyCustomizeDiff replaces the hierarchy when the issuer of one of
+// its intermediates was deleted outside of Terraform, the intermediates
+// cannot be signed again in place.
+func pkiHierarchyCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	for _, v := range diff.Get(consts.FieldIssuers).([]interface{}) {
+		issuer := v.(map[string]interface{})
+		if issuer[consts.FieldIssuerID] != "" {
+			continue
+		}
+
+		log.Printf("[DEBUG] Issuer of PKI secret backend %q not found, replacing the PKI hierarchy", issuer[consts.FieldPath])
+		if err := diff.SetNewComputed(consts.FieldIssuers); err != nil {
+			return err
+		}
+		return diff.ForceNew(consts.FieldIssuers)
+	}
+
+	return nil
+}
+
+func pkiHierarchyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	cas, err := getPKIHierarchyCAs(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// everything but the shared mount settings forces a new hierarchy
+	if err := pkiHierarchyConfigure(ctx, d, client, cas); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return pkiHierarchyRead(ctx, d, meta)
+}
+
+func pkiHierarchyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	cas, err := getPKIHierarchyCAs(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := pkiHierarchyUnmount(ctx, client, cas); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// pkiHierarchyUnmount unmounts the CAs, the leaves first.
+func pkiHierarchyUnmount(ctx context.Context, client *api.Client, cas []*pkiHierarchyCA) error {
+	for i := len(cas) - 1; i >= 0; i-- {
+		log.Printf("[DEBUG] Unmounting PKI secret backend %q", cas[i].path)
+		if err := client.Sys().UnmountWithContext(ctx, cas[i].path); err != nil {
+			return fmt.Errorf("error unmounting PKI secret backend %q: %w", cas[i].path, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,369 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestPkiHierarchy_basic(t *testing.T) {
+	root := acctest.RandomWithPrefix("tf-test-pki-root")
+	issuing := acctest.RandomWithPrefix("tf-test-pki-issuing")
+	nested := acctest.RandomWithPrefix("tf-test-pki-nested")
+	resourceName := "vault_pki_hierarchy.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck: func() {
+			testutil.TestAccPreCheck(t)
+			SkipIfAPIVersionLT(t, testProvider.Meta(), provider.VaultVersion111)
+		},
+		CheckDestroy: testPkiHierarchyDestroy(root, issuing, nested),
+		Steps: []resource.TestStep{
+			{
+				Config: testPkiHierarchyConfig(root, issuing, nested, "72h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", root),
+					resource.TestCheckResourceAttr(resourceName, "issuers.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "issuers.0.path", root),
+					resource.TestCheckResourceAttr(resourceName, "issuers.0.issuer_name", "root"),
+					resource.TestCheckResourceAttr(resourceName, "issuers.0.ca_chain.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "issuers.1.path", issuing),
+					resource.TestCheckResourceAttr(resourceName, "issuers.1.issuer_name", "issuing"),
+					resource.TestCheckResourceAttr(resourceName, "issuers.1.ca_chain.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "issuers.2.path", nested),
+					resource.TestCheckResourceAttr(resourceName, "issuers.2.issuer_name", ""),
+					resource.TestCheckResourceAttr(resourceName, "issuers.2.ca_chain.#", "3"),
+					resource.TestCheckResourceAttrSet(resourceName, "issuers.2.issuer_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "issuers.2.key_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "issuers.2.certificate"),
+					resource.TestCheckResourceAttrSet(resourceName, "issuers.2.serial_number"),
+					testPkiHierarchyCheckMount(issuing, "72h"),
+				),
+			},
+			{
+				Config: testPkiHierarchyConfig(root, issuing, nested, "48h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "issuers.#", "3"),
+					testPkiHierarchyCheckMount(issuing, "48h"),
+				),
+			},
+		},
+	})
+}
+
+func testPkiHierarchyCheckMount(path, crlExpiry string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+
+		resp, err := client.Logical().Read(pkiSecretBackendConfigUrlsPath(path))
+		if err != nil {
+			return err
+		}
+		expected := []interface{}{fmt.Sprintf("https://vault.example.com:8200/v1/%s/crl", path)}
+		if actual := resp.Data[consts.FieldCRLDistributionPoints]; !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected %s %v, got %v", consts.FieldCRLDistributionPoints, expected, actual)
+		}
+
+		resp, err = client.Logical().Read(pkiSecretBackendCrlConfigPath(path))
+		if err != nil {
+			return err
+		}
+		if actual := resp.Data[consts.FieldExpiry]; actual != crlExpiry {
+			return fmt.Errorf("expected %s %q, got %q", consts.FieldExpiry, crlExpiry, actual)
+		}
+
+		return nil
+	}
+}
+
+func testPkiHierarchyDestroy(paths ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testProvider.Meta().(*provider.ProviderMeta).MustGetClient()
+
+		mounts, err := client.Sys().ListMounts()
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			if _, ok := mounts[path+"/"]; ok {
+				return fmt.Errorf("mount %q still exists", path)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testPkiHierarchyConfig(root, issuing, nested, crlExpiry string) string {
+	return fmt.Sprintf(`
+resource "vault_pki_hierarchy" "test" {
+  url_base         = "https://vault.example.com:8200/v1"
+  crl_expiry       = "%s"
+  crl_auto_rebuild = true
+
+  root {
+    path        = "%s"
+    common_name = "Test Root CA"
+    issuer_name = "root"
+  }
+
+  intermediate {
+    path            = "%s"
+    common_name     = "Test Issuing CA"
+    issuer_name     = "issuing"
+    ttl             = "8760h"
+    max_path_length = 1
+  }
+
+  intermediate {
+    path        = "%s"
+    parent      = "%s"
+    common_name = "Test Nested CA"
+    ttl         = "4380h"
+    key_type    = "ec"
+    key_bits    = 256
+  }
+}
+`, crlExpiry, root, issuing, nested, issuing)
+}
+
+func TestGetPKIHierarchyCAs(t *testing.T) {
+	ca := func(path, parent string) map[string]interface{} {
+		return map[string]interface{}{
+			consts.FieldPath:       path,
+			consts.FieldParent:     parent,
+			consts.FieldCommonName: path,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		intermediates []interface{}
+		wantParents   []string
+		wantErr       bool
+	}{
+		{
+			name:          "default-parent",
+			intermediates: []interface{}{ca("int-a", ""), ca("int-b", "")},
+			wantParents:   []string{"", "root", "root"},
+		},
+		{
+			name:          "nested",
+			intermediates: []interface{}{ca("int-a", ""), ca("int-b", "int-a"), ca("int-c", "root")},
+			wantParents:   []string{"", "root", "int-a", "root"},
+		},
+		{
+			name:          "parent-declared-later",
+			intermediates: []interface{}{ca("int-a", "int-b"), ca("int-b", "")},
+			wantErr:       true,
+		},
+		{
+			name:          "self-parent",
+			intermediates: []interface{}{ca("int-a", "int-a")},
+			wantErr:       true,
+		},
+		{
+			name:          "duplicate",
+			intermediates: []interface{}{ca("int-a", ""), ca("root", "")},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := pkiHierarchyResource()
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				consts.FieldRoot:         []interface{}{ca("root", "")},
+				consts.FieldIntermediate: tt.intermediates,
+			})
+
+			cas, err := getPKIHierarchyCAs(d)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("getPKIHierarchyCAs() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getPKIHierarchyCAs() unexpected error: %s", err)
+			}
+
+			var actual []string
+			for _, ca := range cas {
+				parent := ""
+				if ca.parent != nil {
+					parent = ca.parent.path
+				}
+				actual = append(actual, parent)
+			}
+			if !reflect.DeepEqual(tt.wantParents, actual) {
+				t.Fatalf("getPKIHierarchyCAs() expected parents %v, got %v", tt.wantParents, actual)
+			}
+		})
+	}
+}
+
+func TestPKIHierarchyImportedIssuer(t *testing.T) {
+	data := map[string]interface{}{
+		consts.FieldImportedIssuers: []interface{}{"issuer-root", "issuer-int"},
+		"mapping": map[string]interface{}{
+			"issuer-root": "",
+			"issuer-int":  "key-int",
+		},
+	}
+
+	actual, err := pkiHierarchyImportedIssuer(data, "key-int")
+	if err != nil {
+		t.Fatalf("pkiHierarchyImportedIssuer() unexpected error: %s", err)
+	}
+	if actual != "issuer-int" {
+		t.Fatalf("pkiHierarchyImportedIssuer() expected %q, got %q", "issuer-int", actual)
+	}
+
+	if _, err := pkiHierarchyImportedIssuer(data, "key-other"); err == nil {
+		t.Fatalf("pkiHierarchyImportedIssuer() expected an error for an unknown key")
+	}
+}
+
+func TestPKIHierarchyCAFields(t *testing.T) {
+	r := pkiHierarchyResource()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		consts.FieldRoot: []interface{}{
+			map[string]interface{}{
+				consts.FieldPath:       "root",
+				consts.FieldCommonName: "Root CA",
+				consts.FieldIssuerName: "root-2024",
+			},
+		},
+		consts.FieldIntermediate: []interface{}{
+			map[string]interface{}{
+				consts.FieldPath:         "int",
+				consts.FieldCommonName:   "Intermediate CA",
+				consts.FieldOrganization: "Example",
+			},
+		},
+	})
+
+	cas, err := getPKIHierarchyCAs(d)
+	if err != nil {
+		t.Fatalf("getPKIHierarchyCAs() unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		consts.FieldCommonName:    "Root CA",
+		consts.FieldTTL:           "87600h",
+		consts.FieldKeyType:       "rsa",
+		consts.FieldKeyBits:       2048,
+		consts.FieldMaxPathLength: -1,
+		consts.FieldIssuerName:    "root-2024",
+	}
+	actual := pkiSecretBackendRootCertRequestData(pkiHierarchyCAFields(cas[0].spec), "internal", true)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("pkiSecretBackendRootCertRequestData() expected %v, got %v", expected, actual)
+	}
+
+	expected = map[string]interface{}{
+		consts.FieldCommonName:   "Intermediate CA",
+		consts.FieldOrganization: "Example",
+		consts.FieldKeyType:      "rsa",
+		consts.FieldKeyBits:      2048,
+	}
+	actual = pkiSecretBackendIntermediateCertRequestData(pkiHierarchyCAFields(cas[1].spec), "internal", true)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("pkiSecretBackendIntermediateCertRequestData() expected %v, got %v", expected, actual)
+	}
+
+	fields := pkiHierarchyCAFields{
+		consts.FieldCSR:       "csr",
+		consts.FieldIssuerRef: "issuer-root",
+	}
+	for k, v := range cas[1].spec {
+		fields[k] = v
+	}
+	expected = map[string]interface{}{
+		consts.FieldCSR:           "csr",
+		consts.FieldIssuerRef:     "issuer-root",
+		consts.FieldCommonName:    "Intermediate CA",
+		consts.FieldOrganization:  "Example",
+		consts.FieldTTL:           "43800h",
+		consts.FieldMaxPathLength: -1,
+	}
+	actual = pkiSecretBackendRootSignIntermediateRequestData(fields, true)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("pkiSecretBackendRootSignIntermediateRequestData() expected %v, got %v", expected, actual)
+	}
+}
+
+func TestPKIHierarchyCustomizeDiff(t *testing.T) {
+	raw := map[string]interface{}{
+		consts.FieldRoot: []interface{}{
+			map[string]interface{}{
+				consts.FieldPath:       "root",
+				consts.FieldCommonName: "Root CA",
+			},
+		},
+		consts.FieldIntermediate: []interface{}{
+			map[string]interface{}{
+				consts.FieldPath:       "int",
+				consts.FieldCommonName: "Intermediate CA",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		issuerID    string
+		wantReplace bool
+	}{
+		{
+			name:     "in-sync",
+			issuerID: "issuer-int",
+		},
+		{
+			name:        "missing-intermediate-issuer",
+			wantReplace: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := pkiHierarchyResource()
+			d := schema.TestResourceDataRaw(t, r.Schema, raw)
+			d.SetId("root")
+			if err := d.Set(consts.FieldIssuers, []interface{}{
+				map[string]interface{}{
+					consts.FieldPath:     "root",
+					consts.FieldIssuerID: "issuer-root",
+				},
+				map[string]interface{}{
+					consts.FieldPath:     "int",
+					consts.FieldIssuerID: tt.issuerID,
+				},
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+
+			if actual := diff != nil && diff.RequiresNew(); actual != tt.wantReplace {
+				t.Fatalf("expected replace %t, got %t", tt.wantReplace, actual)
+			}
+		})
+	}
+}
@@ -234,8 +234,63 @@ func pkiSecretBackendIntermediateCertRequestCreate(ctx context.Context, d *schem
 	backend := d.Get(consts.FieldBackend).(string)
 	intermediateType := d.Get(consts.FieldType).(string)
 
-	path := pkiSecretBackendIntermediateGeneratePath(backend, intermediateType, provider.IsAPISupported(meta, provider.VaultVersion111))
+	// add multi-issuer write API fields if supported
+	isIssuerAPISupported := provider.IsAPISupported(meta, provider.VaultVersion111)
+
+	path := pkiSecretBackendIntermediateGeneratePath(backend, intermediateType, isIssuerAPISupported)
+	data := pkiSecretBackendIntermediateCertRequestData(d, intermediateType, isIssuerAPISupported)
+
+	log.Printf("[DEBUG] Creating intermediate cert request on PKI secret backend %q", backend)
+	resp, err := client.Logical().Write(path, data)
+	if err != nil {
+		return diag.Errorf("error creating intermediate cert request for PKI secret backend %q: %s", backend, err)
+	}
+	log.Printf("[DEBUG] Created intermediate cert request on PKI secret backend %q", backend)
+
+	if err := d.Set(consts.FieldCSR, resp.Data[consts.FieldCSR]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// multi-issuer API fields that are set to TF state
+	// after a read from Vault
+	multiIssuerAPIComputedFields := []string{
+		consts.FieldKeyID,
+	}
+
+	if isIssuerAPISupported {
+		for _, k := range multiIssuerAPIComputedFields {
+			if err := d.Set(k, resp.Data[k]); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.Get(consts.FieldType) == consts.FieldExported {
+		if err := d.Set(consts.FieldPrivateKey, resp.Data[consts.FieldPrivateKey]); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set(consts.FieldPrivateKeyType, resp.Data[consts.FieldPrivateKeyType]); err != nil {
+			return diag.FromErr(err)
+		}
+
+	}
+
+	id := path
+	if provider.IsAPISupported(meta, provider.VaultVersion111) {
+		// multiple CSRs can be generated
+		// ensure unique IDs
+		uniqueSuffix := uuid.New()
+		id = fmt.Sprintf("%s/%s", path, uniqueSuffix)
+	}
+
+	d.SetId(id)
+	return pkiSecretBackendIntermediateCertRequestRead(ctx, d, meta)
+}
 
+// pkiSecretBackendIntermediateCertRequestData returns the data of the request
+// that generates an intermediate CSR of the given type.
+func pkiSecretBackendIntermediateCertRequestData(d pkiCertRequestFields, intermediateType string, isIssuerAPISupported bool) map[string]interface{} {
 	intermediateCertAPIFields := []string{
 		consts.FieldCommonName,
 		consts.FieldFormat,
@@ -263,9 +318,6 @@ func pkiSecretBackendIntermediateCertRequestCreate(ctx context.Context, d *schem
 		consts.FieldOtherSans,
 	}
 
-	// add multi-issuer write API fields if supported
-	isIssuerAPISupported := provider.IsAPISupported(meta, provider.VaultVersion111)
-
 	// Fields only used when we are generating a key
 	if !(intermediateType == keyTypeKMS || intermediateType == consts.FieldExisting) {
 		intermediateCertAPIFields = append(intermediateCertAPIFields, consts.FieldKeyType, consts.FieldKeyBits)
@@ -289,63 +341,21 @@ func pkiSecretBackendIntermediateCertRequestCreate(ctx context.Context, d *schem
 
 	// add boolean fields
 	for _, k := range intermediateCertBooleanAPIFields {
-		data[k] = d.Get(k)
+		if v := d.Get(k); v != nil {
+			data[k] = v
+		}
 	}
 
 	// add comma separated string fields
 	for _, k := range intermediateCertStringArrayFields {
-		m := util.ToStringArray(d.Get(k).([]interface{}))
+		v, _ := d.Get(k).([]interface{})
+		m := util.ToStringArray(v)
 		if len(m) > 0 {
 			data[k] = strings.Join(m, ",")
 		}
 	}
 
-	log.Printf("[DEBUG] Creating intermediate cert request on PKI secret backend %q", backend)
-	resp, err := client.Logical().Write(path, data)
-	if err != nil {
-		return diag.Errorf("error creating intermediate cert request for PKI secret backend %q: %s", backend, err)
-	}
-	log.Printf("[DEBUG] Created intermediate cert request on PKI secret backend %q", backend)
-
-	if err := d.Set(consts.FieldCSR, resp.Data[consts.FieldCSR]); err != nil {
-		return diag.FromErr(err)
-	}
-
-	// multi-issuer API fields that are set to TF state
-	// after a read from Vault
-	multiIssuerAPIComputedFields := []string{
-		consts.FieldKeyID,
-	}
-
-	if isIssuerAPISupported {
-		for _, k := range multiIssuerAPIComputedFields {
-			if err := d.Set(k, resp.Data[k]); err != nil {
-				return diag.FromErr(err)
-			}
-		}
-	}
-
-	if d.Get(consts.FieldType) == consts.FieldExported {
-		if err := d.Set(consts.FieldPrivateKey, resp.Data[consts.FieldPrivateKey]); err != nil {
-			return diag.FromErr(err)
-		}
-
-		if err := d.Set(consts.FieldPrivateKeyType, resp.Data[consts.FieldPrivateKeyType]); err != nil {
-			return diag.FromErr(err)
-		}
-
-	}
-
-	id := path
-	if provider.IsAPISupported(meta, provider.VaultVersion111) {
-		// multiple CSRs can be generated
-		// ensure unique IDs
-		uniqueSuffix := uuid.New()
-		id = fmt.Sprintf("%s/%s", path, uniqueSuffix)
-	}
-
-	d.SetId(id)
-	return pkiSecretBackendIntermediateCertRequestRead(ctx, d, meta)
+	return data
 }
 
 func pkiSecretBackendIntermediateCertRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
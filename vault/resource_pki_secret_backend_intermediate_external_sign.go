@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var pkiExternalSignSources = []string{
+	consts.FieldSigningCommand,
+	consts.FieldSignedCertificateFile,
+}
+
+func pkiSecretBackendIntermediateExternalSignResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: pkiSecretBackendIntermediateExternalSignCreate,
+		ReadContext:   provider.ReadContextWrapper(pkiSecretBackendCertRead),
+		DeleteContext: pkiSecretBackendIntermediateSetSignedDelete,
+		Schema: map[string]*schema.Schema{
+			consts.FieldBackend: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The PKI secret backend the resource belongs to.",
+				ForceNew:    true,
+			},
+			consts.FieldCSR: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The CSR to have signed by the external CA.",
+				ForceNew:    true,
+			},
+			consts.FieldCSRFile: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path of a file the CSR is written to before signing, " +
+					"for pickup by an external signing process.",
+				ForceNew: true,
+			},
+			consts.FieldSigningCommand: {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Command run to sign the CSR. The CSR is passed on stdin, " +
+					"the signed certificate is expected in PEM format on stdout.",
+				ForceNew:     true,
+				ExactlyOneOf: pkiExternalSignSources,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						consts.FieldCommand: {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The command and its arguments.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						consts.FieldEnvironment: {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Additional environment variables to set for the command.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						consts.FieldTimeout: {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      300,
+							Description:  "Maximum number of seconds to wait for the command to complete.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			consts.FieldSignedCertificateFile: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path of a file containing the PEM encoded certificate " +
+					"signed by the external CA.",
+				ForceNew:     true,
+				ExactlyOneOf: pkiExternalSignSources,
+			},
+			consts.FieldIssuerChain: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "PEM encoded chain of the external CA, the signed certificate " +
+					"is verified against it and it is imported along with the certificate.",
+				ForceNew: true,
+			},
+			consts.FieldMaxPathLength: {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  -1,
+				Description: "The expected maximum path length of the signed certificate. " +
+					"The default of -1 skips the check.",
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			consts.FieldCertificate: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The signed certificate.",
+			},
+			consts.FieldSerialNumber: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The serial number of the signed certificate.",
+			},
+			consts.FieldExpiration: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The expiration date of the signed certificate in unix epoch format.",
+			},
+			consts.FieldImportedIssuers: {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The imported issuers.",
+			},
+			consts.FieldImportedKeys: {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The imported keys.",
+			},
+		},
+	}
+}
+
+func pkiSecretBackendIntermediateExternalSignCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	backend := d.Get(consts.FieldBackend).(string)
+	csrPEM := d.Get(consts.FieldCSR).(string)
+
+	csr, err := pki.ParseCertificateRequest(csrPEM)
+	if err != nil {
+		return diag.Errorf("invalid %q: %s", consts.FieldCSR, err)
+	}
+
+	if v, ok := d.GetOk(consts.FieldCSRFile); ok {
+		log.Printf("[DEBUG] Writing CSR for PKI secret backend %q to %q", backend, v)
+		if err := os.WriteFile(v.(string), []byte(csrPEM), 0o644); err != nil {
+			return diag.Errorf("error writing CSR to %q: %s", v, err)
+		}
+	}
+
+	var signed string
+	if v, ok := d.GetOk(consts.FieldSigningCommand); ok {
+		signed, err = pkiExternalSignRunCommand(ctx, v.([]interface{})[0].(map[string]interface{}), csrPEM)
+	} else {
+		signed, err = pkiExternalSignReadFile(d)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	certs, err := pki.ParseCertificates(signed)
+	if err != nil {
+		return diag.Errorf("invalid signed certificate: %s", err)
+	}
+
+	cert := certs[0]
+	chain := certs[1:]
+	if v, ok := d.GetOk(consts.FieldIssuerChain); ok {
+		issuerChain, err := pki.ParseCertificates(v.(string))
+		if err != nil {
+			return diag.Errorf("invalid %q: %s", consts.FieldIssuerChain, err)
+		}
+		chain = append(chain, issuerChain...)
+	}
+
+	if err := validateExternallySignedIntermediate(cert, csr, chain, d.Get(consts.FieldMaxPathLength).(int)); err != nil {
+		return diag.Errorf("signed certificate failed validation: %s", err)
+	}
+
+	bundle := []string{pki.EncodeCertificate(cert)}
+	for _, c := range chain {
+		bundle = append(bundle, pki.EncodeCertificate(c))
+	}
+
+	path := pkiSecretBackendIntermediateSetSignedCreatePath(backend)
+	data := map[string]interface{}{
+		consts.FieldCertificate: strings.Join(bundle, ""),
+	}
+
+	log.Printf("[DEBUG] Importing externally signed intermediate on PKI secret backend %q", backend)
+	resp, err := client.Logical().WriteWithContext(ctx, path, data)
+	if err != nil {
+		return diag.Errorf("error importing externally signed intermediate on PKI secret backend %q: %s", backend, err)
+	}
+	log.Printf("[DEBUG] Imported externally signed intermediate on PKI secret backend %q", backend)
+
+	d.SetId(path)
+
+	if err := d.Set(consts.FieldCertificate, strings.Trim(bundle[0], "\n")); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldSerialNumber, pki.SerialNumber(cert)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldExpiration, cert.NotAfter.Unix()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, k := range []string{consts.FieldImportedIssuers, consts.FieldImportedKeys} {
+		// Vault versions <= 1.10 do not return any response for this endpoint
+		var v interface{}
+		if resp != nil {
+			v = resp.Data[k]
+		}
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return pkiSecretBackendCertRead(ctx, d, meta)
+}
+
+func pkiExternalSignRunCommand(ctx context.Context, config map[string]interface{}, csrPEM string) (string, error) {
+	args := util.ToStringArray(config[consts.FieldCommand].([]interface{}))
+	timeout := time.Duration(config[consts.FieldTimeout].(int)) * time.Second
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = os.Environ()
+	for k, v := range config[consts.FieldEnvironment].(map[string]interface{}) {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(csrPEM)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	log.Printf("[DEBUG] Running signing command %q", args[0])
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("signing command %q timed out after %s", args[0], timeout)
+		}
+		return "", fmt.Errorf("signing command %q failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+func pkiExternalSignReadFile(d *schema.ResourceData) (string, error) {
+	filename := d.Get(consts.FieldSignedCertificateFile).(string)
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			if csrFile, ok := d.GetOk(consts.FieldCSRFile); ok {
+				return "", fmt.Errorf("signed certificate %q not found, submit the CSR in %q "+
+					"to the external CA and re-apply once the certificate has been staged", filename, csrFile)
+			}
+			return "", fmt.Errorf("signed certificate %q not found", filename)
+		}
+		return "", fmt.Errorf("error reading signed certificate %q: %w", filename, err)
+	}
+
+	return string(b), nil
+}
+
+// validateExternallySignedIntermediate ensures that cert was issued for csr, is
+// a CA certificate with the expected path length and, when chain is non-empty,
+// that it chains up to the external CA.
+func validateExternallySignedIntermediate(cert *x509.Certificate, csr *x509.CertificateRequest, chain []*x509.Certificate, maxPathLength int) error {
+	match, err := pki.PublicKeysEqual(cert, csr)
+	if err != nil {
+		return err
+	}
+	if !match {
+		return errors.New("certificate public key does not match the CSR")
+	}
+
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return errors.New("certificate is not a CA certificate")
+	}
+
+	if maxPathLength >= 0 {
+		actual := cert.MaxPathLen
+		if actual == 0 && !cert.MaxPathLenZero {
+			actual = -1
+		}
+		if actual != maxPathLength {
+			return fmt.Errorf("certificate max path length is %d, expected %d", actual, maxPathLength)
+		}
+	}
+
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("certificate expired on %s", cert.NotAfter.Format(time.RFC3339))
+	}
+
+	if len(chain) > 0 {
+		if _, err := pki.VerifyChain(cert, chain); err != nil {
+			return fmt.Errorf("certificate does not chain to the issuer chain: %w", err)
+		}
+	}
+
+	return nil
+}
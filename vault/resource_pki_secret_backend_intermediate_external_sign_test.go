@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestPkiSecretBackendIntermediateExternalSign_command(t *testing.T) {
+	rootPath := acctest.RandomWithPrefix("pki-root")
+	intermediatePath := acctest.RandomWithPrefix("pki-intermediate")
+	csrFile := filepath.Join(t.TempDir(), "intermediate.csr")
+
+	resourceName := "vault_pki_secret_backend_intermediate_external_sign.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testCheckMountDestroyed("vault_mount", consts.MountTypePKI, consts.FieldPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testPkiSecretBackendIntermediateExternalSignConfig_command(rootPath, intermediatePath, csrFile, -1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBackend, intermediatePath),
+					resource.TestCheckResourceAttrPair(resourceName, consts.FieldCertificate,
+						"vault_pki_secret_backend_root_sign_intermediate.test", consts.FieldCertificate),
+					resource.TestCheckResourceAttrPair(resourceName, consts.FieldSerialNumber,
+						"vault_pki_secret_backend_root_sign_intermediate.test", consts.FieldSerialNumber),
+					resource.TestCheckResourceAttrSet(resourceName, consts.FieldExpiration),
+					func(_ *terraform.State) error {
+						b, err := os.ReadFile(csrFile)
+						if err != nil {
+							return err
+						}
+						if !strings.Contains(string(b), "CERTIFICATE REQUEST") {
+							return fmt.Errorf("expected CSR in %q, got %q", csrFile, b)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestPkiSecretBackendIntermediateExternalSign_pathLength(t *testing.T) {
+	rootPath := acctest.RandomWithPrefix("pki-root")
+	intermediatePath := acctest.RandomWithPrefix("pki-intermediate")
+	csrFile := filepath.Join(t.TempDir(), "intermediate.csr")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testCheckMountDestroyed("vault_mount", consts.MountTypePKI, consts.FieldPath),
+		Steps: []resource.TestStep{
+			{
+				Config:      testPkiSecretBackendIntermediateExternalSignConfig_command(rootPath, intermediatePath, csrFile, 2),
+				ExpectError: regexp.MustCompile(`certificate max path length is 0, expected 2`),
+			},
+		},
+	})
+}
+
+func TestPkiSecretBackendIntermediateExternalSign_file(t *testing.T) {
+	intermediatePath := acctest.RandomWithPrefix("pki-intermediate")
+	dir := t.TempDir()
+	csrFile := filepath.Join(dir, "intermediate.csr")
+	certFile := filepath.Join(dir, "intermediate.crt")
+
+	caCert, caKey, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resourceName := "vault_pki_secret_backend_intermediate_external_sign.test"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testCheckMountDestroyed("vault_mount", consts.MountTypePKI, consts.FieldPath),
+		Steps: []resource.TestStep{
+			{
+				Config:      testPkiSecretBackendIntermediateExternalSignConfig_file(intermediatePath, csrFile, certFile, string(caCert)),
+				ExpectError: regexp.MustCompile(`submit the CSR in .* to the external CA`),
+			},
+			{
+				PreConfig: func() {
+					csr, err := os.ReadFile(csrFile)
+					if err != nil {
+						t.Fatal(err)
+					}
+					cert, err := testutil.SignIntermediateCSR(caCert, caKey, csr, 0)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(certFile, cert, 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testPkiSecretBackendIntermediateExternalSignConfig_file(intermediatePath, csrFile, certFile, string(caCert)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldBackend, intermediatePath),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMaxPathLength, "0"),
+					resource.TestCheckResourceAttrSet(resourceName, consts.FieldCertificate),
+					resource.TestCheckResourceAttrSet(resourceName, consts.FieldSerialNumber),
+				),
+			},
+		},
+	})
+}
+
+func testPkiSecretBackendIntermediateExternalSignConfig_command(rootPath, intermediatePath, csrFile string, maxPathLength int) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test-root" {
+  path                      = "%s"
+  type                      = "pki"
+  description               = "test root"
+  default_lease_ttl_seconds = "8640000"
+  max_lease_ttl_seconds     = "8640000"
+}
+
+resource "vault_mount" "test-intermediate" {
+  path                      = "%s"
+  type                      = "pki"
+  description               = "test intermediate"
+  default_lease_ttl_seconds = "86400"
+  max_lease_ttl_seconds     = "86400"
+}
+
+resource "vault_pki_secret_backend_root_cert" "test" {
+  backend     = vault_mount.test-root.path
+  type        = "internal"
+  common_name = "test Root CA"
+  ttl         = "86400"
+  key_type    = "ec"
+  key_bits    = 256
+}
+
+resource "vault_pki_secret_backend_intermediate_cert_request" "test" {
+  backend     = vault_mount.test-intermediate.path
+  type        = "internal"
+  common_name = "test Intermediate CA"
+}
+
+# stands in for the external CA
+resource "vault_pki_secret_backend_root_sign_intermediate" "test" {
+  backend         = vault_mount.test-root.path
+  csr             = vault_pki_secret_backend_intermediate_cert_request.test.csr
+  common_name     = "test Intermediate CA"
+  max_path_length = 0
+}
+
+resource "vault_pki_secret_backend_intermediate_external_sign" "test" {
+  backend         = vault_mount.test-intermediate.path
+  csr             = vault_pki_secret_backend_intermediate_cert_request.test.csr
+  csr_file        = "%s"
+  issuer_chain    = vault_pki_secret_backend_root_cert.test.certificate
+  max_path_length = %d
+
+  signing_command {
+    command = ["sh", "-c", "cat > /dev/null; printf '%%s\n' \"$SIGNED_CERT\""]
+    environment = {
+      SIGNED_CERT = vault_pki_secret_backend_root_sign_intermediate.test.certificate
+    }
+  }
+}
+`, rootPath, intermediatePath, csrFile, maxPathLength)
+}
+
+func testPkiSecretBackendIntermediateExternalSignConfig_file(intermediatePath, csrFile, certFile, issuerChain string) string {
+	return fmt.Sprintf(`
+resource "vault_mount" "test-intermediate" {
+  path                      = "%s"
+  type                      = "pki"
+  description               = "test intermediate"
+  default_lease_ttl_seconds = "86400"
+  max_lease_ttl_seconds     = "86400"
+}
+
+resource "vault_pki_secret_backend_intermediate_cert_request" "test" {
+  backend     = vault_mount.test-intermediate.path
+  type        = "internal"
+  common_name = "test Intermediate CA"
+}
+
+resource "vault_pki_secret_backend_intermediate_external_sign" "test" {
+  backend                 = vault_mount.test-intermediate.path
+  csr                     = vault_pki_secret_backend_intermediate_cert_request.test.csr
+  csr_file                = "%s"
+  signed_certificate_file = "%s"
+  max_path_length         = 0
+  issuer_chain            = <<EOT
+%s
+EOT
+}
+`, intermediatePath, csrFile, certFile, issuerChain)
+}
+
+func TestValidateExternallySignedIntermediate(t *testing.T) {
+	caCert, caKey, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCACert, _, err := testutil.GenerateCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM, _, err := testutil.GenerateCSR("intermediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCSRPEM, _, err := testutil.GenerateCSR("other")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(maxPathLen int) string {
+		b, err := testutil.SignIntermediateCSR(caCert, caKey, csrPEM, maxPathLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	tests := []struct {
+		name          string
+		cert          string
+		csr           []byte
+		chain         []byte
+		maxPathLength int
+		wantErr       string
+	}{
+		{
+			name:          "basic",
+			cert:          sign(1),
+			csr:           csrPEM,
+			chain:         caCert,
+			maxPathLength: 1,
+		},
+		{
+			name:          "skip-path-length",
+			cert:          sign(3),
+			csr:           csrPEM,
+			maxPathLength: -1,
+		},
+		{
+			name:          "unconstrained-path-length",
+			cert:          sign(-1),
+			csr:           csrPEM,
+			maxPathLength: 0,
+			wantErr:       "certificate max path length is -1, expected 0",
+		},
+		{
+			name:          "zero-path-length",
+			cert:          sign(0),
+			csr:           csrPEM,
+			maxPathLength: 0,
+		},
+		{
+			name:          "key-mismatch",
+			cert:          sign(0),
+			csr:           otherCSRPEM,
+			maxPathLength: -1,
+			wantErr:       "certificate public key does not match the CSR",
+		},
+		{
+			name:          "wrong-chain",
+			cert:          sign(0),
+			csr:           csrPEM,
+			chain:         otherCACert,
+			maxPathLength: -1,
+			wantErr:       "certificate does not chain to the issuer chain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := pki.ParseCertificates(tt.cert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			csr, err := pki.ParseCertificateRequest(string(tt.csr))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var chain []*x509.Certificate
+			if tt.chain != nil {
+				chain, err = pki.ParseCertificates(string(tt.chain))
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = validateExternallySignedIntermediate(certs[0], csr, chain, tt.maxPathLength)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateExternallySignedIntermediate() unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateExternallySignedIntermediate() expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
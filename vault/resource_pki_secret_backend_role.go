@@ -6,6 +6,7 @@ package vault
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -83,6 +84,7 @@ func pkiSecretBackendRoleResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: pkiSecretBackendRoleCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			consts.FieldBackend: {
@@ -728,6 +730,21 @@ func pkiSecretBackendRoleDelete(_ context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// pkiSecretBackendRoleCustomizeDiff enforces the provider's pki_role_policy at plan time.
+func pkiSecretBackendRoleCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	policy, err := provider.GetPKIRolePolicy(meta)
+	if err != nil {
+		return err
+	}
+
+	if errs := policy.Validate(diff); len(errs) > 0 {
+		return fmt.Errorf("PKI role %q violates the provider's %s: %w",
+			diff.Get(consts.FieldName), consts.FieldPKIRolePolicy, errors.Join(errs...))
+	}
+
+	return nil
+}
+
 func pkiSecretBackendRolePath(backend string, name string) string {
 	return strings.Trim(backend, "/") + "/roles/" + strings.Trim(name, "/")
 }
@@ -344,6 +344,76 @@ func TestPkiSecretBackendRole_basic(t *testing.T) {
 	})
 }
 
+func TestPkiSecretBackendRole_rolePolicy(t *testing.T) {
+	backend := acctest.RandomWithPrefix("pki")
+	name := acctest.RandomWithPrefix("role")
+	resourceName := "vault_pki_secret_backend_role.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testPkiSecretBackendRoleCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testPkiSecretBackendRoleConfig_rolePolicy(name, backend, `
+  allow_any_name    = true
+  enforce_hostnames = false
+  max_ttl           = "2400h"
+  policy_identifiers = ["1.2.3.5"]`),
+				PlanOnly: true,
+				ExpectError: regexp.MustCompile(`(?s)allow_any_name is forbidden.*` +
+					`enforce_hostnames must be enabled.*` +
+					`max_ttl "2400h" exceeds the maximum of 720h0m0s.*` +
+					`policy identifiers \[1.2.3.4\] are required`),
+			},
+			{
+				Config: testPkiSecretBackendRoleConfig_rolePolicy(name, backend, `
+  enforce_hostnames = true`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`max_ttl must be set to at most 720h0m0s`),
+			},
+			{
+				Config: testPkiSecretBackendRoleConfig_rolePolicy(name, backend, `
+  enforce_hostnames = true
+  max_ttl           = "86400"
+  policy_identifier {
+    oid = "1.2.3.4"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "max_ttl", "86400"),
+				),
+			},
+		},
+	})
+}
+
+func testPkiSecretBackendRoleConfig_rolePolicy(name, path, roleConfig string) string {
+	return fmt.Sprintf(`
+provider "vault" {
+  pki_role_policy {
+    deny_allow_any_name         = true
+    require_enforce_hostnames   = true
+    max_ttl                     = "720h"
+    required_policy_identifiers = ["1.2.3.4"]
+  }
+}
+
+resource "vault_mount" "pki" {
+  path                      = "%s"
+  type                      = "pki"
+  default_lease_ttl_seconds = 3600
+  max_lease_ttl_seconds     = 86400
+}
+
+resource "vault_pki_secret_backend_role" "test" {
+  backend = vault_mount.pki.path
+  name    = "%s"
+%s
+}
+`, path, name, roleConfig)
+}
+
 func testPkiSecretBackendRoleConfig_basic(name, path string, roleTTL, maxTTL int, extraConfig string) string {
 	return fmt.Sprintf(`
 resource "vault_mount" "pki" {
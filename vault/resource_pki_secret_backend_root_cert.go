@@ -349,8 +349,72 @@ func pkiSecretBackendRootCertCreate(_ context.Context, d *schema.ResourceData, m
 	backend := d.Get(consts.FieldBackend).(string)
 	rootType := d.Get(consts.FieldType).(string)
 
-	path := pkiSecretBackendGenerateRootPath(backend, rootType, provider.IsAPISupported(meta, provider.VaultVersion111))
+	// add multi-issuer write API fields if supported
+	isIssuerAPISupported := provider.IsAPISupported(meta, provider.VaultVersion111)
+
+	path := pkiSecretBackendGenerateRootPath(backend, rootType, isIssuerAPISupported)
+	data := pkiSecretBackendRootCertRequestData(d, rootType, isIssuerAPISupported)
+
+	log.Printf("[DEBUG] Creating root cert on PKI secret backend %q", backend)
+	resp, err := client.Logical().Write(path, data)
+	if err != nil {
+		return diag.Errorf("error creating root cert for PKI secret backend %q: %s", backend, err)
+	}
+	log.Printf("[DEBUG] Created root cert on PKI secret backend %q", backend)
+
+	// helpful to consolidate code into single loop
+	// since 'serial' is deprecated, we read the 'serial_number'
+	// field from the response in order to set to the TF state
+	certFieldsMap := map[string]string{
+		consts.FieldCertificate:  consts.FieldCertificate,
+		consts.FieldIssuingCA:    consts.FieldIssuingCA,
+		consts.FieldSerialNumber: consts.FieldSerialNumber,
+		consts.FieldSerial:       consts.FieldSerialNumber,
+	}
+
+	// multi-issuer API fields that are set to TF state
+	// after a read from Vault
+	multiIssuerAPIComputedFields := []string{
+		consts.FieldIssuerID,
+		consts.FieldKeyID,
+	}
+
+	if isIssuerAPISupported {
+		// add multi-issuer read API fields to field map
+		for _, k := range multiIssuerAPIComputedFields {
+			certFieldsMap[k] = k
+		}
+	}
+
+	for k, v := range certFieldsMap {
+		if err := d.Set(k, resp.Data[v]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	id := path
+	if isIssuerAPISupported {
+		// multiple root certs can be issued
+		// ensure ID for each root_cert resource is unique
+		issuerID := resp.Data[consts.FieldIssuerID]
+		id = fmt.Sprintf("%s/issuer/%s", backend, issuerID)
+	}
+
+	d.SetId(id)
+
+	return nil
+}
+
+// pkiCertRequestFields reads the fields of a certificate request, it is
+// implemented by *schema.ResourceData.
+type pkiCertRequestFields interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+}
 
+// pkiSecretBackendRootCertRequestData returns the data of the request that
+// generates a root certificate of the given type.
+func pkiSecretBackendRootCertRequestData(d pkiCertRequestFields, rootType string, isIssuerAPISupported bool) map[string]interface{} {
 	rootCertAPIFields := []string{
 		consts.FieldCommonName,
 		consts.FieldTTL,
@@ -380,9 +444,6 @@ func pkiSecretBackendRootCertCreate(_ context.Context, d *schema.ResourceData, m
 		consts.FieldPermittedDNSDomains,
 	}
 
-	// add multi-issuer write API fields if supported
-	isIssuerAPISupported := provider.IsAPISupported(meta, provider.VaultVersion111)
-
 	// Fields only used when we are generating a key
 	if !(rootType == keyTypeKMS || rootType == consts.FieldExisting) {
 		rootCertAPIFields = append(rootCertAPIFields, consts.FieldKeyType, consts.FieldKeyBits)
@@ -408,65 +469,21 @@ func pkiSecretBackendRootCertCreate(_ context.Context, d *schema.ResourceData, m
 
 	// add boolean fields
 	for _, k := range rootCertBooleanAPIFields {
-		data[k] = d.Get(k)
+		if v := d.Get(k); v != nil {
+			data[k] = v
+		}
 	}
 
 	// add comma separated string fields
 	for _, k := range rootCertStringArrayFields {
-		m := util.ToStringArray(d.Get(k).([]interface{}))
+		v, _ := d.Get(k).([]interface{})
+		m := util.ToStringArray(v)
 		if len(m) > 0 {
 			data[k] = strings.Join(m, ",")
 		}
 	}
 
-	log.Printf("[DEBUG] Creating root cert on PKI secret backend %q", backend)
-	resp, err := client.Logical().Write(path, data)
-	if err != nil {
-		return diag.Errorf("error creating root cert for PKI secret backend %q: %s", backend, err)
-	}
-	log.Printf("[DEBUG] Created root cert on PKI secret backend %q", backend)
-
-	// helpful to consolidate code into single loop
-	// since 'serial' is deprecated, we read the 'serial_number'
-	// field from the response in order to set to the TF state
-	certFieldsMap := map[string]string{
-		consts.FieldCertificate:  consts.FieldCertificate,
-		consts.FieldIssuingCA:    consts.FieldIssuingCA,
-		consts.FieldSerialNumber: consts.FieldSerialNumber,
-		consts.FieldSerial:       consts.FieldSerialNumber,
-	}
-
-	// multi-issuer API fields that are set to TF state
-	// after a read from Vault
-	multiIssuerAPIComputedFields := []string{
-		consts.FieldIssuerID,
-		consts.FieldKeyID,
-	}
-
-	if isIssuerAPISupported {
-		// add multi-issuer read API fields to field map
-		for _, k := range multiIssuerAPIComputedFields {
-			certFieldsMap[k] = k
-		}
-	}
-
-	for k, v := range certFieldsMap {
-		if err := d.Set(k, resp.Data[v]); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	id := path
-	if isIssuerAPISupported {
-		// multiple root certs can be issued
-		// ensure ID for each root_cert resource is unique
-		issuerID := resp.Data[consts.FieldIssuerID]
-		id = fmt.Sprintf("%s/issuer/%s", backend, issuerID)
-	}
-
-	d.SetId(id)
-
-	return nil
+	return data
 }
 
 func getCACertificate(client *api.Client, path string) (*x509.Certificate, error) {
@@ -241,6 +241,47 @@ func pkiSecretBackendRootSignIntermediateCreate(ctx context.Context, d *schema.R
 
 	commonName := d.Get(consts.FieldCommonName).(string)
 
+	data := pkiSecretBackendRootSignIntermediateRequestData(d, provider.IsAPISupported(meta, provider.VaultVersion111))
+
+	log.Printf("[DEBUG] Creating root sign-intermediate on PKI secret backend %q", backend)
+	resp, err := client.Logical().Write(path, data)
+	if err != nil {
+		return diag.Errorf("error creating root sign-intermediate on PKI secret backend %q: %s", backend, err)
+	}
+	log.Printf("[DEBUG] Created root sign-intermediate on PKI secret backend %q", backend)
+
+	// helpful to consolidate code into single loop
+	// since 'serial' is deprecated, we read the 'serial_number'
+	// field from the response in order to set to the TF state
+	certFieldsMap := map[string]string{
+		consts.FieldCertificate:  consts.FieldCertificate,
+		consts.FieldIssuingCA:    consts.FieldIssuingCA,
+		consts.FieldSerialNumber: consts.FieldSerialNumber,
+		consts.FieldSerial:       consts.FieldSerialNumber,
+	}
+
+	for k, v := range certFieldsMap {
+		if err := d.Set(k, resp.Data[v]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setCAChain(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setCertificateBundle(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", backend, commonName))
+
+	return pkiSecretBackendRootSignIntermediateRead(ctx, d, meta)
+}
+
+// pkiSecretBackendRootSignIntermediateRequestData returns the data of the
+// request that signs an intermediate CSR.
+func pkiSecretBackendRootSignIntermediateRequestData(d pkiCertRequestFields, isIssuerAPISupported bool) map[string]interface{} {
 	intermediateSignAPIFields := []string{
 		consts.FieldCSR,
 		consts.FieldCommonName,
@@ -277,7 +318,7 @@ func pkiSecretBackendRootSignIntermediateCreate(ctx context.Context, d *schema.R
 	}
 
 	// add version specific multi-issuer fields
-	if provider.IsAPISupported(meta, provider.VaultVersion111) {
+	if isIssuerAPISupported {
 		if issuerRef, ok := d.GetOk(consts.FieldIssuerRef); ok {
 			data[consts.FieldIssuerRef] = issuerRef
 		}
@@ -285,51 +326,21 @@ func pkiSecretBackendRootSignIntermediateCreate(ctx context.Context, d *schema.R
 
 	// add boolean fields
 	for _, k := range intermediateSignBooleanAPIFields {
-		data[k] = d.Get(k)
+		if v := d.Get(k); v != nil {
+			data[k] = v
+		}
 	}
 
 	// add comma separated string fields
 	for _, k := range intermediateSignStringArrayFields {
-		m := util.ToStringArray(d.Get(k).([]interface{}))
+		v, _ := d.Get(k).([]interface{})
+		m := util.ToStringArray(v)
 		if len(m) > 0 {
 			data[k] = strings.Join(m, ",")
 		}
 	}
 
-	log.Printf("[DEBUG] Creating root sign-intermediate on PKI secret backend %q", backend)
-	resp, err := client.Logical().Write(path, data)
-	if err != nil {
-		return diag.Errorf("error creating root sign-intermediate on PKI secret backend %q: %s", backend, err)
-	}
-	log.Printf("[DEBUG] Created root sign-intermediate on PKI secret backend %q", backend)
-
-	// helpful to consolidate code into single loop
-	// since 'serial' is deprecated, we read the 'serial_number'
-	// field from the response in order to set to the TF state
-	certFieldsMap := map[string]string{
-		consts.FieldCertificate:  consts.FieldCertificate,
-		consts.FieldIssuingCA:    consts.FieldIssuingCA,
-		consts.FieldSerialNumber: consts.FieldSerialNumber,
-		consts.FieldSerial:       consts.FieldSerialNumber,
-	}
-
-	for k, v := range certFieldsMap {
-		if err := d.Set(k, resp.Data[v]); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	if err := setCAChain(d, resp); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := setCertificateBundle(d, resp); err != nil {
-		return diag.FromErr(err)
-	}
-
-	d.SetId(fmt.Sprintf("%s/%s", backend, commonName))
-
-	return pkiSecretBackendRootSignIntermediateRead(ctx, d, meta)
+	return data
 }
 
 func setCAChain(d *schema.ResourceData, resp *api.Secret) error {
@@ -4,20 +4,23 @@
 package vault
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 )
 
 func policyResource() *schema.Resource {
 	return &schema.Resource{
-		Create: policyWrite,
-		Update: policyWrite,
-		Delete: policyDelete,
-		Read:   provider.ReadWrapper(policyRead),
+		Create:        policyWrite,
+		Update:        policyWrite,
+		Delete:        policyDelete,
+		Read:          provider.ReadWrapper(policyRead),
+		CustomizeDiff: policyCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -35,6 +38,8 @@ func policyResource() *schema.Resource {
 				Required:    true,
 				Description: "The policy document",
 			},
+
+			consts.FieldValidateIdentityTemplates: policyValidateIdentityTemplatesSchema(),
 		},
 	}
 }
@@ -95,3 +100,27 @@ func policyRead(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+// policyCustomizeDiff validates the identity templates of the policy paths
+// whenever the policy changes.
+func policyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get(consts.FieldValidateIdentityTemplates).(bool) ||
+		!diff.HasChange("policy") || !diff.NewValueKnown("policy") {
+		return nil
+	}
+
+	paths, err := policyDocumentPaths(diff.Get("policy").(string))
+	if err != nil {
+		return err
+	}
+	if !policyPathsHaveTemplates(paths) {
+		return nil
+	}
+
+	client, err := provider.GetClient(diff, meta)
+	if err != nil {
+		return err
+	}
+
+	return validatePolicyTemplates(ctx, client, paths)
+}
@@ -5,12 +5,14 @@ package vault
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 	"github.com/hashicorp/terraform-provider-vault/testutil"
 )
@@ -33,6 +35,60 @@ func TestResourcePolicy(t *testing.T) {
 	})
 }
 
+func TestResourcePolicy_identityTemplates(t *testing.T) {
+	name := acctest.RandomWithPrefix("test-")
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testResourcePolicy_identityTemplatesConfig(name,
+					"secret/{{identity.entity.aliases.${vault_auth_backend.test.accessor}.name}}/*"),
+				Check: resource.TestCheckResourceAttr("vault_policy.test", consts.FieldValidateIdentityTemplates, "true"),
+			},
+			{
+				// the accessor is known at plan time
+				Config: testResourcePolicy_identityTemplatesConfig(name,
+					"secret/{{identity.entity.aliases.${vault_auth_backend.test.accessor}.name}}/{{identity.entity.id}}"),
+			},
+			{
+				Config: testResourcePolicy_identityTemplatesConfig(name,
+					"secret/{{identity.entity.aliases.auth_userpass_unknown.name}}/*"),
+				ExpectError: regexp.MustCompile(`unknown mount accessor "auth_userpass_unknown"`),
+			},
+			{
+				Config: testResourcePolicy_identityTemplatesConfig(name,
+					"secret/{{identity.entity.nmae}}/*"),
+				ExpectError: regexp.MustCompile(`unsupported entity field "nmae"`),
+			},
+			{
+				// entity metadata keys are not required to be set on any entity
+				Config: testResourcePolicy_identityTemplatesConfig(name,
+					"secret/{{identity.entity.metadata.unknown_key_for_test}}/*"),
+			},
+		},
+	})
+}
+
+func testResourcePolicy_identityTemplatesConfig(name, path string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%[1]s"
+}
+
+resource "vault_policy" "test" {
+  name                        = "%[1]s"
+  validate_identity_templates = true
+  policy                      = <<EOT
+path "%[2]s" {
+  capabilities = ["read"]
+}
+EOT
+}
+`, name, path)
+}
+
 func testResourcePolicy_initialConfig(name string) string {
 	return fmt.Sprintf(`
 resource "vault_policy" "test" {
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+)
+
+var radiusAuthBackendConfigRegex = regexp.MustCompile("^auth/(.+)/config$")
+
+var radiusAuthBackendConfigFields = []string{
+	consts.FieldHost,
+	consts.FieldPort,
+	consts.FieldDialTimeout,
+	consts.FieldReadTimeout,
+	consts.FieldNASPort,
+	consts.FieldNASIdentifier,
+}
+
+func radiusAuthBackendConfigResource() *schema.Resource {
+	fields := map[string]*schema.Schema{
+		consts.FieldMount: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      consts.MountTypeRadius,
+			Description:  "Path where the RADIUS auth method is mounted.",
+			ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+		},
+		consts.FieldHost: {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The RADIUS server to connect to.",
+		},
+		consts.FieldPort: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1812,
+			Description:  "The UDP port of the RADIUS server.",
+			ValidateFunc: validation.IsPortNumber,
+		},
+		consts.FieldSecret: {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+			Description: "The RADIUS shared secret. " +
+				"The secret cannot be read back from Vault.",
+		},
+		consts.FieldUnregisteredUserPolicies: {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Policies granted to the users that are not registered in Vault.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		consts.FieldDialTimeout: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			Description:  "Number of seconds to wait for a backend connection before timing out.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		consts.FieldReadTimeout: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      10,
+			Description:  "Number of seconds to wait for a backend response before timing out.",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		consts.FieldNASPort: {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     10,
+			Description: "The NAS-Port attribute of the RADIUS request.",
+		},
+		consts.FieldNASIdentifier: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The NAS-Identifier attribute of the RADIUS request.",
+		},
+	}
+
+	addTokenFields(fields, &addTokenFieldsConfig{})
+
+	return &schema.Resource{
+		CreateContext: radiusAuthBackendConfigWrite,
+		UpdateContext: radiusAuthBackendConfigWrite,
+		ReadContext:   provider.ReadContextWrapper(radiusAuthBackendConfigRead),
+		DeleteContext: radiusAuthBackendConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: fields,
+	}
+}
+
+func radiusAuthBackendConfigPath(mount string) string {
+	return fmt.Sprintf("auth/%s/config", mount)
+}
+
+func radiusAuthBackendConfigWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := radiusAuthBackendConfigPath(d.Get(consts.FieldMount).(string))
+
+	data := map[string]interface{}{
+		consts.FieldSecret: d.Get(consts.FieldSecret),
+	}
+	for _, k := range radiusAuthBackendConfigFields {
+		data[k] = d.Get(k)
+	}
+
+	// Vault expects a comma separated list of policies
+	var policies []string
+	for _, v := range d.Get(consts.FieldUnregisteredUserPolicies).(*schema.Set).List() {
+		policies = append(policies, v.(string))
+	}
+	data[consts.FieldUnregisteredUserPolicies] = strings.Join(policies, ",")
+
+	updateTokenFields(d, data, d.IsNewResource())
+
+	log.Printf("[DEBUG] Writing RADIUS auth config to %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing RADIUS auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote RADIUS auth config to %q", path)
+
+	d.SetId(path)
+
+	return radiusAuthBackendConfigRead(ctx, d, meta)
+}
+
+func radiusAuthBackendConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := radiusAuthBackendConfigRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for RADIUS auth config, expected auth/<mount>/config", path)
+	}
+
+	log.Printf("[DEBUG] Reading RADIUS auth config from %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading RADIUS auth config %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read RADIUS auth config from %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] RADIUS auth config %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, k := range radiusAuthBackendConfigFields {
+		if v, ok := resp.Data[k]; ok {
+			if err := d.Set(k, v); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if err := d.Set(consts.FieldUnregisteredUserPolicies,
+		radiusUnregisteredUserPolicies(resp.Data[consts.FieldUnregisteredUserPolicies])); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := readTokenFields(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// radiusUnregisteredUserPolicies returns the unregistered user policies from
+// the response, Vault returns a single empty policy when none are configured.
+func radiusUnregisteredUserPolicies(v interface{}) []string {
+	raw, _ := v.([]interface{})
+
+	var policies []string
+	for _, p := range raw {
+		if s, ok := p.(string); ok && s != "" {
+			policies = append(policies, s)
+		}
+	}
+
+	return policies
+}
+
+func radiusAuthBackendConfigDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing RADIUS auth config %q from state, it cannot be deleted from Vault", d.Id())
+	return nil
+}
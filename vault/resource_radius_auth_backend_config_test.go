@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccRadiusAuthBackendConfig(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-radius")
+	resourceName := "vault_radius_auth_backend_config.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRadiusAuthBackendConfigConfig(mount, 1812, `["guest"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/config"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldHost, "radius.example.com"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPort, "1812"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldUnregisteredUserPolicies+".#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, consts.FieldUnregisteredUserPolicies+".*", "guest"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldDialTimeout, "5"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldReadTimeout, "10"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldNASPort, "10"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldNASIdentifier, "vault"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldTTL, "3600"),
+				),
+			},
+			{
+				Config: testAccRadiusAuthBackendConfigConfig(mount, 1645, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPort, "1645"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldUnregisteredUserPolicies+".#", "0"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil, consts.FieldSecret),
+		},
+	})
+}
+
+func testAccRadiusAuthBackendConfigConfig(mount string, port int, policies string) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "radius"
+  path = "%s"
+}
+
+resource "vault_radius_auth_backend_config" "test" {
+  mount                      = vault_auth_backend.test.path
+  host                       = "radius.example.com"
+  port                       = %d
+  secret                     = "s3cr3t"
+  unregistered_user_policies = %s
+  dial_timeout               = 5
+  nas_identifier             = "vault"
+  token_ttl                  = 3600
+}
+`, mount, port, policies)
+}
+
+func TestRadiusUnregisteredUserPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:  "empty-policy",
+			value: []interface{}{""},
+		},
+		{
+			name:     "policies",
+			value:    []interface{}{"guest", "", "dev"},
+			expected: []string{"guest", "dev"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := radiusUnregisteredUserPolicies(tt.value); !reflect.DeepEqual(tt.expected, actual) {
+				t.Errorf("expected %#v, got %#v", tt.expected, actual)
+			}
+		})
+	}
+}
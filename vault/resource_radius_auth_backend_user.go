@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var radiusAuthBackendUserRegex = regexp.MustCompile("^auth/(.+)/users/([^/]+)$")
+
+func radiusAuthBackendUserResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: radiusAuthBackendUserWrite,
+		UpdateContext: radiusAuthBackendUserWrite,
+		ReadContext:   provider.ReadContextWrapper(radiusAuthBackendUserRead),
+		DeleteContext: radiusAuthBackendUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			consts.FieldMount: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consts.MountTypeRadius,
+				Description:  "Path where the RADIUS auth method is mounted.",
+				ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+			},
+			consts.FieldUsername: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the RADIUS user.",
+			},
+			consts.FieldPolicies: {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Policies granted to the user, in addition to the unregistered user policies.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func radiusAuthBackendUserPath(mount, username string) string {
+	return fmt.Sprintf("auth/%s/users/%s", mount, username)
+}
+
+func radiusAuthBackendUserWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := radiusAuthBackendUserPath(d.Get(consts.FieldMount).(string), d.Get(consts.FieldUsername).(string))
+
+	data := map[string]interface{}{
+		consts.FieldPolicies: d.Get(consts.FieldPolicies).(*schema.Set).List(),
+	}
+
+	log.Printf("[DEBUG] Writing RADIUS user %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing RADIUS user %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote RADIUS user %q", path)
+
+	d.SetId(path)
+
+	return radiusAuthBackendUserRead(ctx, d, meta)
+}
+
+func radiusAuthBackendUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	m := radiusAuthBackendUserRegex.FindStringSubmatch(path)
+	if m == nil {
+		return diag.Errorf("invalid ID %q for RADIUS user, expected auth/<mount>/users/<username>", path)
+	}
+
+	log.Printf("[DEBUG] Reading RADIUS user %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading RADIUS user %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read RADIUS user %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] RADIUS user %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, m[1]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldUsername, m[2]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldPolicies, resp.Data[consts.FieldPolicies]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func radiusAuthBackendUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting RADIUS user %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting RADIUS user %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted RADIUS user %q", path)
+
+	return nil
+}
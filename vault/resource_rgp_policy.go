@@ -6,6 +6,7 @@ package vault
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
 )
 
@@ -13,10 +14,11 @@ var rgpPolicyAttributes = []string{"enforcement_level", "policy"}
 
 func rgpPolicyResource() *schema.Resource {
 	return &schema.Resource{
-		Create: rgpPolicyWrite,
-		Update: rgpPolicyWrite,
-		Delete: rgpPolicyDelete,
-		Read:   provider.ReadWrapper(rgpPolicyRead),
+		Create:        rgpPolicyWrite,
+		Update:        rgpPolicyWrite,
+		Delete:        rgpPolicyDelete,
+		Read:          provider.ReadWrapper(rgpPolicyRead),
+		CustomizeDiff: sentinelPolicyCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -41,6 +43,8 @@ func rgpPolicyResource() *schema.Resource {
 				Required:    true,
 				Description: "The policy document",
 			},
+
+			consts.FieldTest: sentinelPolicyTestSchema(),
 		},
 	}
 }
@@ -5,7 +5,9 @@ package vault
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -26,6 +28,12 @@ const (
 	fieldACSURLS        = "acs_urls"
 	fieldDefaultRole    = "default_role"
 	fieldVerboseLogging = "verbose_logging"
+
+	fieldIDPMetadataRefresh         = "idp_metadata_refresh"
+	fieldIDPSigningCertFingerprints = "idp_signing_cert_fingerprints"
+	fieldSPEntityID                 = "sp_entity_id"
+	fieldSPACSURLs                  = "sp_acs_urls"
+	fieldSPMetadata                 = "sp_metadata"
 )
 
 var (
@@ -50,7 +58,7 @@ func samlAuthBackendResource() *schema.Resource {
 		ReadContext:   provider.ReadContextWrapper(samlAuthBackendRead),
 		UpdateContext: samlAuthBackendUpdate,
 		DeleteContext: samlAuthBackendDelete,
-		CustomizeDiff: getMountCustomizeDiffFunc(consts.FieldPath),
+		CustomizeDiff: samlAuthBackendCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -117,8 +125,108 @@ func samlAuthBackendResource() *schema.Resource {
 					"during the SAML exchange according to the current logging level. Not " +
 					"recommended for production.",
 			},
+			fieldIDPMetadataRefresh: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Fetch the metadata of the identity provider during plan, " +
+					"and show a diff when its signing certificates change.",
+			},
+			fieldIDPSigningCertFingerprints: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The SHA-256 fingerprints of the signing certificates in the " +
+					"identity provider metadata, when 'idp_metadata_refresh' is enabled.",
+			},
+			fieldSPEntityID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The entity ID of the service provider, as configured in Vault.",
+			},
+			fieldSPACSURLs: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The ACS URLs of the service provider, as configured in Vault.",
+			},
+			fieldSPMetadata: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The generated SAML metadata XML of the service provider.",
+			},
+			"tune": authMountTuneSchema(),
 		},
-	}, true)
+	}, false)
+}
+
+func samlAuthBackendCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.HasChanges(fieldEntityID, fieldACSURLS) {
+		for _, k := range []string{fieldSPEntityID, fieldSPACSURLs, fieldSPMetadata} {
+			if err := diff.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := samlAuthBackendDiffIDPMetadata(ctx, diff); err != nil {
+		return err
+	}
+
+	// check whether mount migration is required
+	f := getMountCustomizeDiffFunc(consts.FieldPath)
+	return f(ctx, diff, meta)
+}
+
+// samlAuthBackendDiffIDPMetadata fetches the identity provider metadata when
+// idp_metadata_refresh is enabled, and plans its signing certificate
+// fingerprints.
+func samlAuthBackendDiffIDPMetadata(ctx context.Context, diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown(fieldIDPMetadataRefresh) {
+		return diff.SetNewComputed(fieldIDPSigningCertFingerprints)
+	}
+
+	refresh := diff.Get(fieldIDPMetadataRefresh).(bool)
+	if refresh && !diff.NewValueKnown(fieldIDPMetadataURL) {
+		return diff.SetNewComputed(fieldIDPSigningCertFingerprints)
+	}
+
+	var fingerprints []string
+	if metadataURL := diff.Get(fieldIDPMetadataURL).(string); refresh && metadataURL != "" {
+		var err error
+		fingerprints, err = samlIDPSigningCertFingerprints(ctx, metadataURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	var current []string
+	for _, v := range diff.Get(fieldIDPSigningCertFingerprints).([]interface{}) {
+		current = append(current, v.(string))
+	}
+	if len(current) == 0 && len(fingerprints) == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(current, fingerprints) {
+		return nil
+	}
+
+	return diff.SetNew(fieldIDPSigningCertFingerprints, fingerprints)
+}
+
+// samlAuthBackendPlannedFingerprintsKnown returns whether the signing
+// certificate fingerprints were known in the plan of d.
+func samlAuthBackendPlannedFingerprintsKnown(d *schema.ResourceData) bool {
+	plan := d.GetRawPlan()
+	if plan.IsNull() || !plan.IsKnown() {
+		return false
+	}
+
+	return plan.GetAttr(fieldIDPSigningCertFingerprints).IsKnown()
 }
 
 func samlAuthBackendWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -175,6 +283,23 @@ func samlAuthBackendUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	for _, k := range samlBooleanAPIFields {
 		data[k] = d.Get(k)
 	}
+
+	// use the fingerprints planned by samlAuthBackendDiffIDPMetadata, so that
+	// the state matches the plan even if the IdP metadata changed since. The
+	// metadata is only fetched if the fingerprints were unknown at plan time.
+	var fingerprints []string
+	for _, v := range d.Get(fieldIDPSigningCertFingerprints).([]interface{}) {
+		fingerprints = append(fingerprints, v.(string))
+	}
+	if metadataURL, ok := d.GetOk(fieldIDPMetadataURL); ok && d.Get(fieldIDPMetadataRefresh).(bool) &&
+		!samlAuthBackendPlannedFingerprintsKnown(d) {
+		var err error
+		fingerprints, err = samlIDPSigningCertFingerprints(ctx, metadataURL.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("[DEBUG] Writing saml auth backend config to %q", configPath)
 	_, err := client.Logical().Write(configPath, data)
 	if err != nil {
@@ -182,6 +307,14 @@ func samlAuthBackendUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	log.Printf("[DEBUG] Wrote saml auth backend config to %q", configPath)
 
+	if err := d.Set(fieldIDPSigningCertFingerprints, fingerprints); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := authMountTuneUpdate(d, client, "auth/"+path); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// set ID to where engine is mounted
 	d.SetId(path)
 
@@ -221,6 +354,45 @@ func samlAuthBackendRead(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 	}
 
+	if err := samlAuthBackendReadSPMetadata(d, resp.Data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := authMountTuneRead(d, client, "auth/"+id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// samlAuthBackendReadSPMetadata sets the service provider outputs from the
+// config read from Vault.
+func samlAuthBackendReadSPMetadata(d *schema.ResourceData, data map[string]interface{}) error {
+	entityID, _ := data[fieldEntityID].(string)
+
+	var acsURLs []string
+	if v, ok := data[fieldACSURLS].([]interface{}); ok {
+		for _, u := range v {
+			acsURLs = append(acsURLs, u.(string))
+		}
+	}
+
+	metadata, err := samlSPMetadata(entityID, acsURLs)
+	if err != nil {
+		return fmt.Errorf("error generating the SAML SP metadata: %w", err)
+	}
+
+	fields := map[string]interface{}{
+		fieldSPEntityID: entityID,
+		fieldSPACSURLs:  acsURLs,
+		fieldSPMetadata: metadata,
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting state key %q: err=%s", k, err)
+		}
+	}
+
 	return nil
 }
 
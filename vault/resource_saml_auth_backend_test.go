@@ -5,6 +5,7 @@ package vault
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -43,6 +44,14 @@ func TestAccSAMLAuthBackend_basic(t *testing.T) {
 						"acs_urls.0", "https://my.vault.primary/v1/auth/saml/callback"),
 					resource.TestCheckResourceAttr(resourceName,
 						fieldDefaultRole, "admin"),
+					resource.TestCheckResourceAttr(resourceName,
+						fieldSPEntityID, "https://my.vault/v1/auth/saml"),
+					resource.TestCheckResourceAttr(resourceName,
+						"sp_acs_urls.#", "1"),
+					resource.TestMatchResourceAttr(resourceName,
+						fieldSPMetadata, regexp.MustCompile(`Location="https://my.vault.primary/v1/auth/saml/callback" index="0" isDefault="true"`)),
+					resource.TestCheckResourceAttr(resourceName,
+						"idp_signing_cert_fingerprints.#", "0"),
 				),
 			},
 			{
@@ -62,6 +71,12 @@ func TestAccSAMLAuthBackend_basic(t *testing.T) {
 						"acs_urls.1", "https://my.vault.secondary/v1/auth/saml/callback"),
 					resource.TestCheckResourceAttr(resourceName,
 						fieldDefaultRole, "project-aqua-developers"),
+					resource.TestCheckResourceAttr(resourceName,
+						"sp_acs_urls.#", "2"),
+					resource.TestCheckResourceAttr(resourceName,
+						"sp_acs_urls.1", "https://my.vault.secondary/v1/auth/saml/callback"),
+					resource.TestMatchResourceAttr(resourceName,
+						fieldSPMetadata, regexp.MustCompile(`Location="https://my.vault.secondary/v1/auth/saml/callback" index="1"`)),
 				),
 			},
 			testutil.GetImportTestStep(resourceName, false, nil, consts.FieldDisableRemount),
@@ -94,3 +109,50 @@ resource "vault_saml_auth_backend" "test" {
 `, path)
 	return ret
 }
+
+func TestAccSAMLAuthBackend_tune(t *testing.T) {
+	path := acctest.RandomWithPrefix("saml")
+	resourceType := "vault_saml_auth_backend"
+	resourceName := resourceType + ".test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testutil.TestEntPreCheck(t)
+			SkipIfAPIVersionLT(t, testProvider.Meta(), provider.VaultVersion115)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckMountDestroyed(resourceType, consts.MountTypeSAML, consts.FieldPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSAMLAuthBackendConfig_tune(path, "10m", "hidden", "batch", `"key1", "key2"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "10m", "hidden", "batch", 2),
+			},
+			{
+				Config: testAccSAMLAuthBackendConfig_tune(path, "1h", "unauth", "default-service", `"key1"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "1h", "unauth", "default-service", 1),
+			},
+			testAccAuthMountTuneDriftStep(t, path, testAccSAMLAuthBackendConfig_tune(path, "1h", "unauth", "default-service", `"key1"`)),
+			{
+				Config: testAccSAMLAuthBackendConfig_tune(path, "1h", "unauth", "default-service", `"key1"`),
+				Check:  testAccCheckAuthMountTune(resourceName, "1h", "unauth", "default-service", 1),
+			},
+		},
+	})
+}
+
+func testAccSAMLAuthBackendConfig_tune(path, ttl, visibility, tokenType, keys string) string {
+	return fmt.Sprintf(`
+resource "vault_saml_auth_backend" "test" {
+  path             = "%s"
+  idp_metadata_url = "https://company.okta.com/app/abc123eb9xnIfzlaf697/sso/saml/metadata"
+  entity_id        = "https://my.vault/v1/auth/saml"
+  acs_urls         = ["https://my.vault.primary/v1/auth/saml/callback"]
+  tune {
+    default_lease_ttl            = "%s"
+    listing_visibility           = "%s"
+    token_type                   = "%s"
+    audit_non_hmac_request_keys  = [%s]
+  }
+}
+`, path, ttl, visibility, tokenType, keys)
+}
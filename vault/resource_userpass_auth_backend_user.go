@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/vault/api"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/util"
+)
+
+var userpassAuthBackendUserRegex = regexp.MustCompile("^auth/(.+)/users/([^/]+)$")
+
+func userpassAuthBackendUserResource() *schema.Resource {
+	fields := map[string]*schema.Schema{
+		consts.FieldMount: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      consts.MountTypeUserpass,
+			Description:  "Path where the userpass auth method is mounted.",
+			ValidateFunc: provider.ValidateNoLeadingTrailingSlashes,
+		},
+		consts.FieldUsername: {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the user.",
+		},
+		consts.FieldPassword: {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			Description: "Password of the user. " +
+				"Only the SHA-256 fingerprint of the password is stored in the state.",
+			StateFunc:    userpassPasswordFingerprint,
+			ExactlyOneOf: []string{consts.FieldPassword, consts.FieldPasswordPolicy},
+		},
+		consts.FieldPasswordPolicy: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "Name of the password policy used to generate the password of the user. " +
+				"The generated password is exported in generated_password.",
+			ExactlyOneOf: []string{consts.FieldPassword, consts.FieldPasswordPolicy},
+		},
+		consts.FieldRotationPeriod: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Description: "Number of seconds after which a new password is generated from the password policy. " +
+				"The rotation happens on the next apply once the period has elapsed.",
+			ValidateFunc: validation.IntAtLeast(1),
+			RequiredWith: []string{consts.FieldPasswordPolicy},
+		},
+		consts.FieldGeneratedPassword: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Password generated from the password policy.",
+		},
+		consts.FieldPasswordLastRotated: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time of the last generation of the password, in RFC3339 format.",
+		},
+	}
+
+	addTokenFields(fields, &addTokenFieldsConfig{})
+
+	return &schema.Resource{
+		CreateContext: userpassAuthBackendUserWrite,
+		UpdateContext: userpassAuthBackendUserWrite,
+		ReadContext:   provider.ReadContextWrapper(userpassAuthBackendUserRead),
+		DeleteContext: userpassAuthBackendUserDelete,
+		CustomizeDiff: userpassAuthBackendUserCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: fields,
+	}
+}
+
+// userpassPasswordFingerprint is the StateFunc of the password, so that it is
+// never stored in the state in clear text.
+func userpassPasswordFingerprint(v interface{}) string {
+	password, ok := v.(string)
+	if !ok || password == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+func userpassAuthBackendUserPath(mount, username string) string {
+	return fmt.Sprintf("auth/%s/users/%s", mount, username)
+}
+
+// userpassPasswordRotationDue returns true if the password generated at
+// lastRotated must be rotated at now.
+func userpassPasswordRotationDue(lastRotated string, period int, now time.Time) (bool, error) {
+	if period <= 0 {
+		return false, nil
+	}
+
+	if lastRotated == "" {
+		return true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, lastRotated)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: %w", consts.FieldPasswordLastRotated, lastRotated, err)
+	}
+
+	return !now.Before(t.Add(time.Duration(period) * time.Second)), nil
+}
+
+// userpassAuthBackendUserCustomizeDiff plans a new generated password when the
+// password policy changes or the rotation period has elapsed.
+func userpassAuthBackendUserCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if diff.Get(consts.FieldPasswordPolicy).(string) == "" {
+		return nil
+	}
+
+	rotate := diff.HasChange(consts.FieldPasswordPolicy)
+	if !rotate {
+		var err error
+		rotate, err = userpassPasswordRotationDue(
+			diff.Get(consts.FieldPasswordLastRotated).(string),
+			diff.Get(consts.FieldRotationPeriod).(int),
+			time.Now(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Password of userpass user %q must be rotated", diff.Id())
+	if err := diff.SetNewComputed(consts.FieldGeneratedPassword); err != nil {
+		return err
+	}
+
+	return diff.SetNewComputed(consts.FieldPasswordLastRotated)
+}
+
+func userpassAuthBackendUserWrite(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	create := d.IsNewResource()
+	path := userpassAuthBackendUserPath(d.Get(consts.FieldMount).(string), d.Get(consts.FieldUsername).(string))
+
+	data := map[string]interface{}{}
+	updateTokenFields(d, data, create)
+
+	policy := d.Get(consts.FieldPasswordPolicy).(string)
+	switch {
+	case policy != "":
+		if create || d.HasChanges(consts.FieldPasswordPolicy, consts.FieldGeneratedPassword) {
+			password, err := generatePassword(ctx, client, policy)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			data[consts.FieldPassword] = password
+			if err := d.Set(consts.FieldGeneratedPassword, password); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := d.Set(consts.FieldPasswordLastRotated, time.Now().UTC().Format(time.RFC3339)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	case create || d.HasChange(consts.FieldPassword):
+		data[consts.FieldPassword] = d.Get(consts.FieldPassword).(string)
+		for _, k := range []string{consts.FieldGeneratedPassword, consts.FieldPasswordLastRotated} {
+			if err := d.Set(k, ""); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	log.Printf("[DEBUG] Writing userpass user %q", path)
+	if _, err := client.Logical().WriteWithContext(ctx, path, data); err != nil {
+		return diag.Errorf("error writing userpass user %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Wrote userpass user %q", path)
+
+	d.SetId(path)
+
+	return userpassAuthBackendUserRead(ctx, d, meta)
+}
+
+// generatePassword generates a password from the password policy.
+func generatePassword(ctx context.Context, client *api.Client, policy string) (string, error) {
+	path := fmt.Sprintf("sys/policies/password/%s/generate", policy)
+
+	log.Printf("[DEBUG] Generating password from policy %q", policy)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("error generating password from policy %q: %w", policy, err)
+	}
+	if resp == nil {
+		return "", fmt.Errorf("password policy %q not found", policy)
+	}
+
+	password, ok := resp.Data[consts.FieldPassword].(string)
+	if !ok || password == "" {
+		return "", fmt.Errorf("no password generated from policy %q", policy)
+	}
+
+	return password, nil
+}
+
+func userpassAuthBackendUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+	mount, username, err := parseUserpassAuthBackendUserPath(path)
+	if err != nil {
+		return diag.Errorf("invalid ID %q for userpass user: %s", path, err)
+	}
+
+	log.Printf("[DEBUG] Reading userpass user %q", path)
+	resp, err := client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return diag.Errorf("error reading userpass user %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Read userpass user %q", path)
+
+	if resp == nil {
+		log.Printf("[WARN] Userpass user %q not found, removing from state", path)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set(consts.FieldMount, mount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(consts.FieldUsername, username); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := readTokenFields(d, resp); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func userpassAuthBackendUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, e := provider.GetClient(d, meta)
+	if e != nil {
+		return diag.FromErr(e)
+	}
+
+	path := d.Id()
+
+	log.Printf("[DEBUG] Deleting userpass user %q", path)
+	if _, err := client.Logical().DeleteWithContext(ctx, path); err != nil && !util.Is404(err) {
+		return diag.Errorf("error deleting userpass user %q: %s", path, err)
+	}
+	log.Printf("[DEBUG] Deleted userpass user %q", path)
+
+	return nil
+}
+
+func parseUserpassAuthBackendUserPath(path string) (string, string, error) {
+	m := userpassAuthBackendUserRegex.FindStringSubmatch(path)
+	if m == nil {
+		return "", "", fmt.Errorf("expected auth/<mount>/users/<username>")
+	}
+
+	return m[1], m[2], nil
+}
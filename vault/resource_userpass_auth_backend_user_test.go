@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
+	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/testutil"
+)
+
+func TestAccUserpassAuthBackendUser(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-userpass")
+	resourceName := "vault_userpass_auth_backend_user.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccUserpassAuthBackendUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserpassAuthBackendUserConfig(mount, "s3cr3t-passw0rd", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "auth/"+mount+"/users/admin"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldMount, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldUsername, "admin"),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPassword,
+						userpassPasswordFingerprint("s3cr3t-passw0rd")),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldGeneratedPassword, ""),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldTTL, "3600"),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldPolicies+".#", "1"),
+					testAccUserpassAuthBackendUserLogin(resourceName, "s3cr3t-passw0rd"),
+				),
+			},
+			{
+				Config: testAccUserpassAuthBackendUserConfig(mount, "an0ther-passw0rd", 7200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPassword,
+						userpassPasswordFingerprint("an0ther-passw0rd")),
+					resource.TestCheckResourceAttr(resourceName, TokenFieldTTL, "7200"),
+					testAccUserpassAuthBackendUserLogin(resourceName, "an0ther-passw0rd"),
+				),
+			},
+			testutil.GetImportTestStep(resourceName, false, nil, consts.FieldPassword),
+		},
+	})
+}
+
+func TestAccUserpassAuthBackendUser_passwordPolicy(t *testing.T) {
+	mount := acctest.RandomWithPrefix("tf-test-userpass")
+	resourceName := "vault_userpass_auth_backend_user.test"
+
+	var generated string
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: providerFactories,
+		PreCheck:          func() { testutil.TestAccPreCheck(t) },
+		CheckDestroy:      testAccUserpassAuthBackendUserCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserpassAuthBackendUserPolicyConfig(mount, 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPasswordPolicy, mount),
+					resource.TestCheckResourceAttr(resourceName, consts.FieldPassword, ""),
+					resource.TestCheckResourceAttrSet(resourceName, consts.FieldPasswordLastRotated),
+					resource.TestCheckResourceAttrWith(resourceName, consts.FieldGeneratedPassword, func(value string) error {
+						if len(value) != 24 {
+							return fmt.Errorf("expected a password of 24 characters, got %d", len(value))
+						}
+						generated = value
+						return nil
+					}),
+					testAccUserpassAuthBackendUserGeneratedLogin(resourceName),
+				),
+			},
+			{
+				// the password is not rotated before the period has elapsed
+				Config:   testAccUserpassAuthBackendUserPolicyConfig(mount, 3600),
+				PlanOnly: true,
+			},
+			{
+				// the period is long enough for the plan that follows the apply
+				// to be empty
+				PreConfig: func() {
+					time.Sleep(11 * time.Second)
+				},
+				Config: testAccUserpassAuthBackendUserPolicyConfig(mount, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith(resourceName, consts.FieldGeneratedPassword, func(value string) error {
+						if value == generated {
+							return fmt.Errorf("expected the password to be rotated")
+						}
+						return nil
+					}),
+					testAccUserpassAuthBackendUserGeneratedLogin(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserpassAuthBackendUserCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vault_userpass_auth_backend_user" {
+			continue
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Logical().Read(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if resp != nil {
+			return fmt.Errorf("userpass user %q still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccUserpassAuthBackendUserGeneratedLogin(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testutil.GetResourceFromRootModule(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return testAccUserpassAuthBackendUserLogin(resourceName, rs.Primary.Attributes[consts.FieldGeneratedPassword])(s)
+	}
+}
+
+func testAccUserpassAuthBackendUserLogin(resourceName, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testutil.GetResourceFromRootModule(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		client, err := provider.GetClient(rs.Primary, testProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		path := fmt.Sprintf("auth/%s/login/%s", rs.Primary.Attributes[consts.FieldMount],
+			rs.Primary.Attributes[consts.FieldUsername])
+		resp, err := client.Logical().Write(path, map[string]interface{}{
+			consts.FieldPassword: password,
+		})
+		if err != nil {
+			return fmt.Errorf("error logging in: %w", err)
+		}
+		if resp == nil || resp.Auth == nil {
+			return fmt.Errorf("expected a token from the login")
+		}
+
+		return nil
+	}
+}
+
+func testAccUserpassAuthBackendUserConfig(mount, password string, ttl int) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%s"
+}
+
+resource "vault_userpass_auth_backend_user" "test" {
+  mount          = vault_auth_backend.test.path
+  username       = "admin"
+  password       = "%s"
+  token_policies = ["admin"]
+  token_ttl      = %d
+}
+`, mount, password, ttl)
+}
+
+func testAccUserpassAuthBackendUserPolicyConfig(mount string, rotationPeriod int) string {
+	return fmt.Sprintf(`
+resource "vault_auth_backend" "test" {
+  type = "userpass"
+  path = "%[1]s"
+}
+
+resource "vault_password_policy" "test" {
+  name   = "%[1]s"
+  policy = <<EOT
+length = 24
+rule "charset" {
+  charset   = "abcdefghijklmnopqrstuvwxyz0123456789"
+  min-chars = 1
+}
+EOT
+}
+
+resource "vault_userpass_auth_backend_user" "test" {
+  mount           = vault_auth_backend.test.path
+  username        = "break-glass"
+  password_policy = vault_password_policy.test.name
+  rotation_period = %[2]d
+}
+`, mount, rotationPeriod)
+}
+
+func TestUserpassPasswordRotationDue(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		lastRotated string
+		period      int
+		expected    bool
+		expectErr   bool
+	}{
+		{
+			name:        "no-period",
+			lastRotated: "2020-01-01T00:00:00Z",
+			period:      0,
+			expected:    false,
+		},
+		{
+			name:     "never-rotated",
+			period:   3600,
+			expected: true,
+		},
+		{
+			name:        "not-due",
+			lastRotated: "2024-01-02T11:00:01Z",
+			period:      3600,
+			expected:    false,
+		},
+		{
+			name:        "due",
+			lastRotated: "2024-01-02T11:00:00Z",
+			period:      3600,
+			expected:    true,
+		},
+		{
+			name:        "invalid",
+			lastRotated: "yesterday",
+			period:      3600,
+			expectErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := userpassPasswordRotationDue(tt.lastRotated, tt.period, now)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %t, got %v", tt.expectErr, err)
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUserpassPasswordFingerprint(t *testing.T) {
+	// echo -n password | sha256sum
+	expected := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+	if actual := userpassPasswordFingerprint("password"); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	if actual := userpassPasswordFingerprint(""); actual != "" {
+		t.Errorf("expected an empty fingerprint, got %q", actual)
+	}
+}
+
+func TestParseUserpassAuthBackendUserPath(t *testing.T) {
+	mount, username, err := parseUserpassAuthBackendUserPath("auth/team/userpass/users/admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mount != "team/userpass" || username != "admin" {
+		t.Errorf("unexpected mount %q and username %q", mount, username)
+	}
+
+	if _, _, err := parseUserpassAuthBackendUserPath("auth/userpass/roles/admin"); err == nil {
+		t.Error("expected an error for an invalid path")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+)
+
+const (
+	samlMetadataNamespace = "urn:oasis:names:tc:SAML:2.0:metadata"
+	samlProtocol          = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlBindingHTTPPost   = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+)
+
+// samlEntityDescriptor is the subset of a SAML metadata document needed to
+// find the signing certificates of an identity provider. The root element may
+// be an EntitiesDescriptor with nested EntityDescriptors.
+type samlEntityDescriptor struct {
+	XMLName           xml.Name
+	EntityID          string                 `xml:"entityID,attr"`
+	Entities          []samlEntityDescriptor `xml:"EntityDescriptor"`
+	IDPSSODescriptors []struct {
+		KeyDescriptors []struct {
+			Use          string   `xml:"use,attr"`
+			Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+type samlSPMetadataDescriptor struct {
+	XMLName         xml.Name            `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string              `xml:"entityID,attr"`
+	SPSSODescriptor samlSPSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type samlSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                           `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                           `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                         `xml:"protocolSupportEnumeration,attr"`
+	AssertionConsumerServices  []samlAssertionConsumerService `xml:"AssertionConsumerService"`
+}
+
+type samlAssertionConsumerService struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr,omitempty"`
+}
+
+// samlSPMetadata generates the metadata of the Vault service provider with
+// entityID and acsURLs, for registering Vault with the identity provider.
+func samlSPMetadata(entityID string, acsURLs []string) (string, error) {
+	m := samlSPMetadataDescriptor{
+		EntityID: entityID,
+		SPSSODescriptor: samlSPSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: samlProtocol,
+		},
+	}
+	for i, u := range acsURLs {
+		m.SPSSODescriptor.AssertionConsumerServices = append(m.SPSSODescriptor.AssertionConsumerServices,
+			samlAssertionConsumerService{
+				Binding:   samlBindingHTTPPost,
+				Location:  u,
+				Index:     i,
+				IsDefault: i == 0,
+			})
+	}
+
+	b, err := xml.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(b) + "\n", nil
+}
+
+// samlIDPSigningCertFingerprints fetches the identity provider metadata at
+// metadataURL and returns the sorted SHA-256 fingerprints of its signing
+// certificates.
+func samlIDPSigningCertFingerprints(ctx context.Context, metadataURL string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Fetching SAML IdP metadata %q", metadataURL)
+	resp, err := cleanhttp.DefaultClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching SAML IdP metadata %q: %w", metadataURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching SAML IdP metadata %q: unexpected status %d", metadataURL, resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching SAML IdP metadata %q: %w", metadataURL, err)
+	}
+
+	certs, err := parseSAMLIDPSigningCerts(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAML IdP metadata %q: %w", metadataURL, err)
+	}
+
+	var fingerprints []string
+	for _, cert := range certs {
+		_, fingerprint := pki.Fingerprints(cert)
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	sort.Strings(fingerprints)
+
+	return fingerprints, nil
+}
+
+// parseSAMLIDPSigningCerts returns the signing certificates of the identity
+// providers in the SAML metadata document b. Key descriptors without a use
+// are used for both signing and encryption.
+func parseSAMLIDPSigningCerts(b []byte) ([]*x509.Certificate, error) {
+	var root samlEntityDescriptor
+	if err := xml.Unmarshal(b, &root); err != nil {
+		return nil, err
+	}
+	if root.XMLName.Space != samlMetadataNamespace {
+		return nil, fmt.Errorf("unexpected root element %q", root.XMLName.Local)
+	}
+
+	entities := []samlEntityDescriptor{root}
+	if root.XMLName.Local == "EntitiesDescriptor" {
+		entities = root.Entities
+	}
+
+	seen := map[string]bool{}
+	var certs []*x509.Certificate
+	for _, entity := range entities {
+		for _, idp := range entity.IDPSSODescriptors {
+			for _, key := range idp.KeyDescriptors {
+				if key.Use != "" && key.Use != "signing" {
+					continue
+				}
+
+				for _, data := range key.Certificates {
+					data = strings.Join(strings.Fields(data), "")
+					if seen[data] {
+						continue
+					}
+					seen[data] = true
+
+					der, err := base64.StdEncoding.DecodeString(data)
+					if err != nil {
+						return nil, fmt.Errorf("invalid certificate of entity %q: %w", entity.EntityID, err)
+					}
+					cert, err := x509.ParseCertificate(der)
+					if err != nil {
+						return nil, fmt.Errorf("invalid certificate of entity %q: %w", entity.EntityID, err)
+					}
+					certs = append(certs, cert)
+				}
+			}
+		}
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no identity provider signing certificates found")
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/pki"
+)
+
+func testSAMLCertificate(t *testing.T, cn string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func testSAMLIDPMetadata(entityID string, keys map[string]*x509.Certificate) string {
+	var descriptors []string
+	for use, cert := range keys {
+		useAttr := ""
+		if use != "" {
+			useAttr = fmt.Sprintf(` use=%q`, use)
+		}
+		// wrap the certificate like most IdPs do
+		data := base64.StdEncoding.EncodeToString(cert.Raw)
+		data = data[:32] + "\n        " + data[32:]
+		descriptors = append(descriptors, fmt.Sprintf(`
+    <md:KeyDescriptor%s>
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data>
+          <ds:X509Certificate>%s</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>`, useAttr, data))
+	}
+
+	return fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID=%q>
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">%s
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, entityID, strings.Join(descriptors, ""))
+}
+
+func TestSAMLSPMetadata(t *testing.T) {
+	metadata, err := samlSPMetadata("https://vault.example.com/v1/auth/saml", []string{
+		"https://vault-1.example.com/v1/auth/saml/callback",
+		"https://vault-2.example.com/v1/auth/saml/callback",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := xml.Header + `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://vault.example.com/v1/auth/saml">
+  <SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://vault-1.example.com/v1/auth/saml/callback" index="0" isDefault="true"></AssertionConsumerService>
+    <AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://vault-2.example.com/v1/auth/saml/callback" index="1"></AssertionConsumerService>
+  </SPSSODescriptor>
+</EntityDescriptor>
+`
+	if metadata != want {
+		t.Errorf("samlSPMetadata() = %s, want %s", metadata, want)
+	}
+}
+
+func TestParseSAMLIDPSigningCerts(t *testing.T) {
+	signing := testSAMLCertificate(t, "signing")
+	encryption := testSAMLCertificate(t, "encryption")
+	both := testSAMLCertificate(t, "both")
+
+	tests := []struct {
+		name     string
+		metadata string
+		want     []*x509.Certificate
+		wantErr  string
+	}{
+		{
+			name: "signing",
+			metadata: testSAMLIDPMetadata("https://idp.example.com", map[string]*x509.Certificate{
+				"signing":    signing,
+				"encryption": encryption,
+			}),
+			want: []*x509.Certificate{signing},
+		},
+		{
+			name: "no-use",
+			metadata: testSAMLIDPMetadata("https://idp.example.com", map[string]*x509.Certificate{
+				"": both,
+			}),
+			want: []*x509.Certificate{both},
+		},
+		{
+			name: "entities",
+			metadata: `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">` +
+				testSAMLIDPMetadata("https://idp-1.example.com", map[string]*x509.Certificate{"signing": signing}) +
+				testSAMLIDPMetadata("https://idp-2.example.com", map[string]*x509.Certificate{"signing": signing}) +
+				`</EntitiesDescriptor>`,
+			want: []*x509.Certificate{signing},
+		},
+		{
+			name: "no-signing-certs",
+			metadata: testSAMLIDPMetadata("https://idp.example.com", map[string]*x509.Certificate{
+				"encryption": encryption,
+			}),
+			wantErr: "no identity provider signing certificates found",
+		},
+		{
+			name:     "not-metadata",
+			metadata: `<html><body>Sign in</body></html>`,
+			wantErr:  `unexpected root element "html"`,
+		},
+		{
+			name: "invalid-cert",
+			metadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com">
+  <IDPSSODescriptor><KeyDescriptor><KeyInfo><X509Data><X509Certificate>Zm9v</X509Certificate></X509Data></KeyInfo></KeyDescriptor></IDPSSODescriptor>
+</EntityDescriptor>`,
+			wantErr: `invalid certificate of entity "https://idp.example.com"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSAMLIDPSigningCerts([]byte(tt.metadata))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSAMLIDPSigningCerts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSAMLAuthBackendCustomizeDiff_idpMetadataRefresh(t *testing.T) {
+	oldCert := testSAMLCertificate(t, "old")
+	newCert := testSAMLCertificate(t, "new")
+	_, oldFingerprint := pki.Fingerprints(oldCert)
+	_, newFingerprint := pki.Fingerprints(newCert)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/metadata" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(testSAMLIDPMetadata("https://idp.example.com", map[string]*x509.Certificate{
+			"signing": newCert,
+		})))
+	}))
+	defer server.Close()
+
+	state := func(fingerprint string) *terraform.InstanceState {
+		attributes := map[string]string{
+			"id":                   "saml",
+			"path":                 "saml",
+			"idp_metadata_url":     server.URL + "/metadata",
+			"idp_metadata_refresh": "true",
+			"entity_id":            "https://vault.example.com/v1/auth/saml",
+			"acs_urls.#":           "1",
+			"acs_urls.0":           "https://vault.example.com/v1/auth/saml/callback",
+		}
+		if fingerprint != "" {
+			attributes["idp_signing_cert_fingerprints.#"] = "1"
+			attributes["idp_signing_cert_fingerprints.0"] = fingerprint
+		}
+		return &terraform.InstanceState{ID: "saml", Attributes: attributes}
+	}
+	config := func(metadataURL string, refresh bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"idp_metadata_url":     metadataURL,
+			"idp_metadata_refresh": refresh,
+			"entity_id":            "https://vault.example.com/v1/auth/saml",
+			"acs_urls":             []interface{}{"https://vault.example.com/v1/auth/saml/callback"},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		state       *terraform.InstanceState
+		config      *terraform.ResourceConfig
+		wantDiff    bool
+		wantOld     string
+		wantNew     string
+		wantErr     string
+		wantRemoved bool
+	}{
+		{
+			name:     "rotated",
+			state:    state(oldFingerprint),
+			config:   config(server.URL+"/metadata", true),
+			wantDiff: true,
+			wantOld:  oldFingerprint,
+			wantNew:  newFingerprint,
+		},
+		{
+			name:   "unchanged",
+			state:  state(newFingerprint),
+			config: config(server.URL+"/metadata", true),
+		},
+		{
+			name:        "disabled",
+			state:       state(newFingerprint),
+			config:      config(server.URL+"/metadata", false),
+			wantDiff:    true,
+			wantRemoved: true,
+		},
+		{
+			name:    "unavailable",
+			state:   state(newFingerprint),
+			config:  config(server.URL+"/missing", true),
+			wantErr: "unexpected status 404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := samlAuthBackendResource()
+			diff, err := r.Diff(context.Background(), tt.state, tt.config, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var attr *terraform.ResourceAttrDiff
+			if diff != nil {
+				attr = diff.Attributes["idp_signing_cert_fingerprints.0"]
+			}
+			if !tt.wantDiff {
+				if attr != nil {
+					t.Fatalf("expected no fingerprint diff, got %#v", attr)
+				}
+				return
+			}
+
+			if attr == nil {
+				t.Fatalf("expected a fingerprint diff, got %#v", diff)
+			}
+			if tt.wantRemoved {
+				if !attr.NewRemoved {
+					t.Errorf("expected the fingerprint to be removed, got %#v", attr)
+				}
+				return
+			}
+			if attr.Old != tt.wantOld || attr.New != tt.wantNew {
+				t.Errorf("expected fingerprint diff %q => %q, got %q => %q", tt.wantOld, tt.wantNew, attr.Old, attr.New)
+			}
+		})
+	}
+}
@@ -8,13 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/vault/api"
 
+	"github.com/hashicorp/terraform-provider-vault/internal/consts"
 	"github.com/hashicorp/terraform-provider-vault/internal/provider"
+	"github.com/hashicorp/terraform-provider-vault/internal/sentinel"
 )
 
+var sentinelTestNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 func readSentinelPolicy(client *api.Client, policyType string, name string) (map[string]interface{}, error) {
 	r := client.NewRequest("GET", fmt.Sprintf("/v1/sys/policies/%s/%s", policyType, name))
 
@@ -140,3 +146,118 @@ func sentinelPolicyWrite(policyType string, attributes []string, d *schema.Resou
 
 	return sentinelPolicyRead(policyType, attributes, d, meta)
 }
+
+// sentinelPolicyTestSchema is the schema of the test blocks of the Sentinel
+// policy resources, they are only run locally and never sent to Vault.
+func sentinelPolicyTestSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Description: "Test cases run at plan time with the sentinel binary configured in the provider. " +
+			"The tests are not sent to Vault.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				consts.FieldName: {
+					Type:     schema.TypeString,
+					Required: true,
+					Description: "Name of the test case. " +
+						"Must only contain letters, digits, dashes and underscores.",
+					ValidateFunc: validation.StringMatch(sentinelTestNameRegex,
+						"must only contain letters, digits, dashes and underscores"),
+				},
+				consts.FieldGlobals: {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "JSON encoded values of the globals available to the policy, such as request or identity.",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsJSON,
+					},
+				},
+				consts.FieldExpectedResult: {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Expected result of the main rule.",
+				},
+			},
+		},
+	}
+}
+
+func sentinelPolicyTestCases(raw []interface{}) ([]*sentinel.TestCase, error) {
+	var cases []*sentinel.TestCase
+	names := map[string]bool{}
+	for _, v := range raw {
+		m := v.(map[string]interface{})
+		tc := &sentinel.TestCase{
+			Name:     m[consts.FieldName].(string),
+			Expected: m[consts.FieldExpectedResult].(bool),
+			Globals:  map[string]string{},
+		}
+
+		if names[tc.Name] {
+			return nil, fmt.Errorf("duplicate %s name %q", consts.FieldTest, tc.Name)
+		}
+		names[tc.Name] = true
+
+		for k, g := range m[consts.FieldGlobals].(map[string]interface{}) {
+			tc.Globals[k] = g.(string)
+		}
+		cases = append(cases, tc)
+	}
+
+	return cases, nil
+}
+
+// sentinelPolicyCustomizeDiff validates the Sentinel source at plan time.
+// If the provider has a sentinel binary configured, the source is parsed with
+// sentinel fmt and its test cases are run, otherwise only basic checks are
+// done.
+func sentinelPolicyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("policy") {
+		return nil
+	}
+
+	name := diff.Get("name").(string)
+	source := diff.Get("policy").(string)
+
+	var cases []*sentinel.TestCase
+	if diff.NewValueKnown(consts.FieldTest) {
+		var err error
+		cases, err = sentinelPolicyTestCases(diff.Get(consts.FieldTest).([]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	binary, err := provider.GetSentinelBinary(meta)
+	if err != nil {
+		return err
+	}
+
+	if binary == "" {
+		if err := sentinel.Validate(source); err != nil {
+			return fmt.Errorf("invalid Sentinel policy %q: %w", name, err)
+		}
+		if len(cases) > 0 {
+			return fmt.Errorf("the %s blocks of Sentinel policy %q require the provider's %s to be set",
+				consts.FieldTest, name, consts.FieldSentinelBinary)
+		}
+		return nil
+	}
+
+	runner := &sentinel.Runner{Binary: binary}
+
+	log.Printf("[DEBUG] Parsing Sentinel policy %q with %s", name, binary)
+	if err := runner.Fmt(ctx, source); err != nil {
+		return fmt.Errorf("Sentinel policy %q: %w", name, err)
+	}
+
+	log.Printf("[DEBUG] Running %d tests of Sentinel policy %q with %s", len(cases), name, binary)
+	if err := runner.Test(ctx, source, cases); err != nil {
+		return fmt.Errorf("Sentinel policy %q: %w", name, err)
+	}
+
+	return nil
+}
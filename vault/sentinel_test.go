@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package vault
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-vault/internal/sentinel"
+)
+
+func TestSentinelPolicyTestCases(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{
+			"name":            "read",
+			"expected_result": true,
+			"globals": map[string]interface{}{
+				"request": `{"operation": "read"}`,
+			},
+		},
+		map[string]interface{}{
+			"name":            "delete",
+			"expected_result": false,
+			"globals":         map[string]interface{}{},
+		},
+	}
+
+	actual, err := sentinelPolicyTestCases(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*sentinel.TestCase{
+		{
+			Name:     "read",
+			Expected: true,
+			Globals:  map[string]string{"request": `{"operation": "read"}`},
+		},
+		{
+			Name:     "delete",
+			Expected: false,
+			Globals:  map[string]string{},
+		},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %#v, got %#v", expected, actual)
+	}
+
+	if _, err := sentinelPolicyTestCases(append(raw, raw[0])); err == nil {
+		t.Error("expected an error for duplicate test names")
+	}
+}